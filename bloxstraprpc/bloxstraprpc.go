@@ -8,8 +8,6 @@ package bloxstraprpc
 import (
 	"fmt"
 	"log/slog"
-	"regexp"
-	"strings"
 	"time"
 
 	"github.com/altfoxie/drpc"
@@ -17,21 +15,10 @@ import (
 
 const Reset = "<reset>"
 
-const (
-	GameJoinRequestEntry = "[FLog::GameJoinUtil] GameJoinUtil::makePlaceLauncherRequest"
-	GameJoiningEntry     = "[FLog::Output] ! Joining game"
-	GameJoinReportEntry  = "[FLog::GameJoinLoadTime] Report game_join_loadtime:"
-	GameJoinedEntry      = "[FLog::Output] Connection accepted from"
-	BloxstrapRPCEntry    = "[FLog::Output] [BloxstrapRPC]"
-	GameLeaveEntry       = "[FLog::SingleSurfaceApp] leaveUGCGameInternal"
-)
-
-var (
-	GameJoinRequestEntryPattern = regexp.MustCompile(`makePlaceLauncherRequest(ForTeleport)?: requestCount: [0-9], url: https:\/\/gamejoin\.roblox\.com\/v1\/([^\s\/]+)`)
-	GameJoiningEntryPattern     = regexp.MustCompile(`! Joining game '([0-9a-f\-]{36})'`)
-	GameJoinReportEntryPattern  = regexp.MustCompile(`Report game_join_loadtime: placeid:([0-9]+).*universeid:([0-9]+)`)
-)
+// BloxstrapRPCEntry is the Roblox log entry of a BloxstrapRPC message.
+const BloxstrapRPCEntry = "[FLog::Output] [BloxstrapRPC]"
 
+// ServerType is the type of a Roblox game server.
 type ServerType int
 
 const (
@@ -40,113 +27,55 @@ const (
 	Reserved
 )
 
+// Game is the game shown in the presence.
+type Game struct {
+	PlaceID    string
+	UniverseID string
+	JobID      string
+	Server     ServerType
+}
+
 type Activity struct {
 	presence drpc.Activity
 	client   *drpc.Client
 
-	gameTime    time.Time
-	teleporting bool
-	server      ServerType
+	gameTime time.Time
+	game     Game
+	location string
+	state    string // state set by UpdateGamePresence
 
-	universeID string
-	placeID    string
-	jobID      string
+	fetch bool
+	games map[string]GameInfo
 }
 
-func New() Activity {
+// New returns a new Activity. If fetch is true, the name and icon of the
+// game being played are fetched with the Roblox API, otherwise a generic
+// presence is shown.
+func New(fetch bool) Activity {
 	c, _ := drpc.New("1159891020956323923")
 	return Activity{
 		client: c,
+		fetch:  fetch,
 	}
 }
 
-// HandleRobloxLog handles the given Roblox log entry, to set data
-// and call functions based on the log entry, declared as *Entry(Pattern) constants.
-func (a *Activity) HandleRobloxLog(line string) error {
-	entries := map[string]func(string) error{
-		// In order of which it should appear in log file
-		GameJoinRequestEntry: a.handleGameJoinRequest,                              // For game join type is private, reserved
-		GameJoiningEntry:     a.handleGameJoining,                                  // For JobID (server ID, to join from Discord)
-		GameJoinReportEntry:  a.handleGameJoinReport,                               // For PlaceID and UniverseID
-		GameJoinedEntry:      func(_ string) error { return a.handleGameJoined() }, // Sets presence and time
-		BloxstrapRPCEntry:    a.handleBloxstrapRPC,                                 // BloxstrapRPC
-		GameLeaveEntry:       func(_ string) error { return a.handleGameLeave() },  // Clears presence and time
-	}
-
-	for e, h := range entries {
-		if strings.Contains(line, e) {
-			return h(line)
-		}
-	}
-
-	return nil
+// JoinGame sets the presence to the given game, joined at the given time.
+func (a *Activity) JoinGame(g Game, t time.Time) error {
+	a.gameTime = t
+	return a.TeleportGame(g)
 }
 
-func (a *Activity) handleGameJoinRequest(line string) error {
-	m := GameJoinRequestEntryPattern.FindStringSubmatch(line)
-	// There are multiple outputs for makePlaceLauncherRequest
-	if len(m) != 3 {
-		return fmt.Errorf("log game join request entry is invalid")
-	}
-
-	if m[1] == "ForTeleport" {
-		a.teleporting = true
-	}
-
-	// Keep up to date from upstream Roblox GameJoin API
-	a.server = map[string]ServerType{
-		"join-private-game":       Private,
-		"join-reserved-game":      Reserved,
-		"join-game":               Public,
-		"join-game-instance":      Public,
-		"join-play-together-game": Public,
-	}[m[2]]
-
-	slog.Info("Handled GameJoinRequest", "server_type", a.server, "teleporting", a.teleporting)
-
-	return nil
-}
-
-func (a *Activity) handleGameJoining(line string) error {
-	m := GameJoiningEntryPattern.FindStringSubmatch(line)
-	if len(m) != 2 {
-		return fmt.Errorf("log game joining entry is invalid")
-	}
-
-	a.jobID = m[1]
-
-	slog.Info("Handled GameJoining", "jobid", a.jobID)
-
-	return nil
-}
-
-func (a *Activity) handleGameJoinReport(line string) error {
-	m := GameJoinReportEntryPattern.FindStringSubmatch(line)
-	if len(m) != 3 {
-		return fmt.Errorf("log game join report entry is invalid")
-	}
-
-	a.placeID = m[1]
-	a.universeID = m[2]
-
-	slog.Info("Handled GameJoinReport", "universeid", a.universeID, "placeid", a.placeID)
-
-	return nil
-}
-
-func (a *Activity) handleGameJoined() error {
-	if !a.teleporting {
-		a.gameTime = time.Now()
-	}
-
-	a.teleporting = false
-
-	slog.Info("Handled GameJoined", "time", a.gameTime)
-
+// TeleportGame sets the presence to the given game, keeping the time
+// the previous game was joined at.
+func (a *Activity) TeleportGame(g Game) error {
+	a.game = g
+	a.location = ""
 	return a.UpdateGamePresence(true)
 }
 
-func (a *Activity) handleBloxstrapRPC(line string) error {
+// HandleMessage applies the given BloxstrapRPC message log entry
+// to the presence.
+func (a *Activity) HandleMessage(line string) error {
 	m, err := NewMessage(line)
 	if err != nil {
 		return fmt.Errorf("parse bloxstraprpc message: %w", err)
@@ -158,14 +87,28 @@ func (a *Activity) handleBloxstrapRPC(line string) error {
 	return a.UpdateGamePresence(false)
 }
 
-func (a *Activity) handleGameLeave() error {
+// SetLocation shows the given location of the game server with the given
+// job ID in the presence's state, unless the state was set by the game.
+func (a *Activity) SetLocation(jobID, location string) error {
+	if jobID != a.game.JobID {
+		return nil
+	}
+	a.location = location
+
+	if a.presence.State == a.state {
+		a.presence.State = Reset
+	}
+
+	return a.UpdateGamePresence(false)
+}
+
+// LeaveGame clears the presence.
+func (a *Activity) LeaveGame() error {
 	a.presence = drpc.Activity{}
 	a.gameTime = time.Time{}
-	a.teleporting = false
-	a.server = Public
-	a.universeID = ""
-	a.placeID = ""
-	a.jobID = ""
+	a.game = Game{}
+	a.location = ""
+	a.state = ""
 
 	slog.Info("Handled GameLeave")
 
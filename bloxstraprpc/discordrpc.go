@@ -4,7 +4,6 @@ import (
 	"log/slog"
 
 	"github.com/altfoxie/drpc"
-	"github.com/vinegarhq/vinegar/roblox/api"
 )
 
 func (a *Activity) Connect() error {
@@ -25,11 +24,11 @@ func (a *Activity) Close() error {
 func (a *Activity) UpdateGamePresence(initial bool) error {
 	a.presence.Buttons = []drpc.Button{{
 		Label: "See game page",
-		URL:   "https://www.roblox.com/games/" + a.placeID,
+		URL:   "https://www.roblox.com/games/" + a.game.PlaceID,
 	}}
 
-	if a.server == Public {
-		joinurl := "roblox://experiences/start?placeId=" + a.placeID + "&gameInstanceId=" + a.jobID
+	if a.game.Server == Public {
+		joinurl := "roblox://experiences/start?placeId=" + a.game.PlaceID + "&gameInstanceId=" + a.game.JobID
 		a.presence.Buttons = append(a.presence.Buttons, drpc.Button{
 			Label: "Join server",
 			URL:   joinurl,
@@ -42,39 +41,50 @@ func (a *Activity) UpdateGamePresence(initial bool) error {
 
 	if initial || (a.presence.Details == Reset ||
 		a.presence.State == Reset ||
-		a.presence.Assets.LargeText == Reset) {
-		gd, err := api.GetGameDetails(a.universeID)
-		if err != nil {
-			return err
+		a.presence.Assets.LargeText == Reset ||
+		a.presence.Assets.LargeImage == Reset) {
+		gi := GameInfo{Name: "Roblox", Icon: "roblox"}
+		if a.fetch {
+			var err error
+			gi, err = a.gameInfo(a.game.UniverseID)
+			if err != nil {
+				return err
+			}
 		}
 
 		if initial || a.presence.Details == Reset {
-			a.presence.Details = "Playing " + gd.Name
+			a.presence.Details = "Playing " + gi.Name
 		}
 
 		if initial || a.presence.State == Reset {
-			a.presence.State = "by " + gd.Creator.Name
+			a.presence.State = ""
+			if gi.Creator != "" {
+				a.presence.State = "by " + gi.Creator
+			}
 
-			switch a.server {
+			switch a.game.Server {
 			case Private:
 				a.presence.State = "In a private server"
 			case Reserved:
 				a.presence.State = "In a reserved server"
 			}
+
+			if a.location != "" {
+				if a.presence.State != "" {
+					a.presence.State += " · "
+				}
+				a.presence.State += a.location
+			}
+			a.state = a.presence.State
 		}
 
 		if initial || a.presence.Assets.LargeText == Reset {
-			a.presence.Assets.LargeText = gd.Name
+			a.presence.Assets.LargeText = gi.Name
 		}
-	}
 
-	if initial || a.presence.Assets.LargeImage == Reset {
-		tn, err := api.GetGameIcon(a.universeID, "PlaceHolder", "512x512", "Png", false)
-		if err != nil {
-			return err
+		if initial || a.presence.Assets.LargeImage == Reset {
+			a.presence.Assets.LargeImage = gi.Icon
 		}
-
-		a.presence.Assets.LargeImage = tn.ImageURL
 	}
 
 	if initial || a.presence.Assets.SmallImage == Reset {
@@ -0,0 +1,119 @@
+package bloxstraprpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/roblox/api"
+)
+
+// GameCacheExpiry is the duration after which cached game information
+// is fetched again, for changes to a game's name or icon to be shown.
+const GameCacheExpiry = 24 * time.Hour
+
+// GameCachePath is the file in which game information is cached.
+var GameCachePath = filepath.Join(dirs.Cache, "games.json")
+
+// GameInfo is the information of a Roblox game shown in the presence.
+type GameInfo struct {
+	Name    string    `json:"name"`
+	Creator string    `json:"creator"`
+	Icon    string    `json:"icon"`
+	Fetched time.Time `json:"fetched"`
+}
+
+// gameInfo returns the information of the game with the named universe ID,
+// fetching it with the Roblox API if it is not cached or has expired. If
+// fetching fails, the expired information is used if available.
+func (a *Activity) gameInfo(universeID string) (GameInfo, error) {
+	if a.games == nil {
+		a.games = loadGames(GameCachePath)
+	}
+
+	cached, ok := a.games[universeID]
+	if ok && time.Since(cached.Fetched) < GameCacheExpiry {
+		return cached, nil
+	}
+
+	gi, err := fetchGameInfo(universeID)
+	if err != nil {
+		if ok {
+			slog.Warn("Using expired game information", "universe", universeID, "error", err)
+			return cached, nil
+		}
+
+		return GameInfo{}, err
+	}
+
+	a.games[universeID] = gi
+	if err := saveGames(GameCachePath, a.games); err != nil {
+		slog.Warn("Could not cache game information", "error", err)
+	}
+
+	return gi, nil
+}
+
+func fetchGameInfo(universeID string) (GameInfo, error) {
+	gd, err := api.GetGameDetails(universeID)
+	if err != nil {
+		return GameInfo{}, fmt.Errorf("game details: %w", err)
+	}
+
+	tn, err := api.GetGameIcon(universeID, "PlaceHolder", "512x512", "Png", false)
+	if err != nil {
+		return GameInfo{}, fmt.Errorf("game icon: %w", err)
+	}
+
+	return GameInfo{
+		Name:    gd.Name,
+		Creator: gd.Creator.Name,
+		Icon:    tn.ImageURL,
+		Fetched: time.Now(),
+	}, nil
+}
+
+func loadGames(name string) map[string]GameInfo {
+	games := make(map[string]GameInfo)
+
+	f, err := os.ReadFile(name)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("Could not read game cache", "error", err)
+		}
+		return games
+	}
+
+	if err := json.Unmarshal(f, &games); err != nil {
+		slog.Warn("Ignoring invalid game cache", "error", err)
+		return make(map[string]GameInfo)
+	}
+
+	return games
+}
+
+// saveGames writes the given games to the named file, removing games that
+// have long expired to keep the cache small.
+func saveGames(name string, games map[string]GameInfo) error {
+	for id, gi := range games {
+		if time.Since(gi.Fetched) > GameCacheExpiry*30 {
+			delete(games, id)
+		}
+	}
+
+	f, err := json.Marshal(games)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, f, 0o644)
+}
@@ -0,0 +1,63 @@
+package bloxstraprpc
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vinegarhq/vinegar/roblox/api"
+)
+
+func TestGameInfo(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/v1/games":
+			fmt.Fprint(w, `{"data":[{"name":"Meow Obby","creator":{"name":"Purr"}}]}`)
+		case "/v1/games/icons":
+			fmt.Fprint(w, `{"data":[{"imageUrl":"https://meow.invalid/icon.png"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	for _, service := range []string{"games", "thumbnails"} {
+		api.SetServiceURL(service, srv.URL)
+		defer api.SetServiceURL(service, "")
+	}
+
+	GameCachePath = filepath.Join(t.TempDir(), "games.json")
+
+	a := New(true)
+	gi, err := a.gameInfo("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gi.Name != "Meow Obby" || gi.Creator != "Purr" || gi.Icon != "https://meow.invalid/icon.png" {
+		t.Errorf("game info %+v, want fetched game info", gi)
+	}
+
+	// A new Activity should use the cached game information
+	a = New(true)
+	if gi, err := a.gameInfo("1"); err != nil || gi.Name != "Meow Obby" || requests != 2 {
+		t.Errorf("game info %+v after %d requests, want cached game info", gi, requests)
+	}
+
+	// Expired game information is used if it could not be fetched
+	srv.Close()
+	gi.Fetched = time.Now().Add(-GameCacheExpiry)
+	a.games["1"] = gi
+	if gi, err := a.gameInfo("1"); err != nil || gi.Name != "Meow Obby" {
+		t.Errorf("game info %+v, want expired game info", gi)
+	}
+
+	if _, err := a.gameInfo("2"); err == nil {
+		t.Error("expected uncached game fetch error")
+	}
+}
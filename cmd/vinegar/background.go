@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// DetachedEnv is the environment variable set for the Vinegar process
+// started by Detach, to tell it not to detach again.
+const DetachedEnv = "VINEGAR_DETACHED"
+
+// Detached determines if the running Vinegar process was started by Detach.
+func Detached() bool {
+	return os.Getenv(DetachedEnv) == "1"
+}
+
+// Detach re-executes Vinegar with the same arguments as a background process
+// in a new session, without standard input and output nor a controlling
+// terminal. The background process will handle the Binary for the rest
+// of its session - including the Roblox log file and Discord RPC - and
+// will log to its own log file.
+func Detach() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("executable: %w", err)
+	}
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), DetachedEnv+"=1")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	slog.Info("Detached to background", "pid", cmd.Process.Pid)
+
+	return cmd.Process.Release()
+}
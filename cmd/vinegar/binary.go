@@ -1,27 +1,37 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
-	"github.com/fsnotify/fsnotify"
-	"github.com/godbus/dbus/v5"
 	"github.com/lmittmann/tint"
-	"github.com/nxadm/tail"
 	slogmulti "github.com/samber/slog-multi"
 	bsrpc "github.com/vinegarhq/vinegar/bloxstraprpc"
 	"github.com/vinegarhq/vinegar/config"
 	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/events"
+	"github.com/vinegarhq/vinegar/internal/notify"
+	"github.com/vinegarhq/vinegar/internal/obs"
+	"github.com/vinegarhq/vinegar/internal/plugins"
+	"github.com/vinegarhq/vinegar/internal/scripts"
+	"github.com/vinegarhq/vinegar/internal/session"
 	"github.com/vinegarhq/vinegar/internal/state"
 	"github.com/vinegarhq/vinegar/roblox"
 	boot "github.com/vinegarhq/vinegar/roblox/bootstrapper"
+	"github.com/vinegarhq/vinegar/roblox/protocol"
 	"github.com/vinegarhq/vinegar/splash"
 	"github.com/vinegarhq/vinegar/sysinfo"
 	"github.com/vinegarhq/vinegar/wine"
@@ -31,45 +41,146 @@ import (
 const (
 	LogTimeout = 6 * time.Second
 	DieTimeout = 3 * time.Second
+
+	// LogPollInterval is the interval in which the Roblox log directory
+	// is polled for new log files, when it cannot be watched.
+	LogPollInterval = time.Second
+
+	// LogIdleTimeout is the time after which a tailed Roblox log file,
+	// once a newer log file was made, is no longer tailed if it was not
+	// written to, as the process writing it has most likely exited.
+	LogIdleTimeout = 30 * time.Second
+
+	// WatchdogDelay is the time to wait before relaunching Roblox
+	// after it had exited unexpectedly.
+	WatchdogDelay = 2 * time.Second
+
+	// HandoffTimeout is the time to wait for Roblox to hand over a launch
+	// to the running session after it had shut down, such as when
+	// teleporting to another universe.
+	HandoffTimeout = 3 * time.Second
+)
+
+var (
+	ErrNoRobloxLog     = errors.New("wine never produced a roblox log file")
+	ErrBadAccount      = errors.New("invalid account name")
+	AccountNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 )
 
 const (
 	DialogUseBrowser = "WebView/InternalBrowser is broken, please use the browser for the action that you were doing."
 	DialogQuickLogin = "WebView/InternalBrowser is broken, use Quick Log In to authenticate ('Log In With Another Device' button)"
 	DialogFailure    = "Vinegar experienced an error:\n%s"
+	DialogPanic      = "Vinegar has crashed! Please report this along with the log file:\n%s"
+	DialogCrash      = "Roblox has crashed! A bug report was saved to:\n%s\nOpen its location?"
+	DialogReplace    = "Roblox is already running, leave the current game for the new launch?"
+	DialogOrphaned   = "Processes of a previous Roblox session are still running, which may prevent Roblox from launching. Kill them?"
+	DialogChannel    = "Roblox has requested to switch to the %s channel, which will install its version of Roblox. Switch channels?"
 	DialogNoAVX      = "Warning: Your CPU does not support AVX. While some people may be able to run without it, most are not able to. VinegarHQ cannot provide support for your installation. Continue?"
 )
 
 type Binary struct {
 	// Only initialized in Main
-	Splash *splash.Splash
+	Splash splash.Backend
 
 	GlobalState *state.State
 	State       *state.Binary
+	PrefixState *state.Prefix
 
 	GlobalConfig *config.Config
 	Config       *config.Binary
 
-	Alias  string
-	Name   string
-	Dir    string
-	Prefix *wine.Prefix
-	Type   roblox.BinaryType
-	Deploy *boot.Deployment
+	Alias   string
+	Account string
+	Name    string
+	Dir     string
+	Prefix  *wine.Prefix
+	Type    roblox.BinaryType
+	Deploy  *boot.Deployment
+
+	// Only set once a game's configuration is applied
+	base    *config.Binary
+	placeID string
+
+	// Discord RPC connection, only connected once a game is joined
+	activityMu   sync.Mutex
+	activity     bool
+	activityIdle *time.Timer // disconnects while idling in the menus
+
+	prefixInit <-chan error // result of the wineprefix initialization
+
+	// Only set in Main
+	logPath    string
+	stderr     io.Writer // the terminal, or the TUI drawing on it
+	logOutput  io.Writer
+	logLimit   lineLimiter
+	sessionDir string
+	launches   int
+
+	// Only set if the Binary was given a protocol URI
+	URI *protocol.URI
+
+	// Only set during Setup
+	timing *state.SetupTiming
+
+	// Execution trace task of the launch, only set in Main
+	trace context.Context
 
 	// Logging
 	Auth     bool
 	Activity bsrpc.Activity
+
+	// Events of the Roblox session, parsed from the Roblox log file
+	Events events.Bus
+
+	// Roblox process supervision, set during Execute
+	running   atomic.Bool
+	killed    atomic.Bool
+	shutdown  atomic.Bool
+	dieTimer  atomic.Pointer[time.Timer] // force kill after Roblox shut down
+	crashLog  atomic.Bool                // a crash was logged by Roblox
+	scopeUnit string                     // systemd scope of the Roblox command, if any
+	robloxLog atomic.Value               // path of the last tailed Roblox log file
+	game      events.Game
+	handoff   chan []string
+
+	// OBS WebSocket connection, only connected once a game is joined,
+	// and only used by the goroutine handling the queued OBS events
+	obs          *obs.Client
+	obsRecording bool
+	obsFailed    time.Time // when connecting or a request last failed
+	obsQueue     chan events.Event
+	obsDone      chan struct{}
+
+	// Plugins run for each session event, only started in Main
+	plugins *plugins.Host
+
+	// Scripts hooking into the launch, only loaded in Run
+	scripts []*scripts.Script
 }
 
-func BinaryPrefixDir(bt roblox.BinaryType) string {
-	return filepath.Join(dirs.Prefixes, strings.ToLower(bt.String()))
+// BinaryPrefixDir returns the wineprefix directory of the named account
+// for the given Binary type, the default account has no name.
+func BinaryPrefixDir(bt roblox.BinaryType, account string) string {
+	name := strings.ToLower(bt.String())
+	if account != "" {
+		name += "-" + account
+	}
+
+	return filepath.Join(dirs.Prefixes, name)
 }
 
-func NewBinary(bt roblox.BinaryType, cfg *config.Config) (*Binary, error) {
+// NewBinary returns a new Binary for the given Binary type using the
+// named account, the default account has no name. If the account has
+// a profile, its configuration is used.
+func NewBinary(bt roblox.BinaryType, account string, cfg *config.Config) (*Binary, error) {
 	var bcfg *config.Binary
 	var bstate *state.Binary
 
+	if account != "" && !AccountNamePattern.MatchString(account) {
+		return nil, fmt.Errorf("%w: %q", ErrBadAccount, account)
+	}
+
 	s, err := state.Load()
 	if err != nil {
 		return nil, fmt.Errorf("load state: %w", err)
@@ -84,46 +195,118 @@ func NewBinary(bt roblox.BinaryType, cfg *config.Config) (*Binary, error) {
 		bstate = &s.Studio
 	}
 
-	pfx, err := wine.New(BinaryPrefixDir(bt), bcfg.WineRoot)
+	if _, ok := bcfg.Profiles[account]; ok {
+		slog.Info("Using profile", "name", account)
+
+		pcfg := bcfg.ForProfile(account)
+		bcfg = &pcfg
+	}
+
+	r, err := BinaryRunner(bcfg, bstate)
+	if err != nil {
+		return nil, fmt.Errorf("%s runner: %w", bt, err)
+	}
+
+	// As with the configuration's setup, a missing emulator is not fatal,
+	// Wine is ran directly instead.
+	emu, err := cfg.EmulatorPath()
+	if err != nil {
+		slog.Warn("Running Wine without an emulator", "error", err)
+	}
+
+	pfx, err := wine.New(BinaryPrefixDir(bt, account), wine.Emulate(r, emu))
 	if err != nil {
 		return nil, fmt.Errorf("new prefix %s: %w", bt, err)
 	}
 
 	os.Setenv("GAMEID", "ulwgl-roblox")
 
-	return &Binary{
-		Activity: bsrpc.New(),
+	b := &Binary{
+		Activity: bsrpc.New(bcfg.RPCGameInfo),
 
 		GlobalState: &s,
 		State:       bstate,
+		PrefixState: bstate.AccountPrefix(account),
 
 		GlobalConfig: cfg,
 		Config:       bcfg,
 
-		Alias:  bt.String(),
-		Name:   bt.BinaryName(),
-		Type:   bt,
-		Prefix: pfx,
-	}, nil
+		Alias:   bt.String(),
+		Account: account,
+		Name:    bt.BinaryName(),
+		Type:    bt,
+		Prefix:  pfx,
+	}
+	b.subscribe()
+
+	return b, nil
 }
 
 func (b *Binary) Main(args ...string) int {
+	if b.Config.Background && !Detached() {
+		if err := Detach(); err != nil {
+			slog.Error(fmt.Sprintf("detach: %s", err))
+			return 1
+		}
+
+		return 0
+	}
+
 	logFile, err := LogFile(b.Type.String())
 	if err != nil {
 		slog.Error(fmt.Sprintf("create log file: %s", err))
 		return 1
 	}
 	defer logFile.Close()
+	b.logPath = logFile.Name()
+	b.sessionDir = strings.TrimSuffix(b.logPath, ".log")
+	pruneSessions(b.Type.String())
+
+	ctx, stopTrace, err := StartTrace(b.Alias)
+	if err != nil {
+		slog.Error(fmt.Sprintf("trace %s: %s", TracePath, err))
+		return 1
+	}
+	defer stopTrace()
+	b.trace = ctx
+
+	// The TUI draws below the log, which must be written through it.
+	var tui *TUI
+	b.stderr = os.Stderr
+	kind := SplashKind(&b.GlobalConfig.Splash)
+	if kind == splash.KindTerminal {
+		tui = NewTUI(os.Stdin, os.Stderr)
+		defer tui.Close()
+		b.stderr = tui
+		b.Prefix.Stderr = tui
+		b.Prefix.Stdout = tui
+	}
+
+	b.logOutput = b.robloxLogOutput(logFile)
+	b.logLimit.rate = b.GlobalConfig.RobloxLogRate
 
 	slog.SetDefault(slog.New(slogmulti.Fanout(
-		tint.NewHandler(os.Stderr, nil),
+		tint.NewHandler(b.stderr, nil),
 		tint.NewHandler(logFile, &tint.Options{NoColor: true}),
 	)))
 
-	b.Splash = splash.New(&b.GlobalConfig.Splash)
+	// Nothing is shown in the splash window when there is no
+	// deployment to check, only its dialogs.
+	if tui != nil {
+		b.Splash = tui
+	} else {
+		b.Splash = splash.NewBackend(kind, &b.GlobalConfig.Splash, b.verified())
+	}
 	b.Config.Env.Setenv()
+	defer b.recoverPanic()
+
+	if stop := b.startPlugins(); stop != nil {
+		defer stop()
+	}
 
 	go func() {
+		defer b.recoverPanic()
+
 		err := b.Splash.Run()
 		if errors.Is(splash.ErrClosed, err) {
 			slog.Warn("Splash window closed!")
@@ -147,8 +330,8 @@ func (b *Binary) Main(args ...string) int {
 	if err != nil {
 		slog.Error(err.Error())
 
-		if b.GlobalConfig.Splash.Enabled && !term.IsTerminal(int(os.Stderr.Fd())) {
-			b.Splash.LogPath = logFile.Name()
+		if b.dialogs() && !term.IsTerminal(int(os.Stderr.Fd())) {
+			b.Splash.SetLogPath(logFile.Name())
 			b.Splash.SetMessage("Oops!")
 			b.Splash.Dialog(fmt.Sprintf(DialogFailure, err), false) // blocks
 		}
@@ -160,19 +343,41 @@ func (b *Binary) Main(args ...string) int {
 }
 
 func (b *Binary) Run(args ...string) error {
+	if b.Handoff(args) {
+		return nil
+	}
+
+	b.reapOrphaned()
+
+	if err := b.checkPlaytime(); err != nil {
+		return err
+	}
+
+	done := b.region("init")
 	if err := b.Init(); err != nil {
 		return fmt.Errorf("init %s: %w", b.Type, err)
 	}
+	done()
 
-	if len(args) == 1 && args[0] == "roblox-" {
-		b.HandleProtocolURI(args[0])
+	if len(args) == 1 && protocol.IsProtocol(args[0]) {
+		// Roblox may introduce URIs unknown to the parser, which should
+		// still be given to Roblox as-is.
+		if err := b.HandleProtocolURI(args[0]); err != nil {
+			slog.Error("Failed to parse protocol URI, passing as-is", "error", err)
+		}
+	}
+
+	if err := b.runLaunchScripts(); err != nil {
+		return fmt.Errorf("scripts: %w", err)
 	}
 
 	b.Splash.SetDesc(b.Config.Channel)
 
+	done = b.region("setup")
 	if err := b.Setup(); err != nil {
 		return fmt.Errorf("failed to setup roblox: %w", err)
 	}
+	done()
 
 	if err := b.Execute(args...); err != nil {
 		return fmt.Errorf("failed to run roblox: %w", err)
@@ -194,59 +399,141 @@ func (b *Binary) Init() error {
 
 	// Command-line flag vs wineprefix initialized
 	if firstRun || FirstRun {
-		slog.Info("Initializing wineprefix", "dir", b.Prefix.Dir())
-		b.Splash.SetMessage("Initializing wineprefix")
+		// The wineprefix is initialized alongside the deployment being
+		// installed, which is waited for by SetupPrefix.
+		done := make(chan error, 1)
+		b.prefixInit = done
+
+		go func() {
+			defer b.recoverPanic()
+			done <- b.InitPrefix()
+		}()
+	}
+
+	return nil
+}
 
-		var err error
+// InitPrefix initializes the Binary's wineprefix.
+func (b *Binary) InitPrefix() error {
+	defer b.region("prefix init")()
+
+	slog.Info("Initializing wineprefix", "dir", b.Prefix.Dir())
+	b.Splash.SetMessage("Initializing wineprefix")
+
+	err := b.withTimeout("Initializing wineprefix", PrefixInitTimeout, func() error {
 		switch b.Type {
 		case roblox.Player:
-			err = b.Prefix.Init()
+			return b.Prefix.Init()
 		case roblox.Studio:
 			// Studio accepts all DPIs except the default, which is 96.
 			// Technically this is 'initializing wineprefix', as SetDPI calls Wine which
 			// automatically create the Wineprefix.
-			err = b.Prefix.SetDPI(97)
+			return b.Prefix.SetDPI(97)
 		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to init %s prefix: %w", b.Type, err)
+	}
+
+	return nil
+}
 
+// SetupPrefix waits for the wineprefix initialization started by Init,
+// if any, installing WebView within it if required by the deployment, and
+// sets up the wineprefix's configuration.
+func (b *Binary) SetupPrefix() error {
+	defer b.region("prefix setup")()
+
+	if b.prefixInit != nil {
+		slog.Info("Waiting for wineprefix initialization")
+
+		done := b.region("prefix init wait")
+		err := <-b.prefixInit
+		done()
+		b.prefixInit = nil
 		if err != nil {
-			return fmt.Errorf("failed to init %s prefix: %w", b.Type, err)
+			return err
 		}
 
-		if err := b.InstallWebView(); err != nil {
+		// WebView is installed once the deployment is, as whether
+		// it is required is determined by the deployment.
+		if b.legacy() {
+			slog.Info("Skipping WebView installation for legacy deployment")
+		} else if err := b.InstallWebView(); err != nil {
+			b.notify(notify.Notification{
+				Summary: "WebView installation failed",
+				Body:    err.Error(),
+				Urgency: notify.Critical,
+			})
 			return fmt.Errorf("failed to install webview: %w", err)
 		}
 	}
 
+	if err := b.SetupInputMethod(); err != nil {
+		return fmt.Errorf("setup input method: %w", err)
+	}
+
+	if err := b.SetupKeyboardLayout(); err != nil {
+		return fmt.Errorf("setup keyboard layout: %w", err)
+	}
+
+	if err := b.SetupBrowser(); err != nil {
+		return fmt.Errorf("setup browser: %w", err)
+	}
+
+	if err := b.SetupClipboard(); err != nil {
+		return fmt.Errorf("setup clipboard: %w", err)
+	}
+
+	if err := b.SetupPassthrough(); err != nil {
+		return fmt.Errorf("setup passthrough: %w", err)
+	}
+
 	return nil
 }
 
-func (b *Binary) HandleProtocolURI(mime string) {
-	uris := strings.Split(mime, "+")
-	for _, uri := range uris {
-		kv := strings.Split(uri, ":")
+func (b *Binary) HandleProtocolURI(s string) error {
+	uri, err := protocol.Parse(s)
+	if err != nil {
+		return err
+	}
+	b.URI = uri
 
-		if len(kv) == 2 && kv[0] == "channel" {
-			c := kv[1]
-			if c == "" {
-				continue
-			}
+	slog.Info("Handling protocol URI", "scheme", uri.Scheme,
+		"launchmode", uri.LaunchMode, "placeid", uri.PlaceID)
 
-			slog.Warn("Roblox has requested a user channel, changing...", "channel", c)
-			b.Config.Channel = c
-		}
+	if uri.Channel != "" && uri.Channel != b.Config.Channel && b.acceptChannel(uri.Channel) {
+		slog.Warn("Roblox has requested a user channel, changing...", "channel", uri.Channel)
+		b.Notify(b.Alias+" channel changed", "Roblox has requested the "+uri.Channel+" channel.")
+		b.Config.Channel = uri.Channel
 	}
+
+	return nil
 }
 
+// Execute runs the Binary with the given arguments. If the watchdog is
+// enabled, Roblox will be relaunched until it exits as expected or the
+// watchdog runs out of retries.
 func (b *Binary) Execute(args ...string) error {
-	if b.Config.DiscordRPC {
-		if err := b.Activity.Connect(); err != nil {
-			slog.Error("Could not connect to Discord RPC", "error", err)
-			b.Config.DiscordRPC = false
-		} else {
-			defer b.Activity.Close()
+	if b.URI != nil {
+		if err := b.applyGame(b.URI.PlaceID); err != nil {
+			return err
 		}
 	}
 
+	defer func() {
+		b.activityMu.Lock()
+		defer b.activityMu.Unlock()
+
+		if b.activityIdle != nil {
+			b.activityIdle.Stop()
+			b.activityIdle = nil
+		}
+		b.closeActivity()
+	}()
+
 	// Studio can run in multiple instances, not Player
 	if b.GlobalConfig.MultipleInstances && b.Type == roblox.Player {
 		slog.Info("Running robloxmutexer")
@@ -262,178 +549,509 @@ func (b *Binary) Execute(args ...string) error {
 		}()
 	}
 
+	// The wineprefix is only killed once Roblox won't be relaunched,
+	// as relaunches and handed over launches reuse it.
+	defer b.reap()
+
+	if b.handoffable() {
+		l, err := session.Listen(b.sessionName())
+		if err != nil {
+			slog.Error("Could not listen for handed over launches", "error", err)
+		} else {
+			defer l.Close()
+
+			b.handoff = make(chan []string, 1)
+			go func() {
+				defer b.recoverPanic()
+				l.Serve(b.queueHandoff)
+			}()
+		}
+	}
+
+	restarts := 0
+	for {
+		if err := b.RestoreSettings(); err != nil {
+			slog.Error("Could not restore Roblox settings", "error", err)
+		}
+
+		err := b.execute(args...)
+
+		if err := b.BackupSettings(); err != nil {
+			slog.Error("Could not back up Roblox settings", "error", err)
+		}
+
+		// The game Roblox had joined by itself is applied once it has
+		// exited, for Roblox to be relaunched with its configuration.
+		if b.placeID == "" && b.game.PlaceID != "" {
+			if err := b.applyGame(b.game.PlaceID); err != nil {
+				slog.Error("Could not apply game configuration", "error", err)
+			}
+		}
+
+		if next, ok := b.nextHandoff(); ok {
+			if err := b.prepareHandoff(next); err != nil {
+				return fmt.Errorf("handed over launch: %w", err)
+			}
+
+			args = next
+			continue
+		}
+
+		if b.crashed(err) {
+			b.reportCrash()
+		}
+
+		if !b.exitedUnexpectedly(err) {
+			return err
+		}
+
+		b.Events.Publish(events.Event{Type: events.Crashed, Game: b.game})
+
+		if !b.Config.Watchdog {
+			return err
+		}
+
+		if restarts >= b.Config.WatchdogRetries {
+			slog.Error("Roblox exited unexpectedly, no watchdog retries left", "retries", restarts)
+			return err
+		}
+		restarts++
+
+		slog.Warn("Roblox exited unexpectedly, relaunching",
+			"error", err, "restart", restarts, "retries", b.Config.WatchdogRetries)
+
+		args = b.relaunchArgs(args)
+		time.Sleep(WatchdogDelay)
+	}
+}
+
+// sessionName returns the name of the Binary's session, which is unique
+// to each account, as each account has its own wineprefix.
+func (b *Binary) sessionName() string {
+	if b.Account == "" {
+		return b.Alias
+	}
+
+	return b.Alias + "-" + b.Account
+}
+
+// handoffable determines if launches of the Binary should be handed over
+// to an already running session of it, since only one Player may run
+// at a time.
+func (b *Binary) handoffable() bool {
+	return b.Type == roblox.Player && !b.GlobalConfig.MultipleInstances
+}
+
+// Handoff attempts to hand over the given protocol URI arguments to an already
+// running session of the Binary, and reports whether it had succeeded.
+//
+// Roblox relaunches itself with a new protocol URI when teleporting to another
+// universe or switching channels; handing over the launch lets the running
+// session launch Roblox within its already initialized wineprefix and
+// environment, instead of setting up everything all over again.
+func (b *Binary) Handoff(args []string) bool {
+	if !b.handoffable() || len(args) != 1 || !protocol.IsProtocol(args[0]) {
+		return false
+	}
+
+	if err := session.Send(b.sessionName(), args); err != nil {
+		if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, syscall.ECONNREFUSED) {
+			slog.Error("Failed to hand over launch", "error", err)
+		}
+		return false
+	}
+
+	slog.Info("Handed over launch to running session", "args", args)
+	return true
+}
+
+func (b *Binary) queueHandoff(args []string) error {
+	if len(args) != 1 || !protocol.IsProtocol(args[0]) {
+		return errors.New("only protocol uris can be handed over")
+	}
+
+	select {
+	case b.handoff <- args:
+		slog.Info("Recieved handed over launch", "args", args)
+	default:
+		return errors.New("a handed over launch is already pending")
+	}
+
+	go b.replaceRunning()
+	return nil
+}
+
+// replaceRunning replaces the running Roblox with the pending handed over
+// launch, depending on the second_launch configuration. Roblox is given
+// [HandoffTimeout] to shut down by itself, as it does after relaunching
+// itself, before it is replaced.
+func (b *Binary) replaceRunning() {
+	defer b.recoverPanic()
+
+	time.Sleep(HandoffTimeout)
+
+	// The handed over launch was already launched after Roblox shut down
+	if len(b.handoff) == 0 || !b.running.Load() || b.Config.SecondLaunch == "queue" {
+		return
+	}
+
+	if b.Config.SecondLaunch == "prompt" {
+		if !b.dialogs() {
+			slog.Warn("Cannot prompt for handed over launch without splash, replacing")
+		} else if !b.Splash.Dialog(DialogReplace, true) {
+			slog.Info("Declined handed over launch")
+
+			select {
+			case <-b.handoff:
+			default:
+			}
+			return
+		}
+	}
+
+	slog.Info("Replacing running Roblox with handed over launch")
+
+	// Kills Roblox without it being considered as killed by the user
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+}
+
+// nextHandoff returns the pending handed over launch. If Roblox had shut down
+// by itself, it will wait for one for [HandoffTimeout].
+func (b *Binary) nextHandoff() ([]string, bool) {
+	if b.handoff == nil {
+		return nil, false
+	}
+
+	select {
+	case args := <-b.handoff:
+		return args, true
+	default:
+	}
+
+	if b.killed.Load() || !b.shutdown.Load() {
+		return nil, false
+	}
+
+	select {
+	case args := <-b.handoff:
+		return args, true
+	case <-time.After(HandoffTimeout):
+		return nil, false
+	}
+}
+
+// prepareHandoff handles the handed over launch protocol URI, and sets up
+// the new deployment if Roblox had requested a different channel.
+func (b *Binary) prepareHandoff(args []string) error {
+	channel := b.Config.Channel
+
+	if err := b.HandleProtocolURI(args[0]); err != nil {
+		return fmt.Errorf("protocol uri: %w", err)
+	}
+
+	if err := b.applyGame(b.URI.PlaceID); err != nil {
+		return err
+	}
+
+	if b.Config.Channel == channel {
+		return nil
+	}
+
+	return b.Setup()
+}
+
+// exitedUnexpectedly determines if the last Roblox process, which exited
+// with the given error, had exited without the user having asked it to:
+// Roblox logged a crash, or exited with a failing exit status without
+// having logged its shutdown.
+//
+// If Roblox never made a log file, it is unknown why it had exited,
+// which is not considered unexpected.
+func (b *Binary) exitedUnexpectedly(err error) bool {
+	switch {
+	case b.killed.Load():
+		return false
+	case b.crashLog.Load():
+		return true
+	case b.shutdown.Load(), errors.Is(err, ErrNoRobloxLog):
+		return false
+	}
+
+	return err != nil
+}
+
+// relaunchArgs returns the arguments used to relaunch Roblox into the
+// last game it was in, falling back to the given arguments.
+//
+// Authentication tickets given by protocol URIs are only valid once,
+// so the game is joined with the roblox scheme instead.
+func (b *Binary) relaunchArgs(args []string) []string {
+	if b.Type != roblox.Player || b.game.PlaceID == "" {
+		return args
+	}
+
+	j := protocol.Join{PlaceID: b.game.PlaceID, JobID: b.game.JobID}
+	return []string{j.Experience()}
+}
+
+func (b *Binary) execute(args ...string) error {
+	b.killed.Store(false)
+	b.shutdown.Store(false)
+	b.crashLog.Store(false)
+
+	if err := b.checkPlaytime(); err != nil {
+		// Not unexpected, for Roblox to not be relaunched.
+		b.killed.Store(true)
+		return err
+	}
+
 	cmd, err := b.Command(args...)
 	if err != nil {
 		return fmt.Errorf("%s command: %w", b.Type, err)
 	}
+	defer b.stopScope()
+
+	// The log goroutine is waited for, for the session events to not be
+	// handled while the configuration is changed for the next launch.
+	done := make(chan struct{})
+	var tailing sync.WaitGroup
+	defer func() {
+		close(done)
+		tailing.Wait()
+	}()
 
 	// Roblox will keep running if it was sent SIGINT; requiring acting as the signal holder.
 	// SIGUSR1 is used in Tail() to force kill roblox, used to differenciate between
 	// a user-sent signal and a self sent signal.
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGUSR1)
+	defer signal.Stop(c)
+
+	slog.Info("Running Binary", "name", b.Name, "cmd", cmd)
+	b.recordLaunch(cmd)
+	b.Splash.SetMessage("Launching " + b.Alias)
+
+	// Roblox is ran in its own process group, to be able to kill
+	// all of its processes, and to not recieve the terminal's signals.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	start := time.Now()
+	played := b.State.Played(start)
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start roblox: %w", err)
+	}
+	defer b.recordPlaytime(start)
+
+	if b.Config.Playtime.Enabled() {
+		go b.WatchPlaytime(start, played, done)
+	}
+
+	exited := make(chan struct{})
+	var stopped atomic.Bool
+
 	go func() {
-		s := <-c
+		defer b.recoverPanic()
+
+		var s os.Signal
+		select {
+		case s = <-c:
+		case <-done:
+			return
+		}
+
+		// Don't handle INT after it was recieved, this way if another signal
+		// was sent while Roblox is being stopped, Vinegar will immediately exit.
+		signal.Stop(c)
 
 		slog.Warn("Recieved signal", "signal", s)
 
-		// Only kill Roblox if it hasn't exited
-		if cmd.ProcessState == nil {
-			slog.Warn("Killing Roblox", "pid", cmd.Process.Pid)
-			// This way, cmd.Run() will return and vinegar (should) exit.
-			cmd.Process.Kill()
+		if s != syscall.SIGUSR1 {
+			b.killed.Store(true)
 		}
 
-		// Don't handle INT after it was recieved, this way if another signal was sent,
-		// Vinegar will immediately exit.
-		signal.Stop(c)
+		// This way, cmd.Wait() will return and vinegar (should) exit.
+		stopped.Store(true)
+		b.stop(cmd, exited)
 	}()
 
-	slog.Info("Running Binary", "name", b.Name, "cmd", cmd)
-	b.Splash.SetMessage("Launching " + b.Alias)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Result of looking for the Roblox log file, sent before tailing it.
+	started := make(chan error, 1)
 
+	tailing.Add(1)
 	go func() {
-		// Wait for process to start
-		for {
-			if cmd.Process != nil {
-				break
-			}
+		defer tailing.Done()
+		defer b.recoverPanic()
+
+		// The log files are watched for as long as Roblox runs, for the
+		// log files made after the first one to be tailed too.
+		var created <-chan string
+		dir, err := RobloxLogDir(b.Prefix)
+		if err == nil {
+			created = watchLogs(dir, done)
 		}
 
 		// If the log file wasn't found, assume failure
 		// and don't perform post-launch roblox functions.
-		lf, err := RobloxLogFile(b.Prefix)
+		var lf string
+		if err == nil {
+			lf, err = RobloxLogFile(ctx, created)
+		}
+		started <- err
 		if err != nil {
-			slog.Error("Failed to find Roblox log file", "error", err.Error())
+			if !errors.Is(err, context.Canceled) {
+				slog.Error("Failed to find Roblox log file", "error", err.Error())
+			}
 			return
 		}
 
 		b.Splash.Close()
 
+		// Only Roblox itself is prioritized, for Wine's processes
+		// to not compete with it.
+		pid := b.robloxProcess(cmd.Process.Pid)
+		b.SetAffinity(pid)
+
 		if b.Config.GameMode {
-			b.RegisterGameMode(int32(cmd.Process.Pid))
+			pids := b.RegisterGameMode(cmd.Process.Pid, pid)
+			defer b.UnregisterGameMode(pids)
 		}
 
-		// Blocks and tails file forever until roblox is dead, unless
-		// if finding the log file had failed.
-		b.Tail(lf)
-	}()
+		if b.Config.Inhibit {
+			defer b.Inhibit()()
+		}
 
-	if err := cmd.Run(); err != nil {
-		// thanks for your time, fizzie on #go-nuts
-		// Killed, not an error (in most cases)
-		if cmd.ProcessState.ExitCode() == -1 {
-			slog.Warn("Roblox was killed!")
-			return nil
+		if b.Config.MPRIS {
+			defer b.ExportMPRIS()()
 		}
-		return fmt.Errorf("roblox process: %w", err)
-	}
 
-	return nil
-}
+		switch b.Config.MemoryWatchdog {
+		case "warn", "kill":
+			go b.WatchMemory(cmd.Process.Pid, pid, done)
+		}
 
-func RobloxLogFile(pfx *wine.Prefix) (string, error) {
-	ad, err := pfx.AppDataDir()
-	if err != nil {
-		return "", fmt.Errorf("get appdata: %w", err)
-	}
+		// Blocks and tails file until roblox is dead.
+		b.Tail(lf, created, done)
+	}()
 
-	dir := filepath.Join(ad, "Local", "Roblox", "logs")
+	b.running.Store(true)
+	err = cmd.Wait()
+	b.running.Store(false)
+	close(exited)
 
-	// This is required due to fsnotify requiring the directory
-	// to watch to exist before adding it.
-	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return "", fmt.Errorf("create roblox log dir: %w", err)
+	if t := b.dieTimer.Swap(nil); t != nil {
+		t.Stop()
 	}
 
-	w, err := fsnotify.NewWatcher()
-	if err != nil {
-		return "", fmt.Errorf("make fsnotify watcher: %w", err)
-	}
-	defer w.Close()
+	cancel()
+	logErr := <-started
 
-	if err := w.Add(dir); err != nil {
-		return "", fmt.Errorf("watch roblox log dir: %w", err)
+	// thanks for your time, fizzie on #go-nuts
+	// Killed, not an error (in most cases)
+	if err != nil && (stopped.Load() || cmd.ProcessState.ExitCode() == -1) {
+		slog.Warn("Roblox was killed!")
+		return nil
 	}
 
-	t := time.NewTimer(LogTimeout)
-
-	for {
-		select {
-		case <-t.C:
-			return "", fmt.Errorf("roblox log file not found after %s", LogTimeout)
-		case e := <-w.Events:
-			if e.Has(fsnotify.Create) {
-				return e.Name, nil
-			}
-		case err := <-w.Errors:
-			slog.Error("Recieved fsnotify watcher error", "error", err)
+	// Roblox had exited before a log file was made, Wine must have
+	// failed to run it.
+	if errors.Is(logErr, context.Canceled) {
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrNoRobloxLog, err)
 		}
+		return ErrNoRobloxLog
 	}
-}
 
-func (b *Binary) Tail(name string) {
-	t, err := tail.TailFile(name, tail.Config{Follow: true})
 	if err != nil {
-		slog.Error("Could not tail Roblox log file", "error", err)
-		return
+		return fmt.Errorf("roblox process: %w", err)
 	}
 
-	for line := range t.Lines {
-		fmt.Fprintln(b.Prefix.Stderr, line.Text)
+	return nil
+}
 
-		// Roblox shut down, give it atleast a few seconds, and then send an
-		// internal signal to kill it.
-		// This is due to Roblox occasionally refusing to die. We must kill it.
-		if strings.Contains(line.Text, "[FLog::SingleSurfaceApp] shutDown:") {
-			go func() {
-				time.Sleep(DieTimeout)
-				syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
-			}()
-		}
+// legacy determines if the Binary's deployment should be treated as an
+// older deployment, which is detected when compat is auto by the version
+// directory lacking the Binary's executable.
+//
+// Older deployments may have packages unknown to Vinegar, which are extracted
+// to the version directory instead of failing, and do not require WebView.
+func (b *Binary) legacy() bool {
+	switch b.Config.Compat {
+	case "legacy":
+		return true
+	case "latest":
+		return false
+	}
 
-		if b.Config.DiscordRPC {
-			if err := b.Activity.HandleRobloxLog(line.Text); err != nil {
-				slog.Error("Activity Roblox log handle failed", "error", err)
-			}
-		}
+	_, err := os.Stat(filepath.Join(b.Dir, b.Type.Executable()))
+	return errors.Is(err, os.ErrNotExist)
+}
+
+// executable returns the file name of the Binary's executable within its
+// version directory, which is looked for in legacy deployments.
+func (b *Binary) executable() string {
+	if !b.legacy() {
+		return b.Type.Executable()
+	}
+
+	exe, err := boot.FindExecutable(b.Type, b.Dir)
+	if err != nil {
+		slog.Error("Could not find Roblox executable", "dir", b.Dir, "error", err)
+		return b.Type.Executable()
 	}
+
+	return exe
 }
 
 func (b *Binary) Command(args ...string) (*wine.Cmd, error) {
-	if strings.HasPrefix(strings.Join(args, " "), "roblox-studio:1") {
-		args = []string{"-protocolString", args[0]}
+	if b.URI != nil && b.URI.Scheme == "roblox-studio" {
+		args = []string{"-protocolString", b.URI.String()}
 	}
 
-	cmd := b.Prefix.Wine(filepath.Join(b.Dir, b.Type.Executable()), args...)
+	cmd := b.Prefix.Wine(filepath.Join(b.Dir, b.executable()), args...)
 
 	launcher := strings.Fields(b.Config.Launcher)
+
 	if len(launcher) >= 1 {
-		cmd.Args = append(launcher, cmd.Args...)
-		p, err := b.Config.LauncherPath()
+		p, err := exec.LookPath(launcher[0])
 		if err != nil {
 			return nil, fmt.Errorf("bad launcher: %w", err)
 		}
+
+		slog.Info("Using launcher", "launcher", launcher)
+		cmd.Args = append(launcher, cmd.Args...)
 		cmd.Path = p
 	}
 
-	return cmd, nil
-}
+	if b.Config.Gamescope.Enabled {
+		gs := b.Config.Gamescope.Args()
+		p, err := exec.LookPath(gs[0])
+		if err != nil {
+			return nil, fmt.Errorf("gamescope: %w", err)
+		}
 
-func (b *Binary) RegisterGameMode(pid int32) {
-	conn, err := dbus.ConnectSessionBus()
-	if err != nil {
-		slog.Error("Failed to connect to D-Bus", "error", err)
-		return
+		slog.Info("Using gamescope", "args", gs)
+		cmd.Args = append(gs, cmd.Args...)
+		cmd.Path = p
 	}
 
-	desktop := conn.Object("org.freedesktop.portal.Desktop", "/org/freedesktop/portal/desktop")
+	if b.Config.Sandbox {
+		if err := b.sandbox(cmd); err != nil {
+			return nil, fmt.Errorf("sandbox: %w", err)
+		}
+	}
 
-	call := desktop.Call("org.freedesktop.portal.GameMode.RegisterGame", 0, pid)
-	if call.Err != nil && !errors.Is(call.Err, dbus.ErrMsgNoObject) {
-		slog.Error("Failed to register to GameMode", "error", err)
-		return
+	// The scope is outermost, to also contain the sandbox.
+	if b.Config.Scope.Enabled {
+		if err := b.systemdScope(cmd); err != nil {
+			return nil, fmt.Errorf("scope: %w", err)
+		}
 	}
+
+	return cmd, nil
 }
 
 func LogFile(name string) (*os.File, error) {
@@ -5,15 +5,25 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime/trace"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
-	cp "github.com/otiai10/copy"
 	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/events"
+	"github.com/vinegarhq/vinegar/internal/mods"
 	"github.com/vinegarhq/vinegar/internal/netutil"
+	"github.com/vinegarhq/vinegar/internal/notify"
+	"github.com/vinegarhq/vinegar/internal/retry"
+	"github.com/vinegarhq/vinegar/internal/shadercache"
+	"github.com/vinegarhq/vinegar/internal/state"
 	"github.com/vinegarhq/vinegar/roblox"
 	boot "github.com/vinegarhq/vinegar/roblox/bootstrapper"
+	"github.com/vinegarhq/vinegar/splash"
 	"github.com/vinegarhq/vinegar/wine/dxvk"
 	"golang.org/x/sync/errgroup"
 )
@@ -24,29 +34,114 @@ func (b *Binary) SetDeployment() error {
 			"channel", b.Config.Channel)
 	}
 
+	if b.State.SwitchChannel(b.Config.Channel) {
+		slog.Info("Switching to kept deployment of channel", "channel", b.Config.Channel, "guid", b.State.Version)
+
+		if _, err := os.Stat(filepath.Join(dirs.Versions, b.State.Version)); err != nil {
+			slog.Warn("Kept deployment is missing, reinstalling", "guid", b.State.Version, "error", err)
+			b.State.Version = ""
+		}
+	}
+
 	if b.Config.ForcedVersion != "" {
 		slog.Warn("Using forced deployment!", "guid", b.Config.ForcedVersion)
 
 		d := boot.NewDeployment(b.Type, b.Config.Channel, b.Config.ForcedVersion)
 		b.Deploy = &d
+		b.State.Verified = time.Time{}
+		return nil
+	}
+
+	installed := boot.NewDeployment(b.Type, b.Config.Channel, b.State.Version)
+
+	if b.verified() {
+		slog.Info("Installed deployment was recently verified, not checking for updates",
+			"guid", b.State.Version, "verified", b.State.Verified)
+		b.Deploy = &installed
+		return nil
+	}
+
+	if b.Config.UpdatePolicy == "never" && b.State.Version != "" {
+		slog.Warn("Updates are disabled, using installed deployment!", "guid", b.State.Version)
+		b.Deploy = &installed
 		return nil
 	}
 
 	b.Splash.SetMessage("Fetching " + b.Alias)
 
-	d, err := boot.FetchDeployment(b.Type, b.Config.Channel)
+	d, err := boot.FetchDeployment(b.traceContext(), b.Type, b.Config.Channel)
 	if err != nil {
 		return err
 	}
 
+	if b.Config.UpdatePolicy == "notify" && b.State.Version != "" && b.State.Version != d.GUID {
+		slog.Warn("Update available, using installed deployment!",
+			"guid", b.State.Version, "new_guid", d.GUID)
+		b.Notify(b.Alias+" update available",
+			fmt.Sprintf("Roblox %s is available, %s is being used until updated.", d.GUID, b.State.Version))
+
+		b.Deploy = &installed
+		return nil
+	}
+
 	b.Deploy = &d
+	b.State.Verified = time.Now()
+	b.State.VerifiedChannel = b.Config.Channel
 	return nil
 }
 
+// dialogs determines if the splash can show dialogs, which it can't when
+// it is disabled or nothing is shown.
+func (b *Binary) dialogs() bool {
+	_, null := b.Splash.(splash.Null)
+	return b.GlobalConfig.Splash.Enabled && !null
+}
+
+// verified determines if the installed deployment was verified to be the
+// latest deployment of the Binary's channel within the update check interval,
+// in which the latest deployment is not fetched.
+func (b *Binary) verified() bool {
+	if b.Config.UpdateCheck == 0 || b.Config.ForcedVersion != "" || b.State.Version == "" ||
+		b.State.VerifiedChannel != b.Config.Channel ||
+		time.Since(b.State.Verified) >= b.Config.UpdateCheck {
+		return false
+	}
+
+	_, err := os.Stat(filepath.Join(dirs.Versions, b.State.Version))
+	return err == nil
+}
+
+// phase measures the time taken by the named setup phase, until
+// the returned function is called.
+func (b *Binary) phase(name string) func() {
+	start := time.Now()
+	end := b.region(name)
+
+	return func() {
+		end()
+		if b.timing == nil {
+			return
+		}
+
+		b.timing.Phases = append(b.timing.Phases, state.Phase{
+			Name:     name,
+			Duration: time.Since(start),
+		})
+	}
+}
+
 func (b *Binary) Setup() error {
+	b.timing = &state.SetupTiming{Time: time.Now()}
+	defer func() { b.timing = nil }()
+
+	retry.Network.OnRetry = b.retrying
+	defer func() { retry.Network.OnRetry = nil }()
+
+	done := b.phase("fetch")
 	if err := b.SetDeployment(); err != nil {
 		return fmt.Errorf("set %s deployment: %w", b.Config.Channel, err)
 	}
+	done()
 
 	b.Dir = filepath.Join(dirs.Versions, b.Deploy.GUID)
 	b.Splash.SetDesc(fmt.Sprintf("%s %s", b.Deploy.GUID, b.Deploy.Channel))
@@ -55,9 +150,29 @@ func (b *Binary) Setup() error {
 		slog.Info("Installing Binary", "name", b.Name,
 			"old_guid", b.State.Version, "new_guid", b.Deploy.GUID)
 
+		b.timing.Updated = true
+
+		verb, past := "Updating", "updated"
+		if b.State.Version == "" {
+			verb, past = "Installing", "installed"
+		}
+		id := b.notify(notify.Notification{
+			Summary: verb + " " + b.Alias,
+			Body:    b.Deploy.GUID,
+			Urgency: notify.Low,
+		})
+
 		if err := b.Install(); err != nil {
 			return fmt.Errorf("install %s: %w", b.Deploy.GUID, err)
 		}
+		b.Events.Publish(events.Event{Type: events.Updated, Data: b.Deploy.GUID})
+
+		b.notify(notify.Notification{
+			Summary:  b.Alias + " " + past,
+			Body:     b.Deploy.GUID,
+			Urgency:  notify.Low,
+			Replaces: id,
+		})
 	} else {
 		slog.Info("Binary is up to date!", "name", b.Name, "guid", b.Deploy.GUID)
 	}
@@ -68,21 +183,28 @@ func (b *Binary) Setup() error {
 		return fmt.Errorf("apply fflags: %w", err)
 	}
 
-	overlayDir := filepath.Join(dirs.Overlays, strings.ToLower(b.Type.String()))
-	_, err := os.Stat(overlayDir)
-	if err != nil && !errors.Is(err, os.ErrNotExist) {
-		return fmt.Errorf("stat overlay: %w", err)
-	} else if err == nil {
-		slog.Info("Copying Overlay directory's files", "src", overlayDir, "path", b.Dir)
-
-		if err := cp.Copy(overlayDir, b.Dir); err != nil {
-			return fmt.Errorf("overlay dir: %w", err)
-		}
+	done = b.phase("mods")
+	if err := b.SetupMods(); err != nil {
+		return fmt.Errorf("setup mods: %w", err)
+	}
+	done()
+
+	if err := b.SetupPrefix(); err != nil {
+		return fmt.Errorf("setup prefix: %w", err)
 	}
 
+	done = b.phase("dxvk")
 	if err := b.SetupDxvk(); err != nil {
 		return fmt.Errorf("setup dxvk %s: %w", b.Config.DxvkVersion, err)
 	}
+	done()
+
+	if err := b.SetupShaderCache(); err != nil {
+		return fmt.Errorf("setup shader cache: %w", err)
+	}
+
+	b.timing.Version = b.Deploy.GUID
+	b.State.AddSetupTiming(*b.timing)
 
 	b.Splash.SetProgress(1.0)
 	if err := b.GlobalState.Save(); err != nil {
@@ -92,6 +214,136 @@ func (b *Binary) Setup() error {
 	return nil
 }
 
+// SetupMods applies the Binary's configured mods and overlay directory
+// files over the Binary's version directory, and removes the files
+// previously applied which are no longer present.
+//
+// Mods are applied in the configured order, with files of later mods
+// taking precedence; the overlay directory takes precedence over all mods.
+func (b *Binary) SetupMods() error {
+	if b.State.ModsDisabled {
+		slog.Info("Mods are disabled, restoring replaced files")
+		return mods.Apply(b.Dir, nil, b.State.Mods)
+	}
+
+	var ms []mods.Mod
+
+	for _, name := range b.Config.Mods {
+		m, err := mods.Named(name, dirs.Mods, b.Dir)
+		if err != nil {
+			return fmt.Errorf("load mod: %w", err)
+		}
+
+		slog.Info("Using mod", "name", name, "files", len(m.Files))
+		ms = append(ms, m)
+	}
+
+	overlayDir := filepath.Join(dirs.Overlays, strings.ToLower(b.Type.String()))
+
+	ofs, err := mods.Load(overlayDir)
+	if err != nil {
+		return fmt.Errorf("load overlay: %w", err)
+	}
+	ms = append(ms, mods.Mod{Name: "overlay", Files: ofs})
+
+	files, conflicts := mods.Merge(ms)
+	for _, c := range conflicts {
+		slog.Warn("Mods conflict on file, using winning mod's file",
+			"path", c.Path, "winner", c.Winner, "overridden", c.Overridden)
+	}
+
+	if len(files) == 0 && len(b.State.Mods) == 0 {
+		return nil
+	}
+
+	slog.Info("Applying mods", "path", b.Dir)
+
+	if b.State.Mods == nil {
+		b.State.Mods = make(mods.Applied)
+	}
+
+	return mods.Apply(b.Dir, files, b.State.Mods)
+}
+
+// Prefetch downloads and extracts the packages of the Binary's latest
+// deployment ahead of an update, for the next Setup to skip installing them.
+func (b *Binary) Prefetch() error {
+	if b.Config.ForcedVersion != "" || b.Config.UpdatePolicy == "never" {
+		slog.Info("Updates are disabled, not prefetching", "name", b.Name)
+		return nil
+	}
+
+	// Downloading reports progress to the splash, which is unused
+	b.Splash = splash.Null{}
+
+	d, err := boot.FetchDeployment(b.traceContext(), b.Type, b.Config.Channel)
+	if err != nil {
+		return err
+	}
+
+	if d.GUID == b.State.Version {
+		slog.Info("Binary is up to date!", "name", b.Name, "guid", d.GUID)
+		return nil
+	}
+
+	if err := dirs.Mkdirs(dirs.Downloads); err != nil {
+		return err
+	}
+
+	pm, err := boot.FetchPackageManifest(b.traceContext(), &d)
+	if err != nil {
+		return fmt.Errorf("fetch package manifest: %w", err)
+	}
+
+	// The update is extracted to its own version directory, which
+	// is left unused until it is installed.
+	b.Dir = filepath.Join(dirs.Versions, d.GUID)
+	if err := b.InstallVersion(&pm); err != nil {
+		return fmt.Errorf("install packages: %w", err)
+	}
+
+	b.State.Prefetched = nil
+	for _, pkg := range pm.Packages {
+		b.State.Prefetched = append(b.State.Prefetched, pkg.Checksum)
+	}
+	b.State.PrefetchedVersion = d.GUID
+
+	slog.Info("Prefetched Binary update", "name", b.Name, "guid", d.GUID)
+
+	return b.GlobalState.Save()
+}
+
+// prefetched determines if the Binary's deployment was already
+// extracted by Prefetch.
+func (b *Binary) prefetched() bool {
+	if b.State.PrefetchedVersion != b.Deploy.GUID {
+		return false
+	}
+
+	_, err := os.Stat(b.Dir)
+	return err == nil
+}
+
+// PrefetchWatch prefetches the Binary's latest deployment every interval,
+// with the state reloaded before each prefetch, as the Binary may have
+// been installed by another Vinegar process in the meantime.
+func (b *Binary) PrefetchWatch(interval time.Duration) error {
+	for {
+		s, err := state.Load()
+		if err != nil {
+			return fmt.Errorf("load state: %w", err)
+		}
+		*b.GlobalState = s
+
+		if err := b.Prefetch(); err != nil {
+			slog.Error("Could not prefetch Binary update", "name", b.Name, "error", err)
+		}
+
+		slog.Info("Waiting for next prefetch", "interval", interval)
+		time.Sleep(interval)
+	}
+}
+
 func (b *Binary) Install() error {
 	b.Splash.SetMessage("Installing " + b.Alias)
 
@@ -99,11 +351,17 @@ func (b *Binary) Install() error {
 		return err
 	}
 
-	pm, err := boot.FetchPackageManifest(b.Deploy)
+	pm, err := boot.FetchPackageManifest(b.traceContext(), b.Deploy)
 	if err != nil {
 		return fmt.Errorf("fetch package manifest: %w", err)
 	}
 
+	return b.install(&pm)
+}
+
+// install installs the given package manifest's packages to the
+// Binary's version directory, and adds it as the installed deployment.
+func (b *Binary) install(pm *boot.PackageManifest) error {
 	// Prioritize smaller files first, to have less pressure
 	// on network and extraction
 	//
@@ -112,14 +370,13 @@ func (b *Binary) Install() error {
 		return pm.Packages[i].ZipSize < pm.Packages[j].ZipSize
 	})
 
-	b.Splash.SetMessage("Downloading " + b.Alias)
-	if err := b.DownloadPackages(&pm); err != nil {
-		return fmt.Errorf("download: %w", err)
-	}
-
-	b.Splash.SetMessage("Extracting " + b.Alias)
-	if err := b.ExtractPackages(&pm); err != nil {
-		return fmt.Errorf("extract: %w", err)
+	if b.prefetched() {
+		slog.Info("Using prefetched Binary update", "name", b.Name, "guid", b.Deploy.GUID)
+	} else {
+		b.Splash.SetMessage("Downloading " + b.Alias)
+		if err := b.InstallVersion(pm); err != nil {
+			return fmt.Errorf("install packages: %w", err)
+		}
 	}
 
 	if b.Type == roblox.Studio {
@@ -135,74 +392,219 @@ func (b *Binary) Install() error {
 		return fmt.Errorf("appsettings: %w", err)
 	}
 
-	b.State.Add(&pm)
-
-	if err := b.GlobalState.CleanPackages(); err != nil {
-		return fmt.Errorf("clean packages: %w", err)
-	}
+	b.State.Add(pm)
 
-	if err := b.GlobalState.CleanVersions(); err != nil {
-		return fmt.Errorf("clean versions: %w", err)
+	if _, err := b.GlobalState.Clean(CleanPolicy(b.GlobalConfig), false); err != nil {
+		return fmt.Errorf("clean: %w", err)
 	}
 
 	return nil
 }
 
-func (b *Binary) PerformPackages(pm *boot.PackageManifest, fn func(boot.Package) error) error {
-	donePkgs := 0
-	pkgsLen := len(pm.Packages)
-	eg := new(errgroup.Group)
+// InstallPackages downloads the given packages with at most
+// download_concurrency packages being downloaded at once, and extracts each
+// package to the named version directory as soon as it was downloaded.
+//
+// Packages which are unchanged from the installed version are linked from
+// its version directory instead.
+func (b *Binary) InstallPackages(pm *boot.PackageManifest, dir string) error {
+	n := b.GlobalConfig.DownloadConcurrency
+	slog.Info("Installing Packages", "guid", pm.Deployment.GUID, "count", len(pm.Packages), "concurrency", n)
+
+	pkgDirs := boot.BinaryDirectories(b.Type)
+	report := b.downloadProgress(pm)
+	sem := make(chan struct{}, n)
+	eg, ctx := errgroup.WithContext(b.traceContext())
+
+	installed := b.installedPackageFiles(pm.Deployment.GUID)
+	installedSums := b.installedFileChecksums(pm.Deployment.GUID)
+	var filesMu sync.Mutex
+	files := make(PackageFiles, len(pm.Packages))
+	sums := make(FileChecksums)
+
+	var downloading sync.WaitGroup
+	downloading.Add(len(pm.Packages))
 
+	done := b.phase("download")
 	for _, p := range pm.Packages {
 		p := p
 		eg.Go(func() error {
-			err := fn(p)
+			defer b.region("package")()
+			trace.Log(b.traceContext(), "package", p.Name)
+
+			if pf, ok := installed[p.Checksum]; ok {
+				err := b.linkPackage(pf, dir)
+				if err == nil {
+					slog.Info("Linked unchanged package", "name", p.Name, "files", len(pf))
+					report(boot.Progress{Package: &p, Current: p.ZipSize, Total: p.ZipSize})
+					downloading.Done()
+
+					filesMu.Lock()
+					files[p.Checksum] = pf
+					for _, f := range pf {
+						if c, ok := installedSums[f]; ok {
+							sums[f] = c
+						}
+					}
+					filesMu.Unlock()
+					return nil
+				}
+
+				slog.Warn("Could not link unchanged package, downloading", "name", p.Name, "error", err)
+			}
+
+			sem <- struct{}{}
+			err := ctx.Err()
+			src := filepath.Join(dirs.Downloads, p.Checksum)
+			if err == nil {
+				err = p.Download(ctx, src, pm.DeployURL, report)
+			}
+			<-sem
+			downloading.Done()
+
 			if err != nil {
 				return err
 			}
 
-			donePkgs++
-			b.Splash.SetProgress(float32(donePkgs) / float32(pkgsLen))
+			pf, ps, err := b.extractPackage(pkgDirs, p, src, dir)
+			if err != nil {
+				return err
+			}
 
+			filesMu.Lock()
+			files[p.Checksum] = pf
+			for f, c := range ps {
+				sums[f] = c
+			}
+			filesMu.Unlock()
 			return nil
 		})
 	}
 
-	return eg.Wait()
+	downloading.Wait()
+	done()
+
+	// Only the extraction of the packages which were still being
+	// extracted after the downloads have finished is accounted for.
+	b.Splash.SetMessage("Extracting " + b.Alias)
+	done = b.phase("extract")
+	defer done()
+
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if err := files.write(dir); err != nil {
+		return err
+	}
+
+	return sums.write(dir)
 }
 
-func (b *Binary) DownloadPackages(pm *boot.PackageManifest) error {
-	slog.Info("Downloading Packages", "guid", pm.Deployment.GUID, "count", len(pm.Packages))
+// extractPackage extracts the given package to the named version directory,
+// and returns the paths of its files and their checksums, relative to the
+// version directory.
+func (b *Binary) extractPackage(pkgDirs boot.PackageDirectories, pkg boot.Package, src, dir string) ([]string, FileChecksums, error) {
+	dest, err := b.packageDir(pkgDirs, pkg)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return b.PerformPackages(pm, func(pkg boot.Package) error {
-		return pkg.Download(filepath.Join(dirs.Downloads, pkg.Checksum), pm.DeployURL)
-	})
+	if err := pkg.Extract(src, filepath.Join(dir, dest)); err != nil {
+		return nil, nil, err
+	}
+
+	files, err := pkg.Files(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, f := range files {
+		files[i] = filepath.Join(dest, f)
+	}
+
+	cs, err := pkg.Checksums(src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sums := make(FileChecksums, len(cs))
+	for f, c := range cs {
+		sums[filepath.Join(dest, f)] = c
+	}
+
+	return files, sums, nil
 }
 
-func (b *Binary) ExtractPackages(pm *boot.PackageManifest) error {
-	slog.Info("Extracting Packages", "guid", pm.Deployment.GUID, "count", len(pm.Packages))
+// packageDir returns the directory the given package is extracted to,
+// relative to the version directory.
+func (b *Binary) packageDir(pkgDirs boot.PackageDirectories, pkg boot.Package) (string, error) {
+	dest, ok := pkgDirs[pkg.Name]
+
+	// Packages unknown to Vinegar are what make a deployment
+	// legacy during its installation when compat is auto.
+	if !ok && b.Config.Compat != "latest" {
+		slog.Warn("Extracting unknown legacy package to version directory", "name", pkg.Name)
+	} else if !ok {
+		return "", fmt.Errorf("%w: %s", boot.ErrUnhandledPackage, pkg.Name)
+	}
 
-	pkgDirs := boot.BinaryDirectories(b.Type)
+	return dest, nil
+}
 
-	return b.PerformPackages(pm, func(pkg boot.Package) error {
-		dest, ok := pkgDirs[pkg.Name]
+// retrying shows the given retry of a network request on the splash,
+// which is replaced by the progress once the request is retried.
+func (b *Binary) retrying(a retry.Attempt) {
+	name := a.Name
+	if op, url, ok := strings.Cut(a.Name, " "); ok && strings.Contains(url, "://") {
+		name = op + " " + path.Base(url)
+	}
 
-		if !ok {
-			return fmt.Errorf("unhandled package: %s", pkg.Name)
+	b.Splash.SetMessage(fmt.Sprintf("Retrying %s in %s (attempt %d of %d)",
+		name, a.Delay.Round(100*time.Millisecond), a.Attempt+1, a.Attempts))
+}
+
+// downloadProgress returns a callback reporting the overall progress,
+// speed and remaining time of downloading the given packages to the splash.
+func (b *Binary) downloadProgress(pm *boot.PackageManifest) boot.ProgressFunc {
+	var mu sync.Mutex
+	var total int64
+	progress := make(map[string]boot.Progress, len(pm.Packages))
+	for _, p := range pm.Packages {
+		total += p.ZipSize
+	}
+
+	return func(p boot.Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		progress[p.Package.Name] = p
+
+		var cur int64
+		var speed float64
+		for _, p := range progress {
+			cur += p.Current
+			if p.Current < p.Total {
+				speed += p.Speed
+			}
 		}
 
-		return pkg.Extract(filepath.Join(dirs.Downloads, pkg.Checksum), filepath.Join(b.Dir, dest))
-	})
+		b.Splash.SetProgress(float32(cur) / float32(total))
+		if speed > 0 {
+			eta := time.Duration(float64(total-cur) / speed * float64(time.Second))
+			b.Splash.SetMessage(fmt.Sprintf("Downloading %s (%s/s, %s left)",
+				b.Alias, formatSize(int64(speed)), eta.Round(time.Second)))
+		}
+	}
 }
 
 func (b *Binary) SetupDxvk() error {
-	if b.State.DxvkVersion != "" && !b.Config.Dxvk {
+	if b.PrefixState.DxvkVersion != "" && !b.Config.Dxvk {
 		b.Splash.SetMessage("Uninstalling DXVK")
 		if err := dxvk.Remove(b.Prefix); err != nil {
 			return fmt.Errorf("remove dxvk: %w", err)
 		}
 
-		b.State.DxvkVersion = ""
+		b.PrefixState.DxvkVersion = ""
 		return nil
 	}
 
@@ -213,7 +615,7 @@ func (b *Binary) SetupDxvk() error {
 	b.Splash.SetProgress(0.0)
 	dxvk.Setenv()
 
-	if b.Config.DxvkVersion == b.State.DxvkVersion {
+	if b.Config.DxvkVersion == b.PrefixState.DxvkVersion {
 		return nil
 	}
 
@@ -237,6 +639,53 @@ func (b *Binary) SetupDxvk() error {
 		return fmt.Errorf("extract: %w", err)
 	}
 
-	b.State.DxvkVersion = b.Config.DxvkVersion
+	b.PrefixState.DxvkVersion = b.Config.DxvkVersion
+	return nil
+}
+
+// SetupShaderCache relocates the shader caches to the wineprefix's shader
+// cache directory, for them to be kept across Roblox updates. A new shader
+// cache directory is seeded from the configured pre-built shader cache.
+func (b *Binary) SetupShaderCache() error {
+	if !b.Config.ShaderCache {
+		return nil
+	}
+
+	dir := shadercache.Dir(filepath.Base(BinaryPrefixDir(b.Type, b.Account)))
+	_, err := os.Stat(dir)
+	seed := errors.Is(err, os.ErrNotExist) && b.Config.ShaderSeed != ""
+
+	for name := range shadercache.Caches {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			return err
+		}
+	}
+
+	// Explicitly configured shader cache locations take precedence.
+	for key, value := range shadercache.Env(dir) {
+		if _, ok := b.Config.Env[key]; !ok {
+			os.Setenv(key, value)
+		}
+	}
+
+	if !seed {
+		return nil
+	}
+
+	b.Splash.SetMessage("Downloading shader cache")
+
+	name := filepath.Join(dirs.Cache, "shaders.tar.gz")
+	defer os.Remove(name)
+
+	// Roblox is still usable without the pre-built shader cache.
+	if err := netutil.DownloadProgress(b.Config.ShaderSeed, name, b.Splash.SetProgress); err != nil {
+		slog.Warn("Could not download shader cache", "url", b.Config.ShaderSeed, "error", err)
+		return nil
+	}
+
+	if err := shadercache.Seed(name, dir); err != nil {
+		slog.Warn("Could not seed shader cache", "error", err)
+	}
+
 	return nil
 }
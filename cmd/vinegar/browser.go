@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/portal"
+	"github.com/vinegarhq/vinegar/wine"
+)
+
+// BrowserSchemes are the URL schemes which are opened on the host
+// when requested by Roblox.
+var BrowserSchemes = []string{"http", "https", "mailto", "discord"}
+
+var ErrBadBrowserURL = errors.New("url scheme is not allowed to be opened")
+
+// OpenURL opens the given URL with the host's preferred application,
+// through the OpenURI portal, falling back to xdg-open.
+func OpenURL(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+
+	allowed := false
+	for _, s := range BrowserSchemes {
+		allowed = allowed || strings.EqualFold(u.Scheme, s)
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s", ErrBadBrowserURL, u.Scheme)
+	}
+
+	slog.Info("Opening URL on host", "url", uri)
+
+	err = portal.OpenURI(uri)
+	if err == nil {
+		return nil
+	}
+
+	slog.Warn("Failed to open URL through portal, using xdg-open", "error", err)
+
+	return portal.XDGOpen(uri).Run()
+}
+
+// SetupBrowser sets Wine's browser, used by winebrowser when Roblox
+// requests to open a URL, to Vinegar - which opens it on the host.
+//
+// As Wine only runs the browser with the URL as its sole argument,
+// a script is used to run Vinegar's open command.
+func (b *Binary) SetupBrowser() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("executable: %w", err)
+	}
+
+	path := filepath.Join(dirs.Data, "winebrowser")
+	script := "#!/bin/sh\nexec '" + strings.ReplaceAll(exe, "'", `'\''`) + "' open \"$@\"\n"
+
+	if cur, err := os.ReadFile(path); err != nil || string(cur) != script {
+		if err := dirs.Mkdirs(dirs.Data); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+			return err
+		}
+	}
+
+	return b.SetRegistry(`HKEY_CURRENT_USER\Software\Wine\WineBrowser`, "Browsers", wine.REG_SZ, path)
+}
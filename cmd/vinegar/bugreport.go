@@ -0,0 +1,249 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/portal"
+	"github.com/vinegarhq/vinegar/roblox"
+	"github.com/vinegarhq/vinegar/wine"
+	"golang.org/x/term"
+)
+
+// BugReportTail is the amount of the end of each log file included
+// in a bug report.
+const BugReportTail = 1 << 20
+
+// crashEntries are the entries of a Roblox log file which show that
+// Roblox had crashed, even if its process exited cleanly.
+var crashEntries = []string{
+	"[FLog::CrashReportLog]",
+	"Unhandled exception",
+}
+
+// BugReport writes a bug report to dirs.Logs, made of the tails of the
+// given Vinegar and Roblox log files, the system's information and the
+// redacted configuration, and returns its path. Log files with empty
+// names are left out.
+func BugReport(cfg *config.Config, logPath, robloxLog string) (string, error) {
+	if err := dirs.Mkdirs(dirs.Logs); err != nil {
+		return "", err
+	}
+
+	name := filepath.Join(dirs.Logs, "bugreport-"+time.Now().Format(time.RFC3339)+".tar.gz")
+	f, err := os.Create(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err := writeBugReport(f, cfg, logPath, robloxLog); err != nil {
+		os.Remove(name)
+		return "", err
+	}
+
+	return name, nil
+}
+
+func writeBugReport(w io.Writer, cfg *config.Config, logPath, robloxLog string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	var info bytes.Buffer
+	if err := WriteSysinfo(&info, cfg); err != nil {
+		fmt.Fprintf(&info, "sysinfo: %s\n", err)
+	}
+
+	var c bytes.Buffer
+	if err := toml.NewEncoder(&c).Encode(cfg.Redact()); err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+
+	type file struct {
+		name string
+		data []byte
+	}
+	files := []file{
+		{"sysinfo.txt", info.Bytes()},
+		{"config.toml", c.Bytes()},
+	}
+
+	for _, l := range []struct{ name, path string }{
+		{"vinegar.log", logPath},
+		{"roblox.log", robloxLog},
+	} {
+		if l.path == "" {
+			continue
+		}
+
+		data, err := tailFile(l.path, BugReportTail)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", l.name, err)
+		}
+		files = append(files, file{l.name, data})
+	}
+
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name:    f.name,
+			Mode:    0o644,
+			Size:    int64(len(f.data)),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// tailFile returns at most the last n bytes of the named file.
+func tailFile(name string, n int64) ([]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if st.Size() > n {
+		if _, err := f.Seek(st.Size()-n, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	return io.ReadAll(f)
+}
+
+// crashLogged determines if the given Roblox log line shows that
+// Roblox had crashed.
+func crashLogged(line string) bool {
+	for _, e := range crashEntries {
+		if strings.Contains(line, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// crashed determines if the last Roblox process had crashed: it exited
+// unexpectedly or with an error, or its log file shows a crash.
+func (b *Binary) crashed(err error) bool {
+	if b.killed.Load() {
+		return false
+	}
+
+	return err != nil || !b.shutdown.Load() || b.crashLog.Load()
+}
+
+// reportCrash writes a bug report of the last Roblox process, and shows
+// a dialog offering to open its location, unless the watchdog will
+// relaunch Roblox.
+func (b *Binary) reportCrash() {
+	var robloxLog string
+	if l, ok := b.robloxLog.Load().(string); ok {
+		robloxLog = l
+	}
+
+	name, err := BugReport(b.GlobalConfig, b.logPath, robloxLog)
+	if err != nil {
+		slog.Error("Could not write bug report", "error", err)
+		return
+	}
+
+	slog.Warn("Roblox crashed, wrote bug report", "path", name)
+
+	if b.Config.Watchdog || !b.dialogs() || term.IsTerminal(int(os.Stderr.Fd())) {
+		return
+	}
+
+	b.Splash.SetMessage("Oops!")
+	if !b.Splash.Dialog(fmt.Sprintf(DialogCrash, name), true) { // blocks
+		return
+	}
+
+	if err := portal.Open(dirs.Logs); err != nil {
+		slog.Error("Could not open bug report location", "error", err)
+	}
+}
+
+// NewBugReport writes a bug report of the named Binary's most recent
+// launch, and prints its path.
+func NewBugReport(cfg *config.Config, bt roblox.BinaryType) error {
+	bcfg := &cfg.Player
+	if bt == roblox.Studio {
+		bcfg = &cfg.Studio
+	}
+
+	logPath, err := latestLog(dirs.Logs, bt.String()+"-")
+	if err != nil {
+		return fmt.Errorf("find log: %w", err)
+	}
+
+	var robloxLog string
+	r, err := LoadBinaryRunner(bt, bcfg)
+	if err != nil {
+		return fmt.Errorf("%s runner: %w", bt, err)
+	}
+
+	pfx, err := wine.New(BinaryPrefixDir(bt, ""), r)
+	if err != nil {
+		return fmt.Errorf("%s prefix: %w", bt, err)
+	}
+	if ad, err := pfx.AppDataDir(); err == nil {
+		robloxLog, err = latestLog(filepath.Join(ad, "Local", "Roblox", "logs"), "")
+		if err != nil {
+			return fmt.Errorf("find roblox log: %w", err)
+		}
+	}
+
+	name, err := BugReport(cfg, logPath, robloxLog)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(name)
+	return nil
+}
+
+// latestLog returns the most recently modified log file within dir
+// with the given prefix, or an empty string if there is none.
+func latestLog(dir, prefix string) (string, error) {
+	names, err := logFiles(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	for i := len(names) - 1; i >= 0; i-- {
+		if strings.HasPrefix(filepath.Base(names[i]), prefix) {
+			return names[i], nil
+		}
+	}
+
+	return "", nil
+}
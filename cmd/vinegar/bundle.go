@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/roblox"
+	boot "github.com/vinegarhq/vinegar/roblox/bootstrapper"
+	"github.com/vinegarhq/vinegar/splash"
+	"golang.org/x/sync/errgroup"
+)
+
+// CreateBundle downloads the packages of the given Binary's deployment,
+// being the forced version or the latest deployment of its channel, and
+// writes them as a bundle to dest, to be installed with [Binary.InstallBundle]
+// on machines without access to Roblox.
+func CreateBundle(cfg *config.Config, bt roblox.BinaryType, dest string) error {
+	bcfg := &cfg.Player
+	if bt == roblox.Studio {
+		bcfg = &cfg.Studio
+	}
+
+	ctx := context.Background()
+	pm, err := boot.Resolve(ctx, bt, bcfg.Channel, bcfg.ForcedVersion)
+	if err != nil {
+		return err
+	}
+
+	if err := dirs.Mkdirs(dirs.Downloads); err != nil {
+		return err
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(cfg.DownloadConcurrency)
+	for _, p := range pm.Packages {
+		p := p
+		eg.Go(func() error {
+			return p.Download(ctx, filepath.Join(dirs.Downloads, p.Checksum), pm.DeployURL, nil)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if err := boot.WriteBundle(dest, &pm, dirs.Downloads); err != nil {
+		return fmt.Errorf("write %s: %w", dest, err)
+	}
+
+	slog.Info("Created bundle", "path", dest, "guid", pm.GUID, "channel", pm.Channel)
+	return nil
+}
+
+// InstallBundle installs the deployment within the bundle at src, a
+// directory or tar archive written by [CreateBundle], as the Binary's
+// installed deployment, without any access to Roblox.
+func (b *Binary) InstallBundle(src string) error {
+	// Installing reports progress to the splash, which is unused
+	b.Splash = splash.Null{}
+
+	if err := dirs.Mkdirs(dirs.Downloads); err != nil {
+		return err
+	}
+
+	pm, err := boot.ReadBundle(src, b.Type, dirs.Downloads)
+	if err != nil {
+		return fmt.Errorf("read bundle %s: %w", src, err)
+	}
+
+	if pm.Deployment.Channel != b.Config.Channel {
+		slog.Warn("Bundle is not of the configured channel, it will only be used with its channel!",
+			"channel", pm.Deployment.Channel, "configured", b.Config.Channel)
+	}
+
+	b.State.SwitchChannel(pm.Deployment.Channel)
+	b.Deploy = pm.Deployment
+	b.Dir = filepath.Join(dirs.Versions, b.Deploy.GUID)
+
+	if _, err := os.Stat(b.Dir); err == nil && b.State.Version == b.Deploy.GUID {
+		slog.Info("Binary is up to date!", "name", b.Name, "guid", b.Deploy.GUID)
+		return nil
+	}
+
+	if err := b.install(&pm); err != nil {
+		return fmt.Errorf("install %s: %w", b.Deploy.GUID, err)
+	}
+
+	slog.Info("Installed bundle", "name", b.Name, "guid", b.Deploy.GUID)
+
+	return b.GlobalState.Save()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/vinegarhq/vinegar/roblox/api"
+)
+
+// acceptChannel determines if the Binary should switch to the named channel
+// requested by Roblox, following the Binary's channel policy. When asked,
+// the decision is recorded in the state to not ask for the channel again.
+func (b *Binary) acceptChannel(channel string) bool {
+	switch b.Config.ChannelPolicy {
+	case "", "accept":
+		return true
+	case "ignore":
+		slog.Warn("Ignoring Roblox requested user channel", "channel", channel)
+		return false
+	}
+
+	if ok, decided := b.State.Channels[channel]; decided {
+		slog.Info("Using recorded channel decision", "channel", channel, "accept", ok)
+		return ok
+	}
+
+	// Without dialogs, the user cannot be asked, and the channel is
+	// accepted as it is when asking isn't configured.
+	if !b.dialogs() {
+		slog.Warn("Cannot ask for Roblox requested user channel without dialogs, accepting", "channel", channel)
+		return true
+	}
+
+	ok := b.Splash.Dialog(fmt.Sprintf(DialogChannel, channel), true)
+
+	if b.State.Channels == nil {
+		b.State.Channels = make(map[string]bool)
+	}
+	b.State.Channels[channel] = ok
+
+	if err := b.GlobalState.Save(); err != nil {
+		slog.Error("Could not save channel decision", "error", err)
+	}
+
+	return ok
+}
+
+// PrintChannel prints the Binary's deployment channel details, including
+// any new version being rolled out to the channel.
+func (b *Binary) PrintChannel() error {
+	channel := b.Config.Channel
+
+	if channel == "" {
+		uc, err := api.GetUserChannel(b.Name)
+		if err != nil {
+			slog.Warn("Failed to fetch assigned channel, using LIVE", "error", err)
+		}
+
+		channel = uc.ChannelName
+	}
+	if channel == "" {
+		channel = "LIVE"
+	}
+
+	cv, err := api.GetClientVersion(b.Name, channel)
+	if err != nil {
+		return err
+	}
+
+	info := `* Channel: %s
+* Version: %s %s
+* Bootstrapper: %s
+* Installed: %s
+`
+
+	fmt.Printf(info,
+		channel,
+		cv.Version, cv.ClientVersionUpload,
+		cv.BootstrapperVersion,
+		b.State.Version,
+	)
+
+	if cv.NextClientVersionUpload != "" {
+		fmt.Printf("* Rolling out: %s %s\n", cv.NextClientVersion, cv.NextClientVersionUpload)
+	}
+
+	return nil
+}
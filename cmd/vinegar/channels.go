@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/vinegarhq/vinegar/internal/state"
+)
+
+// Channels handles the channels command, which lists the channels with
+// an installed deployment, which are switched to without downloading.
+func Channels(args []string) error {
+	if len(args) < 1 || args[0] != "list" {
+		commandUsage("channels")
+	}
+
+	s, err := state.Load()
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	for _, bs := range []struct {
+		name string
+		*state.Binary
+	}{
+		{"Player", &s.Player},
+		{"Studio", &s.Studio},
+	} {
+		if bs.Version == "" && len(bs.Deployments) == 0 {
+			continue
+		}
+
+		fmt.Printf("%s:\n", bs.name)
+		if bs.Version != "" {
+			fmt.Printf("* %s: %s (installed)\n", channelName(bs.Channel), bs.Version)
+		}
+
+		channels := make([]string, 0, len(bs.Deployments))
+		for c := range bs.Deployments {
+			channels = append(channels, c)
+		}
+		sort.Strings(channels)
+
+		for _, c := range channels {
+			fmt.Printf("- %s: %s\n", channelName(c), bs.Deployments[c].Version)
+		}
+	}
+
+	return nil
+}
+
+// channelName returns the name of the given channel to be shown, as the
+// default channel has none.
+func channelName(channel string) string {
+	if channel == "" {
+		return "default"
+	}
+	return channel
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/state"
+)
+
+// CleanPolicy returns the retention policy of the cached package
+// downloads and version directories.
+func CleanPolicy(cfg *config.Config) state.Policy {
+	return state.Policy{
+		KeepVersions: cfg.KeepVersions,
+		MaxCacheSize: int64(cfg.MaxCacheSize) << 20,
+	}
+}
+
+// Clean removes the version directories and cached package downloads
+// which aren't kept by the retention policy, and with shaders, all of the
+// shader caches, and prints what was removed; with dryRun, it only prints
+// what would be removed.
+func Clean(dryRun, shaders bool) error {
+	cfg, err := config.Load(ConfigPath)
+	if err != nil {
+		return fmt.Errorf("load config %s: %w", ConfigPath, err)
+	}
+
+	s, err := state.Load()
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	rs, err := s.Clean(CleanPolicy(&cfg), dryRun)
+	if err != nil {
+		return err
+	}
+
+	if shaders {
+		srs, err := cleanShaders(dryRun)
+		rs = append(rs, srs...)
+		if err != nil {
+			return err
+		}
+	}
+
+	var total int64
+	for _, r := range rs {
+		fmt.Printf("%s\t%s\n", formatSize(r.Size), r.Path)
+		total += r.Size
+	}
+
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	fmt.Printf("%s %d items, %s\n", verb, len(rs), formatSize(total))
+
+	if dryRun {
+		return nil
+	}
+
+	if err := s.Save(); err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+
+	return nil
+}
+
+func formatSize(n int64) string {
+	return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+}
+
+// cleanShaders removes the shader cache directories of all wineprefixes,
+// which are created again at the next launch.
+func cleanShaders(dryRun bool) ([]state.Removal, error) {
+	es, err := dirEntries(dirs.Shaders)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := make([]state.Removal, 0, len(es))
+	for _, e := range es {
+		rs = append(rs, state.Removal{Path: e.path, Size: e.size})
+		if dryRun {
+			continue
+		}
+
+		slog.Info("Removing shader cache", "path", e.path, "size", e.size)
+
+		if err := os.RemoveAll(e.path); err != nil {
+			return rs, err
+		}
+	}
+
+	return rs, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+
+	"github.com/vinegarhq/vinegar/wine"
+)
+
+var ErrNoClipboardTool = errors.New("no clipboard tool found, install wl-clipboard, xclip or xsel")
+
+// SetupClipboard sets which of the host's selections is shared with
+// Wine's clipboard, either the clipboard or the primary selection.
+// Wine's X11 driver has no option to not share either of them.
+func (b *Binary) SetupClipboard() error {
+	primary := "N"
+	if b.GlobalConfig.Clipboard == "primary" {
+		primary = "Y"
+	}
+
+	return b.SetRegistry(`HKEY_CURRENT_USER\Software\Wine\X11 Driver`, "UsePrimarySelection",
+		wine.REG_SZ, primary)
+}
+
+// HostClipboard returns the text in the host's clipboard.
+func HostClipboard() (string, error) {
+	var tools [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		tools = append(tools, []string{"wl-paste", "--no-newline", "--type", "text/plain"})
+	}
+	tools = append(tools,
+		[]string{"xclip", "-out", "-selection", "clipboard"},
+		[]string{"xsel", "--output", "--clipboard"},
+	)
+
+	for _, t := range tools {
+		if _, err := exec.LookPath(t[0]); err != nil {
+			continue
+		}
+
+		out, err := exec.Command(t[0], t[1:]...).Output()
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", t[0], err)
+		}
+
+		return string(out), nil
+	}
+
+	return "", ErrNoClipboardTool
+}
+
+// Paste types the text in the host's clipboard into the Roblox window,
+// for when pasting within Roblox does not work or the clipboard is not
+// shared with Wine. xdotool is used to focus the window and type, and
+// wtype to type into the focused window if xdotool is not available.
+func (b *Binary) Paste() error {
+	text, err := HostClipboard()
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		return nil
+	}
+
+	slog.Info("Pasting clipboard into Roblox", "length", len(text))
+
+	if _, err := exec.LookPath("xdotool"); err == nil {
+		return exec.Command("xdotool",
+			"search", "--limit", "1", "--name", "^Roblox$", "windowactivate", "--sync",
+			"type", "--clearmodifiers", "--", text).Run()
+	}
+
+	if _, err := exec.LookPath("wtype"); err == nil {
+		return exec.Command("wtype", "--", text).Run()
+	}
+
+	return errors.New("xdotool or wtype is required to paste")
+}
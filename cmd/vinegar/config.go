@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/config/editor"
+)
+
+var ErrInvalidConfig = errors.New("configuration has errors")
+
+// Config handles the config command, which edits, validates and shows
+// the configuration file.
+func Config(args []string) error {
+	if len(args) < 1 {
+		commandUsage("config")
+	}
+
+	switch args[0] {
+	case "edit":
+		return editor.Edit(ConfigPath)
+	case "validate":
+		problems, err := config.Validate(ConfigPath)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range problems {
+			if p.Line > 0 {
+				fmt.Fprintf(os.Stderr, "%s:%d: %s\n", ConfigPath, p.Line, p.Err)
+			} else {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", ConfigPath, p.Err)
+			}
+		}
+
+		if len(problems) > 0 {
+			return fmt.Errorf("%w: %d found", ErrInvalidConfig, len(problems))
+		}
+
+		fmt.Println(ConfigPath, "is valid")
+	case "show":
+		fs := flag.NewFlagSet("show", flag.ExitOnError)
+		resolved := fs.Bool("resolved", false, "show the effective configuration, including defaults")
+		fs.Usage = func() { commandUsage("config") }
+		fs.Parse(args[1:])
+
+		if !*resolved {
+			data, err := os.ReadFile(ConfigPath)
+			if err != nil {
+				return err
+			}
+
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+
+		cfg, err := config.Load(ConfigPath)
+		if err != nil {
+			return fmt.Errorf("load config %s: %w", ConfigPath, err)
+		}
+
+		return toml.NewEncoder(os.Stdout).Encode(cfg)
+	default:
+		commandUsage("config")
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+)
+
+// CookieEnv is the environment variable which may hold the user's
+// .ROBLOSECURITY cookie.
+const CookieEnv = "ROBLOSECURITY"
+
+var (
+	CookiePath = filepath.Join(dirs.Config, "cookie")
+
+	ErrNoCookie = errors.New("no roblox cookie, set $" + CookieEnv + " or write it to " + CookiePath)
+)
+
+// RobloxCookie returns the user's .ROBLOSECURITY cookie, which is opted in by
+// setting it in the environment or writing it to CookiePath. The cookie grants
+// full access to the user's account, and is never retrieved otherwise.
+func RobloxCookie() (string, error) {
+	if c := os.Getenv(CookieEnv); c != "" {
+		return c, nil
+	}
+
+	fi, err := os.Stat(CookiePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoCookie
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if fi.Mode().Perm()&0o077 != 0 {
+		slog.Warn("Roblox cookie file is accessible by other users! Consider 'chmod 600'",
+			"path", CookiePath)
+	}
+
+	b, err := os.ReadFile(CookiePath)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/mods"
+)
+
+// PackageFilesName is the name of the file within a version directory
+// which lists the files of each of the version's packages.
+const PackageFilesName = ".vinegar-packages.json"
+
+// PackageFiles maps the checksum of each package of a version to the
+// paths of the package's files, relative to the version directory.
+type PackageFiles map[string][]string
+
+func readPackageFiles(dir string) (PackageFiles, error) {
+	data, err := os.ReadFile(filepath.Join(dir, PackageFilesName))
+	if err != nil {
+		return nil, err
+	}
+
+	var pf PackageFiles
+	if err := json.Unmarshal(data, &pf); err != nil {
+		return nil, err
+	}
+
+	return pf, nil
+}
+
+func (pf PackageFiles) write(dir string) error {
+	data, err := json.Marshal(pf)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, PackageFilesName), data, 0o644)
+}
+
+// installedPackageFiles returns the package files of the Binary's installed
+// version, unless it is the named version, or nil if they are unknown.
+func (b *Binary) installedPackageFiles(guid string) PackageFiles {
+	if b.State.Version == "" || b.State.Version == guid {
+		return nil
+	}
+
+	pf, err := readPackageFiles(filepath.Join(dirs.Versions, b.State.Version))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("Could not read installed package files", "error", err)
+		}
+		return nil
+	}
+
+	return pf
+}
+
+// linkPackage hard-links the given files of a package which is unchanged
+// from the installed version to the named version directory, instead of
+// downloading and extracting the package again. Files replaced by mods
+// are linked from their backups. If the package could not be linked,
+// the linked files are removed.
+func (b *Binary) linkPackage(files []string, dir string) (err error) {
+	installed := filepath.Join(dirs.Versions, b.State.Version)
+
+	var linked []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, l := range linked {
+			os.Remove(l)
+		}
+	}()
+
+	for _, rel := range files {
+		src := filepath.Join(installed, rel)
+		if _, ok := b.State.Mods[rel]; ok {
+			src = filepath.Join(installed, mods.BackupDir, rel)
+		}
+		dest := filepath.Join(dir, rel)
+
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return err
+		}
+
+		// Files left by an interrupted installation are replaced, as
+		// writing to a linked file would modify the installed version.
+		if err := os.Remove(dest); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		if err := os.Link(src, dest); err != nil {
+			return fmt.Errorf("link %s: %w", rel, err)
+		}
+		linked = append(linked, dest)
+	}
+
+	return nil
+}
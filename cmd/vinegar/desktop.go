@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vinegarhq/vinegar/internal/desktop"
+	"github.com/vinegarhq/vinegar/internal/portal"
+)
+
+// DesktopEntries returns the desktop entries which handle the Roblox
+// protocol URIs and files, pointing to the running Vinegar executable,
+// which is run through Flatpak if Vinegar is running within Flatpak.
+func DesktopEntries() ([]desktop.Entry, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("executable: %w", err)
+	}
+
+	// Each argument is quoted, for paths with spaces or reserved characters.
+	if id, ok := portal.FlatpakID(); ok {
+		exe = "flatpak run " + desktop.Quote("--command="+filepath.Base(exe)) + " " + desktop.Quote(id)
+	} else {
+		exe = desktop.Quote(exe)
+	}
+
+	return []desktop.Entry{
+		{
+			ID:        "org.vinegarhq.Vinegar.player",
+			Name:      "Roblox Player",
+			Icon:      "org.vinegarhq.Vinegar.player",
+			Exec:      exe + " player run %u",
+			NoDisplay: true,
+			MimeTypes: []string{
+				"x-scheme-handler/roblox",
+				"x-scheme-handler/roblox-player",
+			},
+		},
+		{
+			ID:   "org.vinegarhq.Vinegar.studio",
+			Name: "Roblox Studio",
+			Icon: "org.vinegarhq.Vinegar.studio",
+			Exec: exe + " studio run %u",
+			MimeTypes: []string{
+				"application/x-roblox-rbxl",
+				"application/x-roblox-rbxlx",
+				"x-scheme-handler/roblox-studio",
+				"x-scheme-handler/roblox-studio-auth",
+			},
+		},
+	}, nil
+}
+
+// InstallDesktop handles the install-desktop command, which installs or
+// updates the desktop entries and sets them as the default handlers for
+// their MIME types.
+func InstallDesktop() error {
+	es, err := DesktopEntries()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range es {
+		if err := e.Install(); err != nil {
+			return fmt.Errorf("install %s: %w", e.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// UninstallDesktop handles the uninstall-desktop command, which removes the
+// desktop entries installed by InstallDesktop and their MIME associations.
+func UninstallDesktop() error {
+	es, err := DesktopEntries()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range es {
+		if err := e.Uninstall(); err != nil {
+			return fmt.Errorf("uninstall %s: %w", e.ID, err)
+		}
+	}
+
+	return nil
+}
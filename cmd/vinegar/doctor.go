@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/roblox"
+	"github.com/vinegarhq/vinegar/sysinfo"
+)
+
+// Check is a diagnostic of the system or Vinegar's installation
+// performed by Doctor.
+type Check struct {
+	Name string
+	Run  func() error
+}
+
+// DoctorChecks returns the checks performed by Doctor with the given
+// configuration.
+func DoctorChecks(cfg *config.Config) []Check {
+	cs := []Check{
+		{"CPU supports AVX", func() error {
+			if !sysinfo.CPU.AVX {
+				return errors.New("roblox will most likely fail to run")
+			}
+			return nil
+		}},
+	}
+
+	if sysinfo.InputMethod != "" {
+		cs = append(cs, Check{"Input method (" + sysinfo.InputMethod + ")", func() error {
+			if cfg.InputMethodName() == "" {
+				return errors.New("input method is disabled, text entry such as CJK will not work in Roblox")
+			}
+			if !strings.Contains(os.Getenv("XMODIFIERS"), "@im=") {
+				return errors.New("XMODIFIERS is not set, Wine will not use the input method")
+			}
+			return nil
+		}})
+	}
+
+	if config.Emulated() {
+		cs = append(cs, Check{"x86_64 emulator", func() error {
+			_, err := cfg.EmulatorPath()
+			return err
+		}})
+	}
+
+	for _, bt := range []roblox.BinaryType{roblox.Player, roblox.Studio} {
+		bt := bt
+		bcfg := &cfg.Player
+		if bt == roblox.Studio {
+			bcfg = &cfg.Studio
+		}
+
+		cs = append(cs, Check{"Wine (" + bt.String() + ")", func() error {
+			_, err := LoadBinaryRunner(bt, bcfg)
+			return err
+		}})
+	}
+
+	es, err := DesktopEntries()
+	if err != nil {
+		return append(cs, Check{"Desktop entries", func() error { return err }})
+	}
+
+	for _, e := range es {
+		e := e
+		cs = append(cs, Check{"Desktop entry " + e.File(), func() error {
+			if err := e.Registered(); err != nil {
+				return fmt.Errorf("%w, run 'vinegar install-desktop'", err)
+			}
+			return nil
+		}})
+	}
+
+	return cs
+}
+
+// Doctor runs all checks from DoctorChecks and prints their results,
+// returning an error if any of them failed.
+func Doctor(cfg *config.Config) error {
+	failed := 0
+
+	for _, c := range DoctorChecks(cfg) {
+		if err := c.Run(); err != nil {
+			fmt.Printf("* %s: [ ] %s\n", c.Name, err)
+			failed++
+			continue
+		}
+
+		fmt.Printf("* %s: [x]\n", c.Name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d checks failed", failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"syscall"
+	"time"
+
+	"github.com/vinegarhq/vinegar/bloxstraprpc"
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/events"
+	"github.com/vinegarhq/vinegar/internal/geoip"
+	"github.com/vinegarhq/vinegar/internal/notify"
+	"github.com/vinegarhq/vinegar/internal/plugins"
+)
+
+// subscribe subscribes the Binary's integrations to its session events.
+func (b *Binary) subscribe() {
+	b.Events.Subscribe(b.handleEvent)
+	b.Events.Subscribe(b.handleOBSEvent)
+	b.Events.Subscribe(b.handleActivityEvent)
+	b.Events.Subscribe(b.handleNotifyEvent)
+	b.Events.Subscribe(b.handleLocationEvent)
+	b.Events.Subscribe(b.handlePluginEvent)
+	b.Events.Subscribe(b.handleScriptEvent)
+}
+
+// startPlugins starts the plugins within the plugins directory, if any,
+// and returns the function waiting for them to handle the last events.
+func (b *Binary) startPlugins() func() {
+	ps, err := plugins.Load(dirs.Plugins)
+	if err != nil {
+		slog.Error("Could not load plugins", "dir", dirs.Plugins, "error", err)
+		return nil
+	}
+	if len(ps) == 0 {
+		return nil
+	}
+
+	slog.Info("Starting plugins", "dir", dirs.Plugins, "count", len(ps))
+	b.plugins = plugins.Start(ps, b.Alias, b.Account)
+
+	return b.plugins.Close
+}
+
+func (b *Binary) handlePluginEvent(e events.Event) {
+	if b.plugins != nil {
+		b.plugins.Send(e)
+	}
+}
+
+// handleEvent tracks the game Roblox is in, to relaunch into it, and
+// supervises Roblox's shutdown.
+func (b *Binary) handleEvent(e events.Event) {
+	switch e.Type {
+	case events.Joined, events.Teleported:
+		b.game = e.Game
+	case events.Shutdown:
+		// Roblox shut down, give it atleast a few seconds, and then send an
+		// internal signal to kill it.
+		// This is due to Roblox occasionally refusing to die. We must kill it.
+		// The timer is stopped once Roblox exits, to not kill a Roblox
+		// launched afterwards by a handover.
+		b.shutdown.Store(true)
+		t := time.AfterFunc(DieTimeout, func() {
+			syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+		})
+		if old := b.dieTimer.Swap(t); old != nil {
+			old.Stop()
+		}
+	}
+}
+
+// handleActivityEvent connects to Discord RPC once a game is joined, and
+// updates the presence.
+func (b *Binary) handleActivityEvent(e events.Event) {
+	b.activityMu.Lock()
+	defer b.activityMu.Unlock()
+
+	if e.Type == events.Joined || e.Type == events.Teleported {
+		b.connectActivity()
+	}
+
+	if !b.Config.DiscordRPC || !b.activity {
+		return
+	}
+
+	if err := b.updateActivity(e); err != nil {
+		slog.Error("Activity Roblox event handle failed", "event", e.Type, "error", err)
+	}
+
+	if e.Type == events.Left {
+		b.idleActivity()
+	}
+}
+
+// updateActivity updates the presence with the given event.
+func (b *Binary) updateActivity(e events.Event) error {
+	g := bloxstraprpc.Game{
+		PlaceID:    e.Game.PlaceID,
+		UniverseID: e.Game.UniverseID,
+		JobID:      e.Game.JobID,
+		Server:     activityServers[e.Game.Server],
+	}
+
+	switch e.Type {
+	case events.Joined:
+		return b.Activity.JoinGame(g, e.Time)
+	case events.Teleported:
+		return b.Activity.TeleportGame(g)
+	case events.Located:
+		return b.Activity.SetLocation(g.JobID, e.Data)
+	case events.Message:
+		return b.Activity.HandleMessage(e.Data)
+	case events.Left:
+		return b.Activity.LeaveGame()
+	}
+
+	return nil
+}
+
+var activityServers = map[events.ServerType]bloxstraprpc.ServerType{
+	events.Public:   bloxstraprpc.Public,
+	events.Private:  bloxstraprpc.Private,
+	events.Reserved: bloxstraprpc.Reserved,
+}
+
+func (b *Binary) handleNotifyEvent(e events.Event) {
+	switch e.Type {
+	case events.Crashed:
+		body := "Roblox exited unexpectedly."
+		if b.Config.Watchdog {
+			body += " It will be relaunched by the watchdog."
+		}
+
+		b.notify(notify.Notification{
+			Summary: b.Alias + " crashed",
+			Body:    body,
+			Urgency: notify.Critical,
+		})
+	case events.Located:
+		slog.Info("Connected to server", "location", e.Data, "address", e.Game.Address)
+
+		b.Notify("Connected to server in "+e.Data, "")
+	}
+}
+
+// handleLocationEvent looks up the location of the joined game server in
+// the background, to publish it as a Located event.
+func (b *Binary) handleLocationEvent(e events.Event) {
+	if e.Type != events.Joined && e.Type != events.Teleported {
+		return
+	}
+
+	if !b.GlobalConfig.ServerLocation || e.Game.Address == "" {
+		return
+	}
+
+	go func() {
+		l, err := geoip.Lookup(b.GlobalConfig.ServerLocationAPI, e.Game.Address)
+		if errors.Is(err, geoip.ErrPrivate) {
+			return
+		}
+		if err != nil {
+			slog.Warn("Could not locate game server", "address", e.Game.Address, "error", err)
+			return
+		}
+
+		b.Events.Publish(events.Event{Type: events.Located, Game: e.Game, Data: l.String()})
+	}()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/vinegarhq/vinegar/config"
+)
+
+// FFlags handles the fflags command, which manages FFlag profiles and
+// exports the FFlags written to Roblox's ClientAppSettings.json.
+func FFlags(args []string) error {
+	if len(args) < 1 {
+		commandUsage("fflags")
+	}
+
+	switch args[0] {
+	case "list":
+		names, err := config.FFlagProfiles()
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			fmt.Println(name)
+		}
+	case "import":
+		if len(args) < 2 {
+			commandUsage("fflags")
+		}
+
+		name, err := config.ImportFFlagProfile(args[1])
+		if err != nil {
+			return fmt.Errorf("import %s: %w", args[1], err)
+		}
+
+		fmt.Printf("Imported FFlag profile %q, use it with fflag_profile = %q\n", name, name)
+	case "export":
+		fs := flag.NewFlagSet("export", flag.ExitOnError)
+		studio := fs.Bool("studio", false, "export Roblox Studio's FFlags")
+		fs.Usage = func() { commandUsage("fflags") }
+		fs.Parse(args[1:])
+
+		if fs.NArg() < 1 {
+			commandUsage("fflags")
+		}
+
+		cfg, err := config.Load(ConfigPath)
+		if err != nil {
+			return fmt.Errorf("load config %s: %w", ConfigPath, err)
+		}
+
+		f := cfg.Player.FFlags
+		if *studio {
+			f = cfg.Studio.FFlags
+		}
+
+		j, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(fs.Arg(0), j, 0o644)
+	default:
+		commandUsage("fflags")
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/retry"
+)
+
+// ActivityIdleTimeout is how long Discord RPC stays connected after leaving
+// a game, before disconnecting while idling in the menus.
+const ActivityIdleTimeout = 5 * time.Minute
+
+// applyGame applies the configuration of the game with the given place
+// ID over the Binary's own configuration, for Roblox to launch with.
+// The game is resolved from the protocol URI, or if Roblox wasn't
+// launched into a game, its first join.
+//
+// It must only be called while Roblox isn't running, as the session's
+// event handlers read the configuration: the game resolved by its join
+// is applied once Roblox has exited, taking effect when it is relaunched.
+func (b *Binary) applyGame(placeID string) error {
+	if b.base == nil {
+		b.base = b.Config
+	}
+
+	if placeID == b.placeID {
+		return nil
+	}
+	_, had := b.base.Games[b.placeID]
+	_, has := b.base.Games[placeID]
+	b.placeID = placeID
+
+	cfg, err := b.base.ForGame(placeID)
+	if err != nil {
+		return err
+	}
+	// Roblox may have requested another channel.
+	cfg.Channel = b.Config.Channel
+
+	prev := b.Config
+	b.Config = &cfg
+
+	if !had && !has {
+		return nil
+	}
+
+	slog.Info("Applying game configuration", "placeid", placeID)
+
+	for name := range prev.Env {
+		if _, ok := b.Config.Env[name]; !ok {
+			os.Unsetenv(name)
+		}
+	}
+	b.Config.Env.Setenv()
+
+	if err := b.Config.FFlags.Apply(b.Dir); err != nil {
+		return fmt.Errorf("apply fflags: %w", err)
+	}
+
+	return nil
+}
+
+// connectActivity connects to Discord RPC if it is enabled and not
+// already connected. As it is only connected to once a game is joined,
+// it is attempted again on the next join if Discord isn't running yet.
+func (b *Binary) connectActivity() {
+	if b.activityIdle != nil {
+		b.activityIdle.Stop()
+		b.activityIdle = nil
+	}
+
+	if !b.Config.DiscordRPC || b.activity {
+		return
+	}
+
+	if err := retry.Do("connect to discord rpc", retry.IPC, b.Activity.Connect); err != nil {
+		slog.Warn("Could not connect to Discord RPC, retrying on the next join", "error", err)
+		return
+	}
+
+	b.activity = true
+}
+
+// idleActivity disconnects from Discord RPC once the Binary has been idling
+// in the menus for [ActivityIdleTimeout], unless a game is joined before.
+func (b *Binary) idleActivity() {
+	var t *time.Timer
+	t = time.AfterFunc(ActivityIdleTimeout, func() {
+		b.activityMu.Lock()
+		defer b.activityMu.Unlock()
+
+		if b.activityIdle != t {
+			return
+		}
+		b.activityIdle = nil
+
+		slog.Info("Disconnecting from Discord RPC while idling")
+		b.closeActivity()
+	})
+	b.activityIdle = t
+}
+
+func (b *Binary) closeActivity() {
+	if !b.activity {
+		return
+	}
+
+	if err := b.Activity.Close(); err != nil {
+		slog.Error("Could not close Discord RPC", "error", err)
+	}
+	b.activity = false
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/godbus/dbus/v5"
+	"golang.org/x/sys/unix"
+)
+
+// RegisterGameMode registers the Roblox process with the given ID to
+// GameMode, and returns the IDs of the registered processes. If enabled,
+// all of the processes in the named process group are registered, which
+// includes the Wine processes that Roblox is ran with.
+//
+// If GameMode could not be registered to, the Roblox process alone is
+// reniced to the Binary's renice value instead, if set.
+func (b *Binary) RegisterGameMode(pgid, pid int) []int {
+	pids := []int{pid}
+	if b.Config.GameModeTree {
+		if g := ProcessGroup(pgid); len(g) > 0 {
+			pids = g
+		}
+	}
+
+	conn, err := SessionBus()
+	if err != nil {
+		slog.Error("Failed to connect to D-Bus", "error", err)
+		b.renice(pid)
+		return nil
+	}
+
+	desktop := conn.Object("org.freedesktop.portal.Desktop", "/org/freedesktop/portal/desktop")
+
+	var registered []int
+	for _, p := range pids {
+		var r int32
+		err := desktop.Call("org.freedesktop.portal.GameMode.RegisterGame", 0, int32(p)).Store(&r)
+		if err == nil && r >= 0 {
+			registered = append(registered, p)
+			continue
+		}
+
+		if err != nil && !errors.Is(err, dbus.ErrMsgNoObject) {
+			slog.Error("Failed to register to GameMode", "pid", p, "error", err)
+		}
+
+		b.renice(pid)
+		return registered
+	}
+
+	slog.Info("Registered to GameMode", "pids", registered)
+
+	return registered
+}
+
+// UnregisterGameMode unregisters the processes with the given IDs
+// from GameMode.
+func (b *Binary) UnregisterGameMode(pids []int) {
+	if len(pids) == 0 {
+		return
+	}
+
+	conn, err := SessionBus()
+	if err != nil {
+		slog.Error("Failed to connect to D-Bus", "error", err)
+		return
+	}
+
+	desktop := conn.Object("org.freedesktop.portal.Desktop", "/org/freedesktop/portal/desktop")
+
+	for _, pid := range pids {
+		// The processes have most likely exited, which GameMode
+		// may have already unregistered.
+		desktop.Call("org.freedesktop.portal.GameMode.UnregisterGame", 0, int32(pid))
+	}
+}
+
+// renice sets the niceness of the Roblox process with the given ID to the
+// Binary's renice value, lowering the niceness requires privileges.
+//
+// The niceness is of each thread rather than the process, and is only
+// inherited by threads created afterwards, so each thread is reniced.
+func (b *Binary) renice(pid int) {
+	if b.Config.Renice == 0 {
+		return
+	}
+
+	slog.Info("GameMode is unavailable, renicing Roblox", "pid", pid, "nice", b.Config.Renice)
+
+	for _, tid := range Threads(pid) {
+		if err := syscall.Setpriority(syscall.PRIO_PROCESS, tid, b.Config.Renice); err != nil {
+			slog.Error("Failed to renice Roblox", "pid", pid, "tid", tid, "error", err)
+			return
+		}
+	}
+}
+
+// SetAffinity restricts the Roblox process with the given ID, and each of
+// its threads, to the CPUs of the Binary's CPU affinity, if set.
+func (b *Binary) SetAffinity(pid int) {
+	cpus, err := b.Config.CPUs()
+	if err != nil || len(cpus) == 0 {
+		return
+	}
+
+	var set unix.CPUSet
+	for _, cpu := range cpus {
+		set.Set(cpu)
+	}
+
+	slog.Info("Setting Roblox CPU affinity", "pid", pid, "cpus", b.Config.CPUAffinity)
+
+	for _, tid := range Threads(pid) {
+		if err := unix.SchedSetaffinity(tid, &set); err != nil {
+			slog.Error("Failed to set Roblox CPU affinity", "pid", pid, "tid", tid, "error", err)
+			return
+		}
+	}
+}
+
+// robloxProcess returns the ID of the Roblox process within the process
+// group of the launched process with the given ID, which is the launched
+// process itself unless Roblox is ran with a launcher or Wine wrapper.
+func (b *Binary) robloxProcess(pgid int) int {
+	exe := filepath.Base(strings.ReplaceAll(b.executable(), `\`, "/"))
+
+	pid, ok := FindProcess(pgid, exe)
+	if !ok {
+		slog.Warn("Could not find Roblox process, using launched process", "exe", exe, "pid", pgid)
+		return pgid
+	}
+
+	slog.Info("Found Roblox process", "exe", exe, "pid", pid)
+	return pid
+}
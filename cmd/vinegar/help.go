@@ -0,0 +1,343 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/splash"
+)
+
+var ErrNoCommand = errors.New("no such command")
+
+// Command is the help of a Vinegar command.
+type Command struct {
+	Name     string
+	Args     string
+	Desc     string
+	Examples []string
+}
+
+// Commands holds the help of each of Vinegar's commands, in the order
+// they are listed in.
+var Commands = []Command{
+	{
+		Name: "player",
+		Args: "[-account name | -profile name] run [args...] | exec prog [args...] | channel | kill | paste | prefetch [-watch] [-interval d] | verify [-repair] | winetricks",
+		Desc: "Run Roblox Player, or manage its wineprefix and installation.\n" +
+			"Each named account has its own wineprefix, and profiles are accounts with\n" +
+			"their own configuration, set in [player.profiles.name]. Verifying the\n" +
+			"installation checks its files against the checksums stored when it was\n" +
+			"installed, without downloading anything, listing the files which are\n" +
+			"missing or corrupted. With -repair, only the packages of those files are\n" +
+			"downloaded to repair them. Modded files are skipped. Paste types the\n" +
+			"host's clipboard into Roblox, for when pasting within Roblox is broken;\n" +
+			"the clipboard is always shared with Wine, set clipboard to choose\n" +
+			"between the clipboard and the primary selection.",
+		Examples: []string{
+			"vinegar player run",
+			"vinegar player run -app",
+			"vinegar player -account alt run",
+			"vinegar player -profile alt run",
+			"vinegar player exec winecfg",
+			"vinegar player prefetch",
+			"vinegar player prefetch -watch -interval 30m",
+			"vinegar player verify",
+			"vinegar player verify -repair",
+		},
+	},
+	{
+		Name: "studio",
+		Args: "[-account name | -profile name] run [args...] | serve [-project dir] [-drive letter] [-port n] [-cmd command] [args...] | exec prog [args...] | channel | kill | prefetch [-watch] [-interval d] | verify [-repair] | winetricks",
+		Desc: "Run Roblox Studio, or manage its wineprefix and installation. Serve runs Studio alongside the command serving the project directory, rojo serve for Rojo projects, with the project mapped as a drive.",
+		Examples: []string{
+			"vinegar studio run",
+			"vinegar studio serve -project ~/game",
+			"vinegar studio serve -port 34873 -cmd 'rojo serve --port 34873 dev.project.json'",
+			"vinegar studio verify -repair",
+			"vinegar studio winetricks",
+		},
+	},
+	{
+		Name: "join",
+		Args: "[-account name | -profile name] placeID [-job id] [-private code] | -user name",
+		Desc: "Launch Roblox Player into a place, server, private server or a user's server.\n" +
+			"Joining a user's server requires presence_join to be enabled.",
+		Examples: []string{
+			"vinegar join 1818",
+			"vinegar join 1818 -job 2ff4d5a6-0d6f-4b2c-8e1a-fd6d1a0b1c2e",
+			"vinegar join -user builderman",
+		},
+	},
+	{
+		Name: "doctor",
+		Desc: "Check the system for issues which prevent Roblox from running.",
+	},
+	{
+		Name:     "kill",
+		Args:     "[player | studio]",
+		Desc:     "Kill the wineprefixes of Roblox Player and Studio, and their leftover processes.",
+		Examples: []string{"vinegar kill", "vinegar kill studio"},
+	},
+	{
+		Name: "sysinfo",
+		Desc: "Print information about the system, to include in bug reports.",
+	},
+	{
+		Name: "bugreport",
+		Args: "[-studio]",
+		Desc: "Write a bug report of the most recent launch to the logs directory, made of\n" +
+			"its logs, the system's information and the configuration without secrets.\n" +
+			"This is also done when Roblox crashes.",
+		Examples: []string{"vinegar bugreport", "vinegar bugreport -studio"},
+	},
+	{
+		Name:     "stats",
+		Args:     "-setup",
+		Desc:     "Print the time taken by each phase of the recent setups.",
+		Examples: []string{"vinegar stats -setup"},
+	},
+	{
+		Name:     "steam-shortcut",
+		Args:     "[placeID...]",
+		Desc:     "Add a Steam shortcut for Roblox Player, and for each of the given games.",
+		Examples: []string{"vinegar steam-shortcut", "vinegar steam-shortcut 1818 920587237"},
+	},
+	{
+		Name:     "open",
+		Args:     "url",
+		Desc:     "Open a URL with the host's browser, used by Wine when Roblox opens a link.",
+		Examples: []string{"vinegar open https://www.roblox.com"},
+	},
+	{
+		Name: "fflags",
+		Args: "list | import file | export [-studio] file",
+		Desc: "Manage FFlag profiles, used with fflag_profile.\n" +
+			"Importing a JSON file adds a profile named after the file, and exporting\n" +
+			"writes the FFlags that are given to Roblox.",
+		Examples: []string{
+			"vinegar fflags list",
+			"vinegar fflags import ~/Downloads/competitive.json",
+			"vinegar fflags export fflags.json",
+		},
+	},
+	{
+		Name: "mods",
+		Args: "list | disable | enable [-studio]",
+		Desc: "List the available mods in the order they are applied, set with mods.\n" +
+			"Disabling mods restores the files they replaced, until enabled.",
+		Examples: []string{
+			"vinegar mods list",
+			"vinegar mods disable -studio",
+		},
+	},
+	{
+		Name: "migrate-from-bloxstrap",
+		Args: "dir",
+		Desc: "Import the FFlags, modifications and settings of a Bloxstrap installation,\n" +
+			"such as from a mounted Windows partition, as the bloxstrap FFlag profile\n" +
+			"and mod. With an existing configuration file, the settings to add to it\n" +
+			"are printed instead.",
+		Examples: []string{
+			"vinegar migrate-from-bloxstrap /mnt/windows/Users/meow",
+			"vinegar migrate-from-bloxstrap ~/Bloxstrap",
+		},
+	},
+	{
+		Name: "migrate-from-grapejuice",
+		Args: "[-adopt]",
+		Desc: "Translate the settings of Grapejuice's Player and Studio wineprefixes, such\n" +
+			"as their renderer, Wine installation, FFlags and environment, into the\n" +
+			"configuration. The wineprefixes are offered to be adopted, being moved to\n" +
+			"Vinegar with their Roblox login; -adopt adopts them without asking. With\n" +
+			"an existing configuration file, the settings to add to it are printed instead.",
+		Examples: []string{
+			"vinegar migrate-from-grapejuice",
+			"vinegar migrate-from-grapejuice -adopt",
+		},
+	},
+	{
+		Name: "config",
+		Args: "edit | validate | show [-resolved]",
+		Desc: "Edit the configuration file with $EDITOR, list its errors with their lines,\n" +
+			"or show it. The resolved configuration includes the defaults, the system\n" +
+			"configuration at /etc/vinegar/config.toml and the values set by Vinegar,\n" +
+			"such as the environment. Keys locked by the system configuration cannot\n" +
+			"be changed.",
+		Examples: []string{
+			"vinegar config validate",
+			"vinegar config show -resolved",
+		},
+	},
+	{
+		Name: "edit",
+		Desc: "Edit the configuration file with $EDITOR, and check it for errors.\n" +
+			"Same as config edit.",
+	},
+	{
+		Name: "channels",
+		Args: "list",
+		Desc: "List the channels with an installed deployment of Roblox Player or Studio.\n" +
+			"Switching channels reuses the channel's installed deployment, which is\n" +
+			"kept until uninstalled.",
+		Examples: []string{"vinegar channels list"},
+	},
+	{
+		Name: "install-desktop",
+		Desc: "Install or update desktop entries and set Vinegar as the handler of Roblox\n" +
+			"links and files, such as roblox-player:// and roblox-studio:// URIs.\n" +
+			"Within Flatpak, the entries are installed on the host, running Vinegar\n" +
+			"through Flatpak. Also available as register.",
+	},
+	{
+		Name: "uninstall-desktop",
+		Desc: "Remove the desktop entries installed by install-desktop, and their\n" +
+			"associations. Also available as unregister.",
+	},
+	{
+		Name: "clean",
+		Args: "[-dry-run] [-shaders]",
+		Desc: "Remove old Roblox versions and cached packages, following keep_versions\n" +
+			"and max_cache_size_mb. This is also done after each update.\n" +
+			"The shader caches are only removed with -shaders.",
+		Examples: []string{"vinegar clean -dry-run", "vinegar clean -shaders"},
+	},
+	{
+		Name: "size",
+		Desc: "Print the disk usage of the Roblox versions, wineprefixes and shader caches.",
+	},
+	{
+		Name: "delete",
+		Desc: "Delete all of the wineprefixes.",
+	},
+	{
+		Name: "runner",
+		Args: "list | install <build | url> | use [-studio] <name | system>",
+		Desc: "List the runner backends and the installed and known Wine and Proton\n" +
+			"builds, install a build as a runner, or use an installed runner for\n" +
+			"the Player or Studio. A configured wineroot is used instead of it.",
+		Examples: []string{
+			"vinegar runner list",
+			"vinegar runner install GE-Proton9-1",
+			"vinegar runner use -studio wine-9.0-staging",
+			"vinegar runner use system",
+		},
+	},
+	{
+		Name: "mirrors",
+		Args: "test",
+		Desc: "Probe the latency of the deploy mirrors, including the configured mirror.\n" +
+			"With mirror = \"auto\", the fastest mirror is used, failing over to the\n" +
+			"next fastest mirror on errors.",
+		Examples: []string{"vinegar mirrors test"},
+	},
+	{
+		Name: "bundle",
+		Args: "create [-studio] <dir | file.tar>",
+		Desc: "Download the packages of the latest deployment of the configured channel,\n" +
+			"or the forced version, into a bundle directory or tar archive, for it\n" +
+			"to be installed on machines without access to Roblox.",
+		Examples: []string{"vinegar bundle create roblox.tar"},
+	},
+	{
+		Name: "install",
+		Args: "[-studio] -from <dir | file.tar>",
+		Desc: "Install the deployment within a bundle created by bundle create, with\n" +
+			"its packages verified against the bundle's manifest. Set update_policy\n" +
+			"to \"never\" for it to be launched without access to Roblox.",
+		Examples: []string{"vinegar install -from roblox.tar"},
+	},
+	{
+		Name: "uninstall",
+		Desc: "Remove all of the installed Roblox versions.",
+	},
+	{
+		Name: "version",
+		Desc: "Print Vinegar's version.",
+	},
+	{
+		Name:     "help",
+		Args:     "[command]",
+		Desc:     "Print the help of all commands, or of the named command.",
+		Examples: []string{"vinegar help join"},
+	},
+}
+
+// FindCommand returns the help of the named command.
+func FindCommand(name string) (Command, bool) {
+	for _, c := range Commands {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return Command{}, false
+}
+
+// WriteHelp writes the command's usage, description and examples to w.
+func (c *Command) WriteHelp(w io.Writer) {
+	fmt.Fprintf(w, "usage: vinegar [-config filepath] [-firstrun] [-refresh-sysinfo] [-tui] [-trace file] %s %s\n\n", c.Name, c.Args)
+	fmt.Fprintln(w, c.Desc)
+
+	if len(c.Examples) > 0 {
+		fmt.Fprintln(w, "\nexamples:")
+		for _, e := range c.Examples {
+			fmt.Fprintln(w, "  "+e)
+		}
+	}
+}
+
+func writeUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: vinegar [-config filepath] [-firstrun] [-refresh-sysinfo] [-tui] [-trace file] command [args...]")
+	fmt.Fprintln(w, "\ncommands:")
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, c := range Commands {
+		desc, _, _ := strings.Cut(c.Desc, "\n")
+		fmt.Fprintf(tw, "  %s\t%s\n", c.Name, desc)
+	}
+	tw.Flush()
+
+	fmt.Fprintln(w, "\nRun 'vinegar help command' for the usage and examples of a command.")
+	fmt.Fprintf(w, "Set $%s to window, gtk, terminal or none to choose how progress is shown.\n", splash.KindEnv)
+	fmt.Fprintf(w, "Executables within %s are run for each Roblox event, given as JSON on stdin.\n", dirs.Plugins)
+	fmt.Fprintf(w, "Starlark scripts within %s may hook into each launch and its events.\n", dirs.Scripts)
+}
+
+// Help prints the help of the named command, or of all commands if
+// no command is named.
+func Help(args []string) error {
+	if len(args) == 0 {
+		writeUsage(os.Stdout)
+		return nil
+	}
+
+	c, ok := FindCommand(args[0])
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoCommand, args[0])
+	}
+
+	c.WriteHelp(os.Stdout)
+	return nil
+}
+
+func usage() {
+	writeUsage(os.Stderr)
+	os.Exit(1)
+}
+
+// commandUsage prints the help of the named command and exits,
+// used when the command was given invalid arguments.
+func commandUsage(name string) {
+	c, ok := FindCommand(name)
+	if !ok {
+		usage()
+	}
+
+	c.WriteHelp(os.Stderr)
+	os.Exit(1)
+}
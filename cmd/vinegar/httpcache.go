@@ -0,0 +1,26 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/httpcache"
+	"github.com/vinegarhq/vinegar/roblox/api"
+)
+
+// CacheDeployments caches the responses of the client version and package
+// manifest requests, to revalidate them on each launch, and to keep using
+// them for a while if ClientSettings has an outage.
+func CacheDeployments() {
+	t := &httpcache.Transport{
+		Dir: dirs.HTTPCache,
+		Match: func(r *http.Request) bool {
+			return strings.Contains(r.URL.Path, "/v2/client-version/") ||
+				strings.HasSuffix(r.URL.Path, "-rbxPkgManifest.txt")
+		},
+	}
+
+	http.DefaultClient.Transport = t
+	api.SetClient(&http.Client{Transport: t})
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Inhibit flags of the Inhibit portal.
+const (
+	InhibitSuspend = 4
+	InhibitIdle    = 8
+)
+
+// Inhibit inhibits the session from going idle, such as by the screensaver
+// locking the screen, and from suspending automatically while Roblox is
+// running, as playing with only a controller does not count as activity.
+// The returned function releases the inhibition.
+func (b *Binary) Inhibit() func() {
+	conn, err := SessionBus()
+	if err != nil {
+		slog.Error("Failed to connect to D-Bus", "error", err)
+		return func() {}
+	}
+
+	desktop := conn.Object("org.freedesktop.portal.Desktop", "/org/freedesktop/portal/desktop")
+
+	var handle dbus.ObjectPath
+	err = desktop.Call("org.freedesktop.portal.Inhibit.Inhibit", 0,
+		"", uint32(InhibitSuspend|InhibitIdle),
+		map[string]dbus.Variant{"reason": dbus.MakeVariant("Playing " + b.Alias)},
+	).Store(&handle)
+	if err != nil {
+		slog.Error("Failed to inhibit idle", "error", err)
+		return func() {}
+	}
+
+	slog.Info("Inhibiting idle and suspend", "handle", handle)
+
+	return func() {
+		call := conn.Object("org.freedesktop.portal.Desktop", handle).
+			Call("org.freedesktop.portal.Request.Close", 0)
+		if call.Err != nil {
+			slog.Error("Failed to release idle inhibition", "error", call.Err)
+		}
+	}
+}
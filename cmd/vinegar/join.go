@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/roblox/api"
+	"github.com/vinegarhq/vinegar/roblox/protocol"
+)
+
+var (
+	ErrBadPlaceID      = errors.New("place id must be numeric")
+	ErrNoPresenceJoin  = errors.New("joining users requires presence_join to be enabled")
+	ErrUserNotJoinable = errors.New("user is not in a joinable game")
+)
+
+// JoinFlags are the flags of the join command, parsed along the
+// Binary's flags.
+type JoinFlags struct {
+	fs   *flag.FlagSet
+	join protocol.Join
+	user string
+}
+
+// NewJoinFlags defines the join command's flags in the given FlagSet.
+func NewJoinFlags(fs *flag.FlagSet) *JoinFlags {
+	jf := &JoinFlags{fs: fs}
+
+	fs.StringVar(&jf.join.JobID, "job", "", "id of the server to join")
+	fs.StringVar(&jf.join.LinkCode, "private", "", "link code of the private server to join")
+	fs.StringVar(&jf.user, "user", "", "name of the user to join the server of")
+
+	return jf
+}
+
+// URI returns the protocol URI to launch Player into the place and server
+// given by the join command's flags and remaining arguments: <placeID>
+// [-job id] [-private code], or -user name to join the server the named
+// user is in.
+//
+// If the user's cookie is available, the launch is authenticated with an
+// authentication ticket; otherwise Player authenticates the launch by itself.
+func (jf *JoinFlags) URI(cfg *config.Config, args []string) (string, error) {
+	if len(args) > 0 {
+		jf.join.PlaceID = args[0]
+
+		// Flags given after the place ID
+		jf.fs.Parse(args[1:])
+	}
+
+	j := jf.join
+
+	cookie, cookieErr := RobloxCookie()
+
+	if jf.user != "" {
+		if !cfg.PresenceJoin {
+			return "", ErrNoPresenceJoin
+		}
+		if cookieErr != nil {
+			return "", cookieErr
+		}
+
+		uj, err := UserJoin(cookie, jf.user)
+		if err != nil {
+			return "", err
+		}
+		j = uj
+	}
+
+	if j.PlaceID == "" {
+		commandUsage("join")
+	}
+
+	if _, err := strconv.ParseUint(j.PlaceID, 10, 64); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrBadPlaceID, j.PlaceID)
+	}
+
+	if cookieErr != nil {
+		slog.Info("Joining without authentication ticket", "reason", cookieErr)
+		return j.Experience(), nil
+	}
+
+	ticket, err := api.GetAuthTicket(cookie)
+	if err != nil {
+		return "", fmt.Errorf("authentication ticket: %w", err)
+	}
+
+	return j.Launch(ticket), nil
+}
+
+// UserJoin returns the Join for the server the named user is in, resolved
+// with the presence API.
+func UserJoin(cookie, name string) (protocol.Join, error) {
+	u, err := api.GetUserByName(cookie, name)
+	if err != nil {
+		return protocol.Join{}, fmt.Errorf("user: %w", err)
+	}
+
+	p, err := api.GetUserPresence(cookie, u.ID)
+	if err != nil {
+		return protocol.Join{}, fmt.Errorf("presence: %w", err)
+	}
+
+	if p.UserPresenceType != api.InGame || p.PlaceID == nil || p.GameID == nil {
+		return protocol.Join{}, fmt.Errorf("%w: %s", ErrUserNotJoinable, u.Name)
+	}
+
+	slog.Info("Joining user", "name", u.Name, "location", p.LastLocation)
+
+	return protocol.Join{
+		PlaceID: strconv.FormatInt(*p.PlaceID, 10),
+		JobID:   *p.GameID,
+	}, nil
+}
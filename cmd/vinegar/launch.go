@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/roblox/protocol"
+	"github.com/vinegarhq/vinegar/wine"
+)
+
+// KeptSessions is the amount of session directories of each Binary
+// kept within the logs directory.
+const KeptSessions = 5
+
+// recordLaunch writes a shell script to the session directory which
+// reproduces the given command: with its working directory, its entire
+// environment and the wrappers Roblox is ran with.
+//
+// As the logs directory is shared in bug reports, secrets such as the
+// Roblox cookie and the authentication ticket are redacted, and the
+// script is only readable by the user.
+func (b *Binary) recordLaunch(cmd *wine.Cmd) {
+	if b.sessionDir == "" {
+		return
+	}
+
+	if err := dirs.Mkdirs(b.sessionDir); err != nil {
+		slog.Error("Could not create session directory", "error", err)
+		return
+	}
+
+	b.launches++
+	path := filepath.Join(b.sessionDir, fmt.Sprintf("launch-%d.sh", b.launches))
+
+	if err := os.WriteFile(path, []byte(b.launchScript(cmd)), 0o700); err != nil {
+		slog.Error("Could not record launch", "error", err)
+		return
+	}
+
+	slog.Info("Recorded launch", "path", path)
+}
+
+func (b *Binary) launchScript(cmd *wine.Cmd) string {
+	var s strings.Builder
+
+	dir := cmd.Dir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+
+	// Everything ran before the Roblox executable is a wrapper, such
+	// as the launcher, the emulator and Wine itself.
+	exe := filepath.Join(b.Dir, b.executable())
+	wrappers := cmd.Args
+	for i, arg := range cmd.Args {
+		if arg == exe {
+			wrappers = cmd.Args[:i]
+			break
+		}
+	}
+
+	fmt.Fprintln(&s, "#!/bin/sh")
+	fmt.Fprintf(&s, "# Vinegar %s, %s launched at %s\n", Version, b.Alias, time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&s, "# Wrappers: %s\n", strings.Join(wrappers, " -> "))
+	fmt.Fprintf(&s, "cd %s || exit\n", shellQuote(dir))
+	fmt.Fprintln(&s, "exec env -i \\")
+	for _, kv := range cmd.Environ() {
+		if k, _, _ := strings.Cut(kv, "="); k == CookieEnv || config.SecretEnv(k) {
+			kv = k + "=" + config.Redacted
+		}
+		fmt.Fprintf(&s, "\t%s \\\n", shellQuote(kv))
+	}
+	fmt.Fprintf(&s, "\t%s", shellQuote(cmd.Path))
+	for _, arg := range cmd.Args[1:] {
+		fmt.Fprintf(&s, " %s", shellQuote(redactURI(arg)))
+	}
+	fmt.Fprintln(&s)
+
+	return s.String()
+}
+
+// redactURI replaces the authentication ticket within the named
+// argument if it is a protocol URI.
+func redactURI(arg string) string {
+	if !protocol.IsProtocol(arg) {
+		return arg
+	}
+
+	fields := strings.Split(arg, "+")
+	for i, f := range fields {
+		if k, _, ok := strings.Cut(f, ":"); ok && strings.EqualFold(k, "gameinfo") {
+			fields[i] = k + ":" + config.Redacted
+		}
+	}
+
+	return strings.Join(fields, "+")
+}
+
+// pruneSessions removes the session directories of the named Binary
+// within the logs directory, except for the latest [KeptSessions].
+func pruneSessions(name string) {
+	dirents, err := os.ReadDir(dirs.Logs)
+	if err != nil {
+		return
+	}
+
+	// Sessions are named by the time they were started at, which
+	// sorts them from oldest to newest.
+	var sessions []string
+	for _, d := range dirents {
+		if d.IsDir() && strings.HasPrefix(d.Name(), name+"-") {
+			sessions = append(sessions, d.Name())
+		}
+	}
+	slices.Sort(sessions)
+
+	for len(sessions) > KeptSessions {
+		path := filepath.Join(dirs.Logs, sessions[0])
+		sessions = sessions[1:]
+
+		slog.Info("Removing old session directory", "path", path)
+		if err := os.RemoveAll(path); err != nil {
+			slog.Error("Could not remove session directory", "error", err)
+		}
+	}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// lineLimiter limits the amount of lines allowed within each second.
+type lineLimiter struct {
+	rate    int
+	second  time.Time
+	count   int
+	dropped int
+}
+
+// allow determines if a line at the given time is within the rate limit,
+// and returns the amount of lines dropped since the last allowed line.
+func (l *lineLimiter) allow(now time.Time) (bool, int) {
+	if l.rate <= 0 {
+		return true, 0
+	}
+
+	if s := now.Truncate(time.Second); !s.Equal(l.second) {
+		l.second = s
+		l.count = 0
+	}
+
+	if l.count >= l.rate {
+		l.dropped++
+		return false, 0
+	}
+	l.count++
+
+	dropped := l.dropped
+	l.dropped = 0
+	return true, dropped
+}
+
+// robloxLogOutput returns the writer to forward the tailed Roblox log lines
+// to, as configured, with the given Vinegar log file. A nil writer is
+// returned if the lines should not be forwarded.
+func (b *Binary) robloxLogOutput(logFile *os.File) io.Writer {
+	switch b.GlobalConfig.RobloxLogs {
+	case "off":
+		return nil
+	case "file":
+		return logFile
+	case "both":
+		return io.MultiWriter(b.stderr, logFile)
+	default:
+		return b.stderr
+	}
+}
+
+// forwardLog forwards the given Roblox log line, if it is within the
+// configured rate limit.
+func (b *Binary) forwardLog(line string) {
+	if b.logOutput == nil {
+		return
+	}
+
+	ok, dropped := b.logLimit.allow(time.Now())
+	if !ok {
+		return
+	}
+
+	if dropped > 0 {
+		slog.Warn("Dropped Roblox log lines exceeding the rate limit",
+			"dropped", dropped, "rate", b.logLimit.rate)
+	}
+
+	fmt.Fprintln(b.logOutput, line)
+}
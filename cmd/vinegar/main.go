@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/lmittmann/tint"
 	"github.com/vinegarhq/vinegar/config"
@@ -17,23 +18,22 @@ import (
 )
 
 var (
-	BinPrefix  string
-	ConfigPath string
-	FirstRun   bool
-	Version    string
+	BinPrefix      string
+	ConfigPath     string
+	FirstRun       bool
+	RefreshSysinfo bool
+	TracePath      string
+	TUIMode        bool
+	Version        string
 )
 
 func init() {
 	flag.StringVar(&ConfigPath, "config", filepath.Join(dirs.Config, "config.toml"), "config.toml file which should be used")
 	flag.BoolVar(&FirstRun, "firstrun", false, "to trigger first run behavior")
-}
-
-func usage() {
-	fmt.Fprintln(os.Stderr, "usage: vinegar [-config filepath] [-firstrun] player|studio run [args...]")
-	fmt.Fprintln(os.Stderr, "       vinegar [-config filepath] player|studio kill|winetricks")
-	fmt.Fprintln(os.Stderr, "       vinegar [-config filepath] sysinfo")
-	fmt.Fprintln(os.Stderr, "       vinegar delete|edit|uninstall|version")
-	os.Exit(1)
+	flag.BoolVar(&RefreshSysinfo, "refresh-sysinfo", false, "detect the hardware information again instead of using the cache")
+	flag.BoolVar(&TUIMode, "tui", false, "show progress in the terminal instead of the splash window")
+	flag.StringVar(&TracePath, "trace", "", "write an execution trace of the launch to the given file")
+	flag.Usage = usage
 }
 
 func main() {
@@ -45,8 +45,32 @@ func main() {
 	slog.SetDefault(slog.New(tint.NewHandler(os.Stderr, nil)))
 
 	switch cmd {
-	case "delete", "edit", "uninstall", "version":
+	case "channels", "clean", "config", "delete", "edit", "fflags", "help", "migrate-from-bloxstrap", "migrate-from-grapejuice", "mods", "open", "install-desktop", "register", "size", "stats", "steam-shortcut", "uninstall-desktop", "unregister", "uninstall", "version":
+		// The configuration picks the GPU from the hardware information.
+		switch cmd {
+		case "clean", "config", "fflags", "mods":
+			loadHardware()
+		}
+
 		switch cmd {
+		case "channels":
+			if err := Channels(args[1:]); err != nil {
+				log.Fatalf("channels: %s", err)
+			}
+		case "clean":
+			fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+			dryRun := fs.Bool("dry-run", false, "only list what would be removed")
+			shaders := fs.Bool("shaders", false, "also remove the shader caches")
+			fs.Usage = func() { commandUsage(cmd) }
+			fs.Parse(args[1:])
+
+			if err := Clean(*dryRun, *shaders); err != nil {
+				log.Fatalf("clean: %s", err)
+			}
+		case "config":
+			if err := Config(args[1:]); err != nil {
+				log.Fatalf("config: %s", err)
+			}
 		case "delete":
 			if err := Delete(); err != nil {
 				log.Fatal(err)
@@ -55,6 +79,72 @@ func main() {
 			if err := editor.Edit(ConfigPath); err != nil {
 				log.Fatalf("edit %s: %s", ConfigPath, err)
 			}
+		case "fflags":
+			if err := FFlags(args[1:]); err != nil {
+				log.Fatalf("fflags: %s", err)
+			}
+		case "help":
+			if err := Help(args[1:]); err != nil {
+				log.Fatalf("help: %s", err)
+			}
+		case "migrate-from-bloxstrap":
+			if len(args) < 2 {
+				commandUsage(cmd)
+			}
+
+			if err := MigrateBloxstrap(args[1]); err != nil {
+				log.Fatalf("migrate from bloxstrap: %s", err)
+			}
+		case "migrate-from-grapejuice":
+			fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+			adopt := fs.Bool("adopt", false, "adopt the wineprefixes without asking")
+			fs.Usage = func() { commandUsage(cmd) }
+			fs.Parse(args[1:])
+
+			if err := MigrateGrapejuice(*adopt); err != nil {
+				log.Fatalf("migrate from grapejuice: %s", err)
+			}
+		case "mods":
+			if err := Mods(args[1:]); err != nil {
+				log.Fatalf("mods: %s", err)
+			}
+		case "open":
+			if len(args) < 2 {
+				commandUsage(cmd)
+			}
+
+			if err := OpenURL(args[1]); err != nil {
+				log.Fatalf("open %s: %s", args[1], err)
+			}
+		case "install-desktop", "register":
+			if err := InstallDesktop(); err != nil {
+				log.Fatalf("install desktop: %s", err)
+			}
+		case "size":
+			if err := Size(); err != nil {
+				log.Fatalf("size: %s", err)
+			}
+		case "stats":
+			fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+			setup := fs.Bool("setup", false, "time taken by each phase of the recent setups")
+			fs.Usage = func() { commandUsage(cmd) }
+			fs.Parse(args[1:])
+
+			if !*setup {
+				commandUsage(cmd)
+			}
+
+			if err := PrintSetupStats(); err != nil {
+				log.Fatalf("stats: %s", err)
+			}
+		case "steam-shortcut":
+			if err := AddSteamShortcuts(args[1:]); err != nil {
+				log.Fatalf("steam shortcut: %s", err)
+			}
+		case "uninstall-desktop", "unregister":
+			if err := UninstallDesktop(); err != nil {
+				log.Fatalf("uninstall desktop: %s", err)
+			}
 		case "uninstall":
 			if err := Uninstall(); err != nil {
 				log.Fatal(err)
@@ -62,7 +152,7 @@ func main() {
 		case "version":
 			fmt.Println("Vinegar", Version)
 		}
-	case "player", "studio", "sysinfo":
+	case "player", "studio", "join", "bugreport", "bundle", "doctor", "install", "kill", "mirrors", "runner", "sysinfo":
 		// Remove after a few releases
 		if _, err := os.Stat(dirs.Prefix); err == nil {
 			slog.Info("Deleting deprecated old Wineprefix!")
@@ -71,6 +161,9 @@ func main() {
 			}
 		}
 
+		loadHardware()
+		CacheDeployments()
+
 		cfg, err := config.Load(ConfigPath)
 		if err != nil {
 			log.Fatalf("load config %s: %s", ConfigPath, err)
@@ -78,43 +171,202 @@ func main() {
 
 		var bt roblox.BinaryType
 		switch cmd {
-		case "player":
+		case "player", "join":
 			bt = roblox.Player
 		case "studio":
 			bt = roblox.Studio
+		case "bugreport":
+			fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+			studio := fs.Bool("studio", false, "report Roblox Studio's most recent launch")
+			fs.Usage = func() { commandUsage(cmd) }
+			fs.Parse(args[1:])
+
+			bt := roblox.Player
+			if *studio {
+				bt = roblox.Studio
+			}
+
+			if err := NewBugReport(&cfg, bt); err != nil {
+				log.Fatalf("bugreport: %s", err)
+			}
+			os.Exit(0)
+		case "bundle":
+			fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+			studio := fs.Bool("studio", false, "bundle Roblox Studio")
+			fs.Usage = func() { commandUsage(cmd) }
+			if len(args) < 2 || args[1] != "create" {
+				commandUsage(cmd)
+			}
+			fs.Parse(args[2:])
+
+			if fs.NArg() != 1 {
+				commandUsage(cmd)
+			}
+
+			bt := roblox.Player
+			if *studio {
+				bt = roblox.Studio
+			}
+
+			if err := CreateBundle(&cfg, bt, fs.Arg(0)); err != nil {
+				log.Fatalf("bundle: %s", err)
+			}
+			os.Exit(0)
+		case "install":
+			fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+			studio := fs.Bool("studio", false, "install Roblox Studio")
+			from := fs.String("from", "", "bundle directory or tar archive to install from")
+			fs.Usage = func() { commandUsage(cmd) }
+			fs.Parse(args[1:])
+
+			if *from == "" {
+				commandUsage(cmd)
+			}
+
+			bt := roblox.Player
+			if *studio {
+				bt = roblox.Studio
+			}
+
+			b, err := NewBinary(bt, "", &cfg)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if err := b.InstallBundle(*from); err != nil {
+				log.Fatalf("install %s: %s", bt, err)
+			}
+			os.Exit(0)
+		case "kill":
+			if err := Kill(&cfg, args[1:]); err != nil {
+				log.Fatalf("kill: %s", err)
+			}
+			os.Exit(0)
+		case "mirrors":
+			if err := Mirrors(&cfg, args[1:]); err != nil {
+				log.Fatalf("mirrors: %s", err)
+			}
+			os.Exit(0)
+		case "runner":
+			if err := Runner(&cfg, args[1:]); err != nil {
+				log.Fatalf("runner: %s", err)
+			}
+			os.Exit(0)
+		case "doctor":
+			if err := Doctor(&cfg); err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
 		case "sysinfo":
 			PrintSysinfo(&cfg)
 			os.Exit(0)
 		}
 
-		b, err := NewBinary(bt, &cfg)
+		fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+		account := fs.String("account", "", "named account to use, which has its own wineprefix")
+		fs.StringVar(account, "profile", "", "named profile to use, an account with its own configuration")
+		var jf *JoinFlags
+		if cmd == "join" {
+			jf = NewJoinFlags(fs)
+		}
+		fs.Usage = func() { commandUsage(cmd) }
+		fs.Parse(args[1:])
+		args = fs.Args()
+
+		b, err := NewBinary(bt, *account, &cfg)
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		switch flag.Arg(1) {
+		if cmd == "join" {
+			uri, err := jf.URI(&cfg, args)
+			if err != nil {
+				log.Fatalf("join: %s", err)
+			}
+
+			if code := b.Main(uri); code > 0 {
+				os.Exit(code)
+			}
+			return
+		}
+
+		switch fs.Arg(0) {
 		case "exec":
 			if len(args) < 2 {
-				usage()
+				commandUsage(cmd)
 			}
 
-			if err := b.Prefix.Wine(args[2], args[3:]...).Run(); err != nil {
+			if err := b.Prefix.Wine(args[1], args[2:]...).Run(); err != nil {
 				log.Fatalf("exec prefix %s: %s", bt, err)
 			}
+		case "channel":
+			if err := b.PrintChannel(); err != nil {
+				log.Fatalf("channel %s: %s", bt, err)
+			}
 		case "kill":
-			b.Prefix.Kill()
+			if err := Reap(b.Prefix); err != nil {
+				log.Fatalf("kill %s: %s", bt, err)
+			}
+		case "paste":
+			if err := b.Paste(); err != nil {
+				log.Fatalf("paste %s: %s", bt, err)
+			}
+		case "prefetch":
+			pf := flag.NewFlagSet("prefetch", flag.ExitOnError)
+			watch := pf.Bool("watch", false, "keep prefetching updates every interval")
+			interval := pf.Duration("interval", time.Hour, "interval between prefetches with -watch")
+			pf.Usage = func() { commandUsage(cmd) }
+			pf.Parse(args[1:])
+
+			if *watch {
+				err = b.PrefetchWatch(*interval)
+			} else {
+				err = b.Prefetch()
+			}
+			if err != nil {
+				log.Fatalf("prefetch %s: %s", bt, err)
+			}
+		case "verify":
+			vf := flag.NewFlagSet("verify", flag.ExitOnError)
+			repair := vf.Bool("repair", false, "repair the packages of the missing or corrupted files")
+			vf.Usage = func() { commandUsage(cmd) }
+			vf.Parse(args[1:])
+
+			if err := b.Verify(*repair); err != nil {
+				log.Fatalf("verify %s: %s", bt, err)
+			}
 		case "winetricks":
 			if err := b.Prefix.Winetricks(); err != nil {
 				log.Fatalf("exec winetricks %s: %s", bt, err)
 			}
 		case "run":
-			if code := b.Main(args[2:]...); code > 0 {
+			if code := b.Main(args[1:]...); code > 0 {
+				os.Exit(code)
+			}
+		case "serve":
+			if bt != roblox.Studio {
+				commandUsage(cmd)
+			}
+
+			var o ServeOptions
+			sf := flag.NewFlagSet("serve", flag.ExitOnError)
+			sf.StringVar(&o.Dir, "project", ".", "project directory mapped within Studio")
+			sf.StringVar(&o.Drive, "drive", "r", "drive letter the project directory is mapped as")
+			sf.IntVar(&o.Port, "port", RojoPort, "localhost port the project is served on")
+			sf.StringVar(&o.Command, "cmd", "", "command serving the project, rojo serve for Rojo projects")
+			sf.Usage = func() { commandUsage(cmd) }
+			sf.Parse(args[1:])
+
+			if code := b.Serve(o, sf.Args()...); code > 0 {
 				os.Exit(code)
 			}
 		default:
-			usage()
+			commandUsage(cmd)
 		}
+	case "":
+		usage()
 	default:
+		fmt.Fprintf(os.Stderr, "vinegar: unknown command %q\n\n", cmd)
 		usage()
 	}
 }
@@ -132,7 +384,9 @@ func Delete() error {
 	}
 
 	s.Player.DxvkVersion = ""
+	s.Player.Accounts = nil
 	s.Studio.DxvkVersion = ""
+	s.Studio.Accounts = nil
 
 	if err := s.Save(); err != nil {
 		return fmt.Errorf("save state: %w", err)
@@ -155,8 +409,12 @@ func Uninstall() error {
 
 	s.Player.Version = ""
 	s.Player.Packages = nil
+	s.Player.Prefetched = nil
+	s.Player.Deployments = nil
 	s.Studio.Version = ""
 	s.Studio.Packages = nil
+	s.Studio.Prefetched = nil
+	s.Studio.Deployments = nil
 
 	if err := s.Save(); err != nil {
 		return fmt.Errorf("save state: %w", err)
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// MemoryWatchInterval is the interval in which the memory usage of Roblox
+// is checked by the memory watchdog.
+const MemoryWatchInterval = 5 * time.Second
+
+// meminfo returns the amount of memory of the named /proc/meminfo field.
+func meminfo(field string) (int64, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		v, ok := strings.CutPrefix(s.Text(), field+":")
+		if !ok {
+			continue
+		}
+
+		kb, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(v), " kB"), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		return kb * 1024, nil
+	}
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, fmt.Errorf("meminfo: no %s", field)
+}
+
+// memAvailable returns the amount of memory available for starting new
+// applications without swapping.
+func memAvailable() (int64, error) {
+	return meminfo("MemAvailable")
+}
+
+// memoryLimit returns the amount of memory the named process may use
+// before being killed: the system's memory, or the limit of the
+// process's cgroup if it is lower.
+func memoryLimit(pid int) (int64, error) {
+	total, err := meminfo("MemTotal")
+	if err != nil {
+		return 0, err
+	}
+
+	cg, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return total, nil
+	}
+
+	// Only the unified hierarchy of cgroup v2 is supported.
+	for _, l := range strings.Split(string(cg), "\n") {
+		path, ok := strings.CutPrefix(l, "0::")
+		if !ok {
+			continue
+		}
+
+		max, err := os.ReadFile(filepath.Join("/sys/fs/cgroup", path, "memory.max"))
+		if err != nil {
+			break
+		}
+
+		// The limit is "max" if there isn't one.
+		if n, err := strconv.ParseInt(string(bytes.TrimSpace(max)), 10, 64); err == nil && n < total {
+			return n, nil
+		}
+	}
+
+	return total, nil
+}
+
+// residentMemory returns the total resident memory of the given processes.
+func residentMemory(pids []int) (rss int64) {
+	for _, pid := range pids {
+		statm, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "statm"))
+		if err != nil {
+			continue
+		}
+
+		f := strings.Fields(string(statm))
+		if len(f) < 2 {
+			continue
+		}
+
+		if pages, err := strconv.ParseInt(f[1], 10, 64); err == nil {
+			rss += pages * int64(os.Getpagesize())
+		}
+	}
+
+	return
+}
+
+// WatchMemory checks the memory used by the processes of the named Roblox
+// process group until done is closed, and warns or stops Roblox once it
+// reaches memory_limit percent of the memory it may use, as Roblox being
+// killed for running out of memory is otherwise indistinguishable from
+// a crash.
+func (b *Binary) WatchMemory(pgid, pid int, done <-chan struct{}) {
+	defer b.recoverPanic()
+
+	limit, err := memoryLimit(pid)
+	if err != nil {
+		slog.Error("Could not determine memory limit", "error", err)
+		return
+	}
+	threshold := limit / 100 * int64(b.Config.MemoryLimit)
+
+	slog.Info("Watching Roblox memory usage", "limit", limit, "threshold", threshold)
+
+	t := time.NewTicker(MemoryWatchInterval)
+	defer t.Stop()
+
+	warned := false
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+		}
+
+		rss := residentMemory(ProcessGroup(pgid))
+		if rss < threshold {
+			warned = false
+			continue
+		}
+
+		if warned {
+			continue
+		}
+		warned = true
+
+		used := fmt.Sprintf("%d MiB of %d MiB", rss>>20, limit>>20)
+		slog.Warn("Roblox is running out of memory", "rss", rss, "limit", limit)
+
+		if b.Config.MemoryWatchdog == "kill" {
+			b.Notify("Roblox ran out of memory", "Roblox was stopped after using "+used+" of memory.")
+
+			// Stopped as if Roblox refused to die, as it is not the user's doing.
+			syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+			return
+		}
+
+		b.Notify("Roblox is running out of memory", "Roblox is using "+used+" of memory, and may be killed.")
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/internal/bloxstrap"
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/grapejuice"
+	"github.com/vinegarhq/vinegar/internal/state"
+	"github.com/vinegarhq/vinegar/roblox"
+	"golang.org/x/term"
+)
+
+// BloxstrapName is the name of the FFlag profile and mod migrated
+// from Bloxstrap.
+const BloxstrapName = "bloxstrap"
+
+// MigrateBloxstrap handles the migrate-from-bloxstrap command, which imports
+// the FFlags, mods and settings of the Bloxstrap installation within the
+// named directory as the Player's FFlag profile, mods and configuration.
+func MigrateBloxstrap(dir string) error {
+	bs, err := bloxstrap.Find(dir)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Migrating Bloxstrap installation", "dir", bs.Dir)
+
+	player := make(map[string]any)
+
+	if p, ok := bs.FFlagsPath(); ok {
+		if err := config.ImportFFlagProfileAs(p, BloxstrapName); err != nil {
+			return fmt.Errorf("import fflags: %w", err)
+		}
+
+		slog.Info("Imported Bloxstrap FFlags", "profile", BloxstrapName)
+		player["fflag_profile"] = BloxstrapName
+	}
+
+	// Bloxstrap's own modifications take precedence over its presets.
+	ms := bs.Settings.Presets()
+
+	modDir := filepath.Join(dirs.Mods, BloxstrapName)
+	if err := os.RemoveAll(modDir); err != nil {
+		return err
+	}
+
+	n, err := bs.CopyMods(modDir)
+	if err != nil {
+		return fmt.Errorf("copy mods: %w", err)
+	}
+	if n > 0 {
+		slog.Info("Imported Bloxstrap modifications", "mod", BloxstrapName, "files", n)
+		ms = append(ms, BloxstrapName)
+	}
+
+	if len(ms) > 0 {
+		player["mods"] = ms
+	}
+	if rpc := bs.Settings.UseDiscordRichPresence; rpc != nil {
+		player["discord_rpc"] = *rpc
+	}
+	if c := bs.Settings.DeploymentChannel(); c != "" {
+		player["channel"] = c
+	}
+
+	if len(player) == 0 {
+		return writeMigration("Bloxstrap", nil)
+	}
+
+	return writeMigration("Bloxstrap", map[string]any{"player": player})
+}
+
+// MigrateGrapejuice handles the migrate-from-grapejuice command, which
+// translates the settings of Grapejuice's Player and Studio wineprefixes into
+// the configuration, and adopts the wineprefixes if accepted or adopt is set,
+// keeping the Roblox login within them.
+func MigrateGrapejuice(adopt bool) error {
+	gs, err := grapejuice.Load(grapejuice.SettingsPath)
+	if err != nil {
+		return err
+	}
+
+	s, err := state.Load()
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	tables := make(map[string]any)
+	adopted := make(map[string]bool)
+
+	for _, b := range []struct {
+		bt   roblox.BinaryType
+		bs   *state.Binary
+		hint string
+	}{
+		{roblox.Player, &s.Player, grapejuice.PlayerHint},
+		{roblox.Studio, &s.Studio, grapejuice.StudioHint},
+	} {
+		p := gs.Prefix(b.hint)
+		if p == nil {
+			continue
+		}
+
+		table := make(map[string]any)
+		if r := p.VinegarRenderer(); r != "" {
+			table["renderer"] = r
+		}
+		if w := gs.WineRoot(p); w != "" {
+			table["wineroot"] = w
+		}
+		if len(p.FFlags) > 0 {
+			table["fflags"] = p.FFlags
+		}
+		if len(p.Env) > 0 {
+			table["env"] = p.Env
+		}
+		if len(table) > 0 {
+			tables[strings.ToLower(b.bt.String())] = table
+		}
+
+		src := p.Dir(grapejuice.PrefixesDir)
+		dst := BinaryPrefixDir(b.bt, "")
+		if _, err := os.Stat(src); err != nil || adopted[p.Name] {
+			continue
+		}
+		if _, err := os.Stat(dst); err == nil {
+			slog.Warn("Not adopting Grapejuice wineprefix, as the Binary already has a wineprefix",
+				"name", p.DisplayName, "binary", b.bt, "dir", dst)
+			continue
+		}
+
+		if !adopt && !confirm(fmt.Sprintf("Adopt Grapejuice's %s wineprefix for %s, keeping its Roblox login?",
+			p.DisplayName, b.bt)) {
+			continue
+		}
+
+		slog.Info("Adopting Grapejuice wineprefix", "name", p.DisplayName, "binary", b.bt, "dir", src)
+
+		if err := dirs.Mkdirs(dirs.Prefixes); err != nil {
+			return err
+		}
+		if err := moveDir(src, dst); err != nil {
+			return fmt.Errorf("adopt %s: %w", src, err)
+		}
+		adopted[p.Name] = true
+
+		// What was set up by Grapejuice is unknown, and is set up again.
+		b.bs.Prefix = state.Prefix{}
+	}
+
+	if len(adopted) > 0 {
+		if err := s.Save(); err != nil {
+			return fmt.Errorf("save state: %w", err)
+		}
+	}
+
+	return writeMigration("Grapejuice", tables)
+}
+
+// writeMigration writes the configuration tables migrated from the named
+// launcher to the configuration file if there is none yet, as it would
+// otherwise be rewritten without its comments; the tables to add to it
+// are printed instead.
+func writeMigration(from string, tables map[string]any) error {
+	if len(tables) == 0 {
+		fmt.Println(from, "has no settings to migrate")
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(tables); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(ConfigPath); !errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("Migrated %s, add the following to %s:\n\n%s", from, ConfigPath, buf.Bytes())
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ConfigPath), 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(ConfigPath, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrated %s to %s\n", from, ConfigPath)
+	return nil
+}
+
+// confirm asks the given question on the terminal, and reports whether
+// it was accepted. Without a terminal, nothing is accepted.
+func confirm(question string) bool {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false
+	}
+
+	fmt.Printf("%s [y/N] ", question)
+
+	var answer string
+	fmt.Scanln(&answer)
+
+	return strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes")
+}
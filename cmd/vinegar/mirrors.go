@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vinegarhq/vinegar/config"
+	boot "github.com/vinegarhq/vinegar/roblox/bootstrapper"
+)
+
+// Mirrors handles the mirrors command, which probes the known deploy
+// mirrors and the configured mirror, and prints them fastest first.
+func Mirrors(cfg *config.Config, args []string) error {
+	if len(args) < 1 || args[0] != "test" {
+		commandUsage("mirrors")
+	}
+
+	ms := slices.Clone(boot.Mirrors)
+	configured := strings.TrimSuffix(cfg.Mirror, "/")
+	if cfg.Mirror != "" && cfg.Mirror != "auto" && !slices.Contains(ms, configured) {
+		ms = append(ms, configured)
+	}
+
+	for i, p := range boot.ProbeMirrors(context.Background(), ms) {
+		name := p.Mirror
+		if p.Mirror == configured {
+			name += " (configured)"
+		}
+
+		if p.Err != nil {
+			fmt.Printf("-  %s: %s\n", name, p.Err)
+			continue
+		}
+
+		fmt.Printf("%d. %s: %s\n", i+1, name, p.Latency.Round(time.Millisecond))
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/mods"
+	"github.com/vinegarhq/vinegar/internal/state"
+)
+
+// Mods handles the mods command, which lists the available mods and
+// disables or enables applying them to a Binary's version directory.
+func Mods(args []string) error {
+	if len(args) < 1 {
+		commandUsage("mods")
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ExitOnError)
+	studio := fs.Bool("studio", false, "manage Roblox Studio's mods")
+	fs.Usage = func() { commandUsage("mods") }
+	fs.Parse(args[1:])
+
+	cfg, err := config.Load(ConfigPath)
+	if err != nil {
+		return fmt.Errorf("load config %s: %w", ConfigPath, err)
+	}
+
+	s, err := state.Load()
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	bcfg, bs := &cfg.Player, &s.Player
+	if *studio {
+		bcfg, bs = &cfg.Studio, &s.Studio
+	}
+
+	switch args[0] {
+	case "list":
+		return listMods(bcfg, bs)
+	case "disable":
+		bs.ModsDisabled = true
+
+		if bs.Version != "" && len(bs.Mods) > 0 {
+			dir := filepath.Join(dirs.Versions, bs.Version)
+			if err := mods.Apply(dir, nil, bs.Mods); err != nil {
+				return fmt.Errorf("restore %s: %w", dir, err)
+			}
+		}
+
+		fmt.Println("Mods are disabled, and the replaced files have been restored")
+	case "enable":
+		bs.ModsDisabled = false
+
+		fmt.Println("Mods are enabled, and will be applied on the next launch")
+	default:
+		commandUsage("mods")
+	}
+
+	return s.Save()
+}
+
+func listMods(bcfg *config.Binary, bs *state.Binary) error {
+	entries, err := os.ReadDir(dirs.Mods)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	kinds := make(map[string]string)
+	for name, p := range mods.Presets {
+		kinds[name] = "preset: " + p.Description
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			kinds[e.Name()] = "directory"
+		}
+	}
+
+	// The enabled mods are listed first, in the order they are applied.
+	for i, name := range bcfg.Mods {
+		kind, ok := kinds[name]
+		if !ok {
+			kind = "missing"
+		}
+		delete(kinds, name)
+
+		fmt.Printf("%d. %s (%s)\n", i+1, name, kind)
+	}
+
+	var names []string
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("-  %s (%s)\n", name, kinds[name])
+	}
+
+	if bs.ModsDisabled {
+		fmt.Println("\nMods are disabled, enable them with: vinegar mods enable")
+	}
+
+	return nil
+}
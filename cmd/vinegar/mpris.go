@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+const (
+	MPRISPrefix = "org.mpris.MediaPlayer2."
+	MPRISPath   = "/org/mpris/MediaPlayer2"
+
+	mprisRootIface   = "org.mpris.MediaPlayer2"
+	mprisPlayerIface = "org.mpris.MediaPlayer2.Player"
+)
+
+// mprisRoot implements the org.mpris.MediaPlayer2 interface.
+type mprisRoot struct{}
+
+func (mprisRoot) Raise() *dbus.Error { return nil }
+
+// Quit stops Roblox the same way as an interrupt would.
+func (mprisRoot) Quit() *dbus.Error {
+	syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	return nil
+}
+
+// mprisPlayer implements the org.mpris.MediaPlayer2.Player interface,
+// passing through the media controls to another media player, as the media
+// keys sent to Vinegar's player would have otherwise been meant for them.
+type mprisPlayer struct {
+	conn *dbus.Conn
+	name string
+}
+
+// target returns the media player to pass the media controls through to,
+// preferring a player which is playing over one which is paused.
+func (m *mprisPlayer) target() dbus.BusObject {
+	var names []string
+	err := m.conn.BusObject().Call("org.freedesktop.DBus.ListNames", 0).Store(&names)
+	if err != nil {
+		slog.Error("Failed to list MPRIS players", "error", err)
+		return nil
+	}
+
+	var paused dbus.BusObject
+	for _, n := range names {
+		if !strings.HasPrefix(n, MPRISPrefix) || n == m.name {
+			continue
+		}
+
+		obj := m.conn.Object(n, MPRISPath)
+		v, err := obj.GetProperty(mprisPlayerIface + ".PlaybackStatus")
+		if err != nil {
+			continue
+		}
+
+		switch v.Value() {
+		case "Playing":
+			return obj
+		case "Paused":
+			if paused == nil {
+				paused = obj
+			}
+		}
+	}
+
+	return paused
+}
+
+func (m *mprisPlayer) forward(method string) *dbus.Error {
+	obj := m.target()
+	if obj == nil {
+		slog.Info("No media player to pass media control to", "method", method)
+		return nil
+	}
+
+	slog.Info("Passing media control", "player", obj.Destination(), "method", method)
+
+	if err := obj.Call(mprisPlayerIface+"."+method, 0).Err; err != nil {
+		slog.Error("Failed to pass media control", "player", obj.Destination(), "error", err)
+	}
+
+	return nil
+}
+
+func (m *mprisPlayer) Next() *dbus.Error      { return m.forward("Next") }
+func (m *mprisPlayer) Previous() *dbus.Error  { return m.forward("Previous") }
+func (m *mprisPlayer) Pause() *dbus.Error     { return m.forward("Pause") }
+func (m *mprisPlayer) PlayPause() *dbus.Error { return m.forward("PlayPause") }
+func (m *mprisPlayer) Stop() *dbus.Error      { return m.forward("Stop") }
+func (m *mprisPlayer) Play() *dbus.Error      { return m.forward("Play") }
+
+// Seeking is not supported, Seek is left out as CanSeek is false.
+
+func (m *mprisPlayer) SetPosition(track dbus.ObjectPath, pos int64) *dbus.Error {
+	return nil
+}
+
+func (m *mprisPlayer) OpenUri(uri string) *dbus.Error {
+	return nil
+}
+
+func (m *mprisPlayer) volume(c *prop.Change) *dbus.Error {
+	obj := m.target()
+	if obj == nil {
+		return nil
+	}
+
+	err := obj.SetProperty(mprisPlayerIface+".Volume", dbus.MakeVariant(c.Value))
+	if err != nil {
+		slog.Error("Failed to pass volume", "player", obj.Destination(), "error", err)
+	}
+
+	return nil
+}
+
+// ExportMPRIS exposes a minimal MPRIS media player for Roblox, so that the
+// media keys and volume controls of the desktop or compositor - such as
+// gamescope's - which are sent to Roblox while it is focused, are passed
+// through to the other running media players. The returned function
+// removes the media player.
+func (b *Binary) ExportMPRIS() func() {
+	conn, err := SessionBus()
+	if err != nil {
+		slog.Error("Failed to connect to D-Bus", "error", err)
+		return func() {}
+	}
+
+	name := fmt.Sprintf("%svinegar.instance%d", MPRISPrefix, os.Getpid())
+	player := &mprisPlayer{conn: conn, name: name}
+
+	props, err := prop.Export(conn, MPRISPath, prop.Map{
+		mprisRootIface: {
+			"CanQuit":             {Value: true, Emit: prop.EmitConst},
+			"CanRaise":            {Value: false, Emit: prop.EmitConst},
+			"HasTrackList":        {Value: false, Emit: prop.EmitConst},
+			"Identity":            {Value: b.Alias, Emit: prop.EmitConst},
+			"DesktopEntry":        {Value: "org.vinegarhq.Vinegar." + strings.ToLower(b.Type.String()), Emit: prop.EmitConst},
+			"SupportedUriSchemes": {Value: []string{}, Emit: prop.EmitConst},
+			"SupportedMimeTypes":  {Value: []string{}, Emit: prop.EmitConst},
+		},
+		mprisPlayerIface: {
+			"PlaybackStatus": {Value: "Playing", Emit: prop.EmitConst},
+			"Rate":           {Value: 1.0, Emit: prop.EmitConst},
+			"MinimumRate":    {Value: 1.0, Emit: prop.EmitConst},
+			"MaximumRate":    {Value: 1.0, Emit: prop.EmitConst},
+			"Volume":         {Value: 1.0, Writable: true, Emit: prop.EmitTrue, Callback: player.volume},
+			"Position":       {Value: int64(0), Emit: prop.EmitFalse},
+			"CanGoNext":      {Value: true, Emit: prop.EmitConst},
+			"CanGoPrevious":  {Value: true, Emit: prop.EmitConst},
+			"CanPlay":        {Value: true, Emit: prop.EmitConst},
+			"CanPause":       {Value: true, Emit: prop.EmitConst},
+			"CanSeek":        {Value: false, Emit: prop.EmitConst},
+			"CanControl":     {Value: true, Emit: prop.EmitConst},
+			"Metadata": {Value: map[string]dbus.Variant{
+				"mpris:trackid": dbus.MakeVariant(dbus.ObjectPath("/org/vinegarhq/Vinegar/" + b.Type.String())),
+				"xesam:title":   dbus.MakeVariant(b.Alias),
+			}, Emit: prop.EmitConst},
+		},
+	})
+	if err != nil {
+		slog.Error("Failed to export MPRIS properties", "error", err)
+		return func() {}
+	}
+
+	root := mprisRoot{}
+	node := &introspect.Node{
+		Name: MPRISPath,
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			prop.IntrospectData,
+			{
+				Name:       mprisRootIface,
+				Methods:    introspect.Methods(root),
+				Properties: props.Introspection(mprisRootIface),
+			},
+			{
+				Name:       mprisPlayerIface,
+				Methods:    introspect.Methods(player),
+				Properties: props.Introspection(mprisPlayerIface),
+			},
+		},
+	}
+
+	for iface, v := range map[string]interface{}{
+		mprisRootIface:                        root,
+		mprisPlayerIface:                      player,
+		"org.freedesktop.DBus.Introspectable": introspect.NewIntrospectable(node),
+	} {
+		if err := conn.Export(v, MPRISPath, iface); err != nil {
+			slog.Error("Failed to export MPRIS interface", "interface", iface, "error", err)
+			return func() {}
+		}
+	}
+
+	reply, err := conn.RequestName(name, dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		slog.Error("Failed to own MPRIS name", "name", name, "error", err)
+		return func() {}
+	}
+
+	slog.Info("Exported MPRIS media player", "name", name)
+
+	return func() {
+		if _, err := conn.ReleaseName(name); err != nil {
+			slog.Error("Failed to release MPRIS name", "error", err)
+		}
+	}
+}
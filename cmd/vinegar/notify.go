@@ -0,0 +1,67 @@
+package main
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/vinegarhq/vinegar/internal/notify"
+	"github.com/vinegarhq/vinegar/internal/retry"
+)
+
+// SessionBus returns the shared connection to the D-Bus session bus,
+// retrying with [retry.IPC], as the session bus may still be starting
+// up during login.
+func SessionBus() (conn *dbus.Conn, err error) {
+	err = retry.Do("connect to d-bus", retry.IPC, func() error {
+		conn, err = dbus.ConnectSessionBus()
+		return err
+	})
+
+	return
+}
+
+var (
+	notifierOnce sync.Once
+	notifier     *notify.Notifier
+)
+
+// Notifier returns the Notifier used to send desktop notifications on
+// behalf of Vinegar, which is nil if the session bus is unavailable.
+func Notifier() *notify.Notifier {
+	notifierOnce.Do(func() {
+		conn, err := SessionBus()
+		if err != nil {
+			slog.Error("Failed to connect to D-Bus", "error", err)
+			return
+		}
+
+		notifier = notify.New(conn, "Vinegar", "org.vinegarhq.Vinegar")
+	})
+
+	return notifier
+}
+
+// Notify sends a desktop notification with the given summary and body,
+// unless notifications are disabled, and returns its ID.
+func (b *Binary) Notify(summary, body string) uint32 {
+	return b.notify(notify.Notification{Summary: summary, Body: body})
+}
+
+func (b *Binary) notify(nt notify.Notification) uint32 {
+	if !b.GlobalConfig.Notifications {
+		return 0
+	}
+
+	n := Notifier()
+	if n == nil {
+		return 0
+	}
+
+	id, err := n.Send(nt)
+	if err != nil {
+		slog.Error("Failed to send notification", "error", err)
+	}
+
+	return id
+}
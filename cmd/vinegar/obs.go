@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/events"
+	"github.com/vinegarhq/vinegar/internal/obs"
+	"github.com/vinegarhq/vinegar/roblox/api"
+)
+
+const (
+	// OBSEventVendor is the vendor name of the custom events broadcasted
+	// to OBS WebSocket clients, such as OBS scripts and plugins.
+	OBSEventVendor = "vinegar"
+
+	// OBSRedialDelay is the time waited after failing to connect to
+	// OBS, or after a failed request, before connecting to it again.
+	OBSRedialDelay = time.Minute
+
+	// OBSQueueSize is the amount of events kept while OBS is still
+	// handling an earlier event, after which events are dropped.
+	OBSQueueSize = 16
+)
+
+func (b *Binary) obsRequest(typ string, data any) {
+	cfg := &b.GlobalConfig.OBS
+
+	if b.obs == nil {
+		if time.Since(b.obsFailed) < OBSRedialDelay {
+			return
+		}
+
+		c, err := obs.Dial(cfg.Address, cfg.Password)
+		if err != nil {
+			slog.Error("Failed to connect to OBS WebSocket", "address", cfg.Address, "error", err)
+			b.obsFailed = time.Now()
+			return
+		}
+		b.obs = c
+	}
+
+	slog.Info("Making OBS request", "type", typ)
+
+	err := b.obs.Request(typ, data)
+	if err == nil {
+		return
+	}
+	slog.Error("OBS request failed", "error", err)
+
+	// An unsuccessful request leaves the connection usable, unlike
+	// any other error such as the connection timing out.
+	var rerr *obs.RequestError
+	if !errors.As(err, &rerr) {
+		b.obs.Close()
+		b.obs = nil
+		b.obsFailed = time.Now()
+	}
+}
+
+// handleOBSEvent queues the given event to be handled by OBS, to not wait
+// for OBS or the Roblox API while handling events.
+func (b *Binary) handleOBSEvent(e events.Event) {
+	if !b.GlobalConfig.OBS.Enabled {
+		return
+	}
+
+	switch e.Type {
+	case events.Joined, events.Teleported, events.Left:
+	default:
+		return
+	}
+
+	if b.obsQueue == nil {
+		b.obsQueue = make(chan events.Event, OBSQueueSize)
+		b.obsDone = make(chan struct{})
+		go b.runOBS()
+	}
+
+	select {
+	case b.obsQueue <- e:
+	default:
+		slog.Warn("OBS is busy, dropping event", "event", e.Type)
+	}
+}
+
+// runOBS handles the queued events one at a time, and leaves the last
+// joined game once the queue is closed.
+func (b *Binary) runOBS() {
+	defer close(b.obsDone)
+	defer b.recoverPanic()
+
+	var game events.Game
+	for e := range b.obsQueue {
+		switch e.Type {
+		case events.Joined, events.Teleported:
+			game = e.Game
+			b.OBSGameJoined(e.Game)
+		case events.Left:
+			b.OBSGameLeft(e.Game)
+		}
+	}
+
+	b.OBSGameLeft(game)
+	if b.obs != nil {
+		b.obs.Close()
+		b.obs = nil
+	}
+}
+
+// OBSGameJoined broadcasts the joined game's details to OBS, and
+// switches the scene and starts recording as configured.
+func (b *Binary) OBSGameJoined(g events.Game) {
+	cfg := &b.GlobalConfig.OBS
+	if !cfg.Enabled {
+		return
+	}
+
+	event := map[string]string{
+		"vendor":     OBSEventVendor,
+		"event":      "GameJoined",
+		"placeId":    g.PlaceID,
+		"universeId": g.UniverseID,
+		"jobId":      g.JobID,
+	}
+
+	if g.UniverseID != "" {
+		if gd, err := api.GetGameDetails(g.UniverseID); err == nil {
+			event["name"] = gd.Name
+			event["creator"] = gd.Creator.Name
+		}
+	}
+
+	b.obsRequest("BroadcastCustomEvent", map[string]any{"eventData": event})
+
+	if cfg.Scene != "" {
+		b.obsRequest("SetCurrentProgramScene", map[string]string{"sceneName": cfg.Scene})
+	}
+
+	if cfg.Record && !b.obsRecording {
+		b.obsRequest("StartRecord", nil)
+		b.obsRecording = true
+	}
+}
+
+// OBSGameLeft broadcasts leaving the game to OBS, and switches the
+// scene and stops recording as configured.
+func (b *Binary) OBSGameLeft(g events.Game) {
+	cfg := &b.GlobalConfig.OBS
+	if !cfg.Enabled || (b.obs == nil && !b.obsRecording) {
+		return
+	}
+
+	b.obsRequest("BroadcastCustomEvent", map[string]any{"eventData": map[string]string{
+		"vendor":  OBSEventVendor,
+		"event":   "GameLeft",
+		"placeId": g.PlaceID,
+	}})
+
+	if cfg.LeaveScene != "" {
+		b.obsRequest("SetCurrentProgramScene", map[string]string{"sceneName": cfg.LeaveScene})
+	}
+
+	if b.obsRecording {
+		b.obsRequest("StopRecord", nil)
+		b.obsRecording = false
+	}
+}
+
+// closeOBS waits for the queued events to be handled by OBS, and
+// disconnects from it.
+func (b *Binary) closeOBS() {
+	if b.obsQueue == nil {
+		return
+	}
+
+	close(b.obsQueue)
+	<-b.obsDone
+	b.obsQueue = nil
+}
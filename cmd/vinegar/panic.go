@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+
+	"golang.org/x/term"
+)
+
+// recoverPanic recovers from a panic of the calling goroutine, logging
+// its stack trace and showing a crash dialog pointing to the log file,
+// and exits. It must be deferred by the Binary's goroutines, as a
+// panic can only be recovered within its own goroutine.
+func (b *Binary) recoverPanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	slog.Error("Vinegar panicked!", "panic", r, "stack", string(debug.Stack()))
+
+	if b.Splash != nil {
+		if b.dialogs() && !term.IsTerminal(int(os.Stderr.Fd())) {
+			b.Splash.SetLogPath(b.logPath)
+			b.Splash.SetMessage("Oops!")
+			b.Splash.Dialog(fmt.Sprintf(DialogPanic, b.logPath), false) // blocks
+		}
+
+		b.Splash.Close()
+	}
+
+	os.Exit(2)
+}
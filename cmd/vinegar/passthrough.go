@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/vinegarhq/vinegar/roblox"
+)
+
+// SetupPassthrough links the host directories passed through to Studio
+// within its wineprefix: its local plugins directory, the Wine drives, and
+// the links within the wineprefix's user directory. Links which are no
+// longer passed through are removed.
+//
+// Directories already within the wineprefix in place of a link are kept
+// alongside it, with the ".bak" suffix.
+func (b *Binary) SetupPassthrough() error {
+	pt := &b.Config.Passthrough
+	if b.Type != roblox.Studio || (!pt.Enabled() && len(b.PrefixState.Passthrough) == 0) {
+		return nil
+	}
+
+	ad, err := b.Prefix.AppDataDir()
+	if err != nil {
+		return err
+	}
+	home := filepath.Dir(ad)
+
+	links := make(map[string]string)
+	if pt.Plugins != "" {
+		links[filepath.Join(ad, "Local", "Roblox", "Plugins")] = pt.Plugins
+	}
+	for letter, dir := range pt.Drives {
+		links[filepath.Join(b.Prefix.Dir(), "dosdevices", letter+":")] = dir
+	}
+	for rel, dir := range pt.Links {
+		links[filepath.Join(home, rel)] = dir
+	}
+
+	var linked []string
+	for name, dir := range links {
+		rel, err := filepath.Rel(b.Prefix.Dir(), name)
+		if err != nil {
+			return err
+		}
+
+		if err := passthrough(dir, name); err != nil {
+			return fmt.Errorf("link %s: %w", dir, err)
+		}
+		linked = append(linked, rel)
+	}
+
+	for _, rel := range b.PrefixState.Passthrough {
+		if slices.Contains(linked, rel) {
+			continue
+		}
+
+		name := filepath.Join(b.Prefix.Dir(), rel)
+		if fi, err := os.Lstat(name); err != nil || fi.Mode()&os.ModeSymlink == 0 {
+			continue
+		}
+
+		slog.Info("Removing passed through directory", "path", name)
+		if err := os.Remove(name); err != nil {
+			return err
+		}
+	}
+
+	slices.Sort(linked)
+	b.PrefixState.Passthrough = linked
+	return nil
+}
+
+// passthrough links the named host directory, created if missing, to the
+// named path within the wineprefix.
+func passthrough(dir, name string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	fi, err := os.Lstat(name)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	case fi.Mode()&os.ModeSymlink != 0:
+		if target, _ := os.Readlink(name); target == dir {
+			return nil
+		}
+		if err := os.Remove(name); err != nil {
+			return err
+		}
+	default:
+		slog.Warn("Keeping directory replaced by passed through directory", "path", name, "backup", name+".bak")
+		if err := os.Rename(name, name+".bak"); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("Passing through directory", "dir", dir, "path", name)
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	return os.Symlink(dir, name)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"syscall"
+	"time"
+)
+
+// PlaytimeInterval is the maximum interval in which the remaining
+// playtime is checked.
+const PlaytimeInterval = time.Minute
+
+// checkPlaytime returns the reason Roblox may not be played now, if
+// its playtime is restricted.
+func (b *Binary) checkPlaytime() error {
+	if !b.Config.Playtime.Enabled() {
+		return nil
+	}
+
+	now := time.Now()
+	_, err := b.Config.Playtime.Remaining(now, b.State.Played(now))
+	return err
+}
+
+// recordPlaytime adds the time Roblox was played since start to the
+// Binary's playtime.
+func (b *Binary) recordPlaytime(start time.Time) {
+	b.State.AddPlaytime(start, time.Now())
+
+	if err := b.GlobalState.Save(); err != nil {
+		slog.Error("Could not save playtime", "error", err)
+	}
+}
+
+// WatchPlaytime checks the remaining playtime of Roblox, launched at start
+// with played being the time played on its day before, until done is closed.
+// Roblox is warned of being stopped the configured warning beforehand, and
+// is stopped once its playtime is over.
+func (b *Binary) WatchPlaytime(start time.Time, played time.Duration, done <-chan struct{}) {
+	defer b.recoverPanic()
+
+	pt := &b.Config.Playtime
+	day := start.Format(time.DateOnly)
+	warned := false
+
+	for {
+		now := time.Now()
+
+		// The time played before the launch only counts on its day.
+		p := played + now.Sub(start)
+		if now.Format(time.DateOnly) != day {
+			y, m, d := now.Date()
+			p = now.Sub(time.Date(y, m, d, 0, 0, 0, 0, now.Location()))
+		}
+
+		left, err := pt.Remaining(now, p)
+		if err != nil {
+			slog.Warn("Playtime is over, stopping Roblox", "reason", err)
+			b.Notify("Playtime is over", fmt.Sprintf("Roblox was stopped, as the %s.", err))
+
+			// Not unexpected, for Roblox to not be relaunched.
+			b.killed.Store(true)
+			syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+			return
+		}
+
+		wait := left - pt.Warning
+		if wait <= 0 {
+			if !warned {
+				warned = true
+				slog.Warn("Playtime is almost over", "left", left)
+				b.Notify("Playtime is almost over",
+					fmt.Sprintf("Roblox will be stopped in %s.", left.Round(time.Second)))
+			}
+			wait = left
+		}
+
+		select {
+		case <-done:
+			return
+		case <-time.After(min(wait, PlaytimeInterval)):
+		}
+	}
+}
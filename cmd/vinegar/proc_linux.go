@@ -3,8 +3,10 @@
 package main
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -24,3 +26,162 @@ func CommFound(query string) bool {
 
 	return false
 }
+
+// ProcessGroup returns the IDs of the processes within the named
+// process group.
+func ProcessGroup(pgid int) []int {
+	stats, _ := filepath.Glob("/proc/[0-9]*/stat")
+
+	var pids []int
+	for _, stat := range stats {
+		s, err := os.ReadFile(stat)
+		if err != nil {
+			continue
+		}
+
+		// The process name may contain spaces and parentheses, the
+		// fields following it are state, ppid and pgrp.
+		i := bytes.LastIndexByte(s, ')')
+		if i < 0 {
+			continue
+		}
+
+		f := strings.Fields(string(s[i+1:]))
+		if len(f) < 3 || f[2] != strconv.Itoa(pgid) {
+			continue
+		}
+
+		if pid, err := strconv.Atoi(filepath.Base(filepath.Dir(stat))); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+
+	return pids
+}
+
+// FindProcess returns the ID of the process within the named process group
+// which is running the named Windows executable with Wine, which sets the
+// process's first argument to the executable's Windows path.
+func FindProcess(pgid int, exe string) (int, bool) {
+	for _, pid := range ProcessGroup(pgid) {
+		if runsExecutable(pid, exe) {
+			return pid, true
+		}
+	}
+
+	return 0, false
+}
+
+// runsExecutable determines if the named process is running the named
+// Windows executable with Wine.
+func runsExecutable(pid int, exe string) bool {
+	c, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return false
+	}
+
+	arg0, _, _ := bytes.Cut(c, []byte{0})
+	name := strings.ReplaceAll(string(arg0), `\`, "/")
+	return strings.EqualFold(filepath.Base(name), exe)
+}
+
+// PrefixProcesses returns the IDs of the processes running within the
+// named wineprefix, including its wineserver, which are found by the
+// WINEPREFIX variable of their environment.
+func PrefixProcesses(dir string) []int {
+	environs, _ := filepath.Glob("/proc/[0-9]*/environ")
+	self := os.Getpid()
+
+	var pids []int
+	for _, environ := range environs {
+		pid, err := strconv.Atoi(filepath.Base(filepath.Dir(environ)))
+		if err != nil || pid == self {
+			continue
+		}
+
+		// Only the processes of the current user are readable.
+		e, err := os.ReadFile(environ)
+		if err != nil {
+			continue
+		}
+
+		for _, kv := range bytes.Split(e, []byte{0}) {
+			// Proton's wineprefix is within the given directory.
+			v, ok := bytes.CutPrefix(kv, []byte("WINEPREFIX="))
+			if ok && (string(v) == dir || strings.HasPrefix(string(v), dir+"/")) {
+				pids = append(pids, pid)
+				break
+			}
+		}
+	}
+
+	return pids
+}
+
+// parentProcess returns the ID of the parent of the named process, or 0
+// if it has none or it cannot be read.
+func parentProcess(pid int) int {
+	s, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0
+	}
+
+	i := bytes.LastIndexByte(s, ')')
+	if i < 0 {
+		return 0
+	}
+
+	f := strings.Fields(string(s[i+1:]))
+	if len(f) < 2 {
+		return 0
+	}
+
+	ppid, _ := strconv.Atoi(f[1])
+	return ppid
+}
+
+// OrphanedProcesses returns the IDs of the given processes if none of them
+// were started by a running Vinegar, as they are then left behind by
+// sessions which did not exit cleanly.
+func OrphanedProcesses(pids []int) []int {
+	self, err := os.Executable()
+	if err != nil {
+		return nil
+	}
+
+	for _, pid := range pids {
+		for p := parentProcess(pid); p > 1; p = parentProcess(p) {
+			if runsVinegar(p, self) {
+				return nil
+			}
+		}
+	}
+
+	return pids
+}
+
+// runsVinegar determines if the process with the given ID is Vinegar
+// ran from the executable at self, including if the executable was
+// since replaced, such as by Vinegar being updated while running.
+func runsVinegar(pid int, self string) bool {
+	exe, err := os.Readlink(filepath.Join("/proc", strconv.Itoa(pid), "exe"))
+	if err != nil {
+		return false
+	}
+
+	return strings.TrimSuffix(exe, " (deleted)") == self
+}
+
+// Threads returns the IDs of the threads of the process with the given ID.
+func Threads(pid int) []int {
+	tasks, _ := filepath.Glob(filepath.Join("/proc", strconv.Itoa(pid), "task", "[0-9]*"))
+
+	tids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		if tid, err := strconv.Atoi(filepath.Base(task)); err == nil {
+			tids = append(tids, tid)
+		}
+	}
+
+	return tids
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/vinegarhq/vinegar/wine"
+)
+
+// SetRegistry sets the named registry value in the Binary's wineprefix,
+// unless it was already set to the same data by Vinegar.
+func (b *Binary) SetRegistry(key, value string, rtype wine.RegistryType, data string) error {
+	name := key + `\` + value
+
+	if d, ok := b.PrefixState.Registry[name]; ok && d == data {
+		return nil
+	}
+
+	slog.Info("Setting registry value", "key", key, "value", value, "data", data)
+
+	if err := b.Prefix.RegistryAdd(key, value, rtype, data); err != nil {
+		return err
+	}
+
+	if b.PrefixState.Registry == nil {
+		b.PrefixState.Registry = make(map[string]string)
+	}
+	b.PrefixState.Registry[name] = data
+
+	return nil
+}
+
+// SetupInputMethod sets the input style Wine uses with the input method,
+// which affects how text being composed (such as with CJK input) is shown.
+func (b *Binary) SetupInputMethod() error {
+	if b.GlobalConfig.InputMethodName() == "" || b.GlobalConfig.InputStyle == "" {
+		return nil
+	}
+
+	return b.SetRegistry(`HKEY_CURRENT_USER\Software\Wine\X11 Driver`, "InputStyle",
+		wine.REG_SZ, b.GlobalConfig.InputStyle)
+}
+
+// SetupKeyboardLayout sets the keyboard layout loaded by Roblox, which
+// Roblox uses to determine keybinds for non-US keyboard layouts.
+func (b *Binary) SetupKeyboardLayout() error {
+	klid, err := b.GlobalConfig.KeyboardLayoutID()
+	if err != nil || klid == "" {
+		return err
+	}
+
+	return b.SetRegistry(`HKEY_CURRENT_USER\Keyboard Layout\Preload`, "1", wine.REG_SZ, klid)
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/nxadm/tail"
+	"github.com/vinegarhq/vinegar/internal/events"
+	"github.com/vinegarhq/vinegar/wine"
+)
+
+// RobloxLogDir returns the Roblox log directory within the given
+// wineprefix, which is made if it does not exist to be able to watch it.
+func RobloxLogDir(pfx *wine.Prefix) (string, error) {
+	ad, err := pfx.AppDataDir()
+	if err != nil {
+		return "", fmt.Errorf("get appdata: %w", err)
+	}
+
+	dir := filepath.Join(ad, "Local", "Roblox", "logs")
+
+	// This is required due to fsnotify requiring the directory
+	// to watch to exist before adding it.
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create roblox log dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+// RobloxLogFile waits for Roblox to make a new log file, sent by created
+// from watchLogs, for [LogTimeout] and returns its path. If ctx is done
+// before the log file is made, ctx's error is returned.
+func RobloxLogFile(ctx context.Context, created <-chan string) (string, error) {
+	t := time.NewTimer(LogTimeout)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-t.C:
+		return "", fmt.Errorf("%w after %s", ErrNoRobloxLog, LogTimeout)
+	case name := <-created:
+		return name, nil
+	}
+}
+
+// unwatchableFS is the set of the filesystem types on which inotify
+// is known to miss changes, such as those made by another host or
+// by a FUSE server.
+var unwatchableFS = map[int64]string{
+	0x6969:     "nfs",
+	0xff534d42: "cifs",
+	0x65735546: "fuse",
+	0x794c7630: "overlayfs",
+}
+
+// watchable determines if changes within dir can be relied upon to be
+// reported by inotify.
+func watchable(dir string) bool {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return true
+	}
+
+	_, ok := unwatchableFS[int64(st.Type)]
+	return !ok
+}
+
+// logFiles returns the Roblox log files within dir, ordered by their
+// modification time.
+func logFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type file struct {
+		name string
+		mod  time.Time
+	}
+	var files []file
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".log") {
+			continue
+		}
+
+		i, err := e.Info()
+		if err != nil {
+			continue
+		}
+
+		files = append(files, file{filepath.Join(dir, e.Name()), i.ModTime()})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].mod.Before(files[j].mod)
+	})
+
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = f.name
+	}
+
+	return names, nil
+}
+
+// watchLogs sends the path of each Roblox log file made within dir, until
+// done is closed. The directory is watched with fsnotify, and polled every
+// [LogPollInterval] if inotify cannot be used or is not reliable for dir.
+func watchLogs(dir string, done <-chan struct{}) <-chan string {
+	created := make(chan string)
+	seen := make(map[string]bool)
+
+	existing, err := logFiles(dir)
+	if err != nil {
+		slog.Error("Could not list Roblox log files", "error", err)
+	}
+	for _, name := range existing {
+		seen[name] = true
+	}
+
+	send := func(name string) bool {
+		if seen[name] || !strings.HasSuffix(name, ".log") {
+			return true
+		}
+		seen[name] = true
+
+		select {
+		case created <- name:
+			return true
+		case <-done:
+			return false
+		}
+	}
+
+	var w *fsnotify.Watcher
+	if !watchable(dir) {
+		slog.Warn("Polling Roblox log directory, as inotify is unreliable on its filesystem", "dir", dir)
+	} else {
+		w, err = fsnotify.NewWatcher()
+		if err == nil {
+			err = w.Add(dir)
+		}
+		if err != nil {
+			slog.Warn("Polling Roblox log directory, as it could not be watched", "error", err)
+			if w != nil {
+				w.Close()
+			}
+			w = nil
+		}
+	}
+
+	if w != nil {
+		go func() {
+			defer w.Close()
+
+			for {
+				select {
+				case <-done:
+					return
+				case e := <-w.Events:
+					if e.Has(fsnotify.Create) && !send(e.Name) {
+						return
+					}
+				case err := <-w.Errors:
+					slog.Error("Recieved fsnotify watcher error", "error", err)
+				}
+			}
+		}()
+
+		return created
+	}
+
+	go func() {
+		t := time.NewTicker(LogPollInterval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+			}
+
+			names, err := logFiles(dir)
+			if err != nil {
+				slog.Error("Could not list Roblox log files", "error", err)
+				continue
+			}
+
+			for _, name := range names {
+				if !send(name) {
+					return
+				}
+			}
+		}
+	}()
+
+	return created
+}
+
+type logLine struct {
+	file string
+	text string
+}
+
+// Tail tails the named Roblox log file, and each of the log files made
+// after it sent by created from watchLogs, until done is closed.
+//
+// Roblox makes a new log file for each of its processes, such as when
+// relaunching itself to teleport to another universe, and for its crash
+// handler. Each log file is parsed separately, as each process logs
+// which game it is in by itself. Once a newer log file was made, a log
+// file is no longer tailed after not being written to for [LogIdleTimeout].
+func (b *Binary) Tail(name string, created <-chan string, done <-chan struct{}) {
+	defer b.closeOBS()
+
+	poll := !watchable(filepath.Dir(name))
+
+	type file struct {
+		tail *tail.Tail
+		last time.Time
+	}
+	files := make(map[string]*file)
+	newest := name
+	defer func() {
+		for _, f := range files {
+			f.tail.Stop()
+		}
+	}()
+
+	lines := make(chan logLine)
+	follow := func(name string) {
+		t, err := tail.TailFile(name, tail.Config{Follow: true, Poll: poll})
+		if err != nil {
+			slog.Error("Could not tail Roblox log file", "path", name, "error", err)
+			return
+		}
+
+		slog.Info("Tailing Roblox log file", "path", name)
+		b.robloxLog.Store(name)
+		files[name] = &file{t, time.Now()}
+		newest = name
+
+		go func() {
+			for l := range t.Lines {
+				select {
+				case lines <- logLine{name, l.Text}:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	follow(name)
+
+	idle := time.NewTicker(LogIdleTimeout / 2)
+	defer idle.Stop()
+
+	parsers := make(map[string]*events.Parser)
+	for {
+		select {
+		case <-done:
+			return
+		case name := <-created:
+			follow(name)
+		case now := <-idle.C:
+			for name, f := range files {
+				if name == newest || now.Sub(f.last) < LogIdleTimeout {
+					continue
+				}
+
+				slog.Info("Stopped tailing idle Roblox log file", "path", name)
+				f.tail.Stop()
+				delete(files, name)
+				delete(parsers, name)
+			}
+		case l := <-lines:
+			// Lines still sent after the log file was no longer tailed.
+			f, ok := files[l.file]
+			if !ok {
+				continue
+			}
+			f.last = time.Now()
+
+			b.forwardLog(l.text)
+
+			if crashLogged(l.text) {
+				b.crashLog.Store(true)
+			}
+
+			p, ok := parsers[l.file]
+			if !ok {
+				p = new(events.Parser)
+				parsers[l.file] = p
+			}
+
+			if e, ok := p.Parse(l.text); ok {
+				b.Events.Publish(e)
+			}
+		}
+	}
+}
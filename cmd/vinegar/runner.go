@@ -0,0 +1,247 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/netutil"
+	"github.com/vinegarhq/vinegar/internal/state"
+	"github.com/vinegarhq/vinegar/roblox"
+	"github.com/vinegarhq/vinegar/wine"
+	"github.com/vinegarhq/vinegar/wine/build"
+)
+
+var (
+	ErrUnknownBuild       = errors.New("unknown build, give its tarball url instead")
+	ErrRunnerNotInstalled = errors.New("runner is not installed")
+	ErrBadRunnerName      = errors.New("invalid runner name")
+	ErrNotRunnerBuild     = errors.New("build is not a wine or proton installation")
+)
+
+// RunnerDir returns the directory of the named installed runner.
+func RunnerDir(name string) string {
+	return filepath.Join(dirs.Runners, name)
+}
+
+// BinaryRunner returns the Runner of the Binary's configuration, which uses
+// the Binary's installed runner in use if it has no wine root configured.
+func BinaryRunner(bcfg *config.Binary, bs *state.Binary) (wine.Runner, error) {
+	root := bcfg.WineRoot
+	if root == "" && bs.Runner != "" {
+		root = RunnerDir(bs.Runner)
+	}
+
+	return wine.Lookup(root, bcfg.Backend())
+}
+
+// LoadBinaryRunner returns the named Binary's [BinaryRunner], with
+// its state loaded.
+func LoadBinaryRunner(bt roblox.BinaryType, bcfg *config.Binary) (wine.Runner, error) {
+	s, err := state.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load state: %w", err)
+	}
+
+	bs := &s.Player
+	if bt == roblox.Studio {
+		bs = &s.Studio
+	}
+
+	return BinaryRunner(bcfg, bs)
+}
+
+// Runner handles the runner command, which lists the runner backends and
+// the installed and known runners, installs runners, and sets the runner
+// used by a Binary.
+func Runner(cfg *config.Config, args []string) error {
+	if len(args) < 1 {
+		commandUsage("runner")
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ExitOnError)
+	studio := fs.Bool("studio", false, "use the runner for Roblox Studio")
+	fs.Usage = func() { commandUsage("runner") }
+	fs.Parse(args[1:])
+
+	switch args[0] {
+	case "list":
+		return listRunners()
+	case "install":
+		if fs.NArg() != 1 {
+			commandUsage("runner")
+		}
+		return installRunner(fs.Arg(0))
+	case "use":
+		if fs.NArg() != 1 {
+			commandUsage("runner")
+		}
+
+		bt := roblox.Player
+		if *studio {
+			bt = roblox.Studio
+		}
+		return useRunner(cfg, bt, fs.Arg(0))
+	default:
+		commandUsage("runner")
+	}
+
+	return nil
+}
+
+func listRunners() error {
+	s, err := state.Load()
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	entries, err := os.ReadDir(dirs.Runners)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	installed := make(map[string]bool)
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			installed[e.Name()] = true
+		}
+	}
+
+	names := make([]string, 0, len(installed))
+	for name := range installed {
+		names = append(names, name)
+	}
+	for _, b := range build.Builds {
+		if !installed[b.Name] {
+			names = append(names, b.Name)
+		}
+	}
+	sort.Strings(names)
+
+	fmt.Println("Backends:", strings.Join(wine.Backends(), ", "))
+	fmt.Println()
+
+	for _, name := range names {
+		if !installed[name] {
+			fmt.Printf("-  %s (not installed)\n", name)
+			continue
+		}
+
+		notes := []string{wine.Detect(RunnerDir(name))}
+		for _, b := range []struct {
+			bt roblox.BinaryType
+			bs *state.Binary
+		}{
+			{roblox.Player, &s.Player},
+			{roblox.Studio, &s.Studio},
+		} {
+			if b.bs.Runner == name {
+				notes = append(notes, "used by "+b.bt.String())
+			}
+		}
+
+		fmt.Printf("*  %s (%s)\n", name, strings.Join(notes, ", "))
+	}
+
+	return nil
+}
+
+// installRunner installs the named known build, or the build of the
+// given tarball URL, as a runner.
+func installRunner(name string) error {
+	b, ok := build.Find(name)
+	if !ok {
+		if !strings.Contains(name, "://") {
+			return fmt.Errorf("%w: %s", ErrUnknownBuild, name)
+		}
+		b = build.FromURL(name)
+	}
+
+	if b.Name == "" || b.Name != filepath.Base(b.Name) || !filepath.IsLocal(b.Name) {
+		return fmt.Errorf("%w: %q", ErrBadRunnerName, b.Name)
+	}
+
+	if err := dirs.Mkdirs(dirs.Downloads, dirs.Runners); err != nil {
+		return err
+	}
+
+	tarball := filepath.Join(dirs.Downloads, path.Base(b.URL))
+	slog.Info("Downloading runner", "name", b.Name, "url", b.URL)
+
+	if err := netutil.Download(b.URL, tarball); err != nil {
+		return fmt.Errorf("download %s: %w", b.Name, err)
+	}
+	defer os.Remove(tarball)
+
+	tmp, err := os.MkdirTemp(dirs.Runners, "."+b.Name+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := build.Extract(tarball, tmp); err != nil {
+		return fmt.Errorf("extract %s: %w", b.Name, err)
+	}
+
+	if _, err := wine.Lookup(tmp, ""); err != nil {
+		return fmt.Errorf("%w: %w", ErrNotRunnerBuild, err)
+	}
+
+	if err := moveDir(tmp, RunnerDir(b.Name)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Installed runner %s, use it with: vinegar runner use %[1]s\n", b.Name)
+	return nil
+}
+
+// useRunner sets the named installed runner to be used by the Binary,
+// with 'system' using the system's Wine installation.
+func useRunner(cfg *config.Config, bt roblox.BinaryType, name string) error {
+	bcfg := &cfg.Player
+	if bt == roblox.Studio {
+		bcfg = &cfg.Studio
+	}
+
+	s, err := state.Load()
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	bs := &s.Player
+	if bt == roblox.Studio {
+		bs = &s.Studio
+	}
+
+	bs.Runner = ""
+	if name != "system" {
+		if _, err := os.Stat(RunnerDir(name)); err != nil || name != filepath.Base(name) {
+			return fmt.Errorf("%w: %s", ErrRunnerNotInstalled, name)
+		}
+
+		bs.Runner = name
+	}
+
+	if _, err := BinaryRunner(bcfg, bs); err != nil {
+		return fmt.Errorf("runner %s: %w", name, err)
+	}
+
+	if bcfg.WineRoot != "" {
+		slog.Warn("The configured wineroot is used instead of the runner", "wineroot", bcfg.WineRoot)
+	}
+
+	if err := s.Save(); err != nil {
+		return fmt.Errorf("save state: %w", err)
+	}
+
+	fmt.Printf("%s uses the %s runner\n", bt, name)
+	return nil
+}
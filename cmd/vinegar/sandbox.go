@@ -0,0 +1,47 @@
+package main
+
+import (
+	"log/slog"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/sandbox"
+	"github.com/vinegarhq/vinegar/sysinfo"
+	"github.com/vinegarhq/vinegar/wine"
+)
+
+// sandbox wraps the given command to run within a bubblewrap sandbox, which
+// only has access to the Binary's wineprefix, the Roblox versions and the
+// shader caches, along with the paths in sandbox_binds and the directories
+// passed through to Studio.
+//
+// The sandbox starts its own wineserver, so the wineserver of the setup
+// is stopped beforehand, to not have two of them run the wineprefix.
+func (b *Binary) sandbox(cmd *wine.Cmd) error {
+	if !b.sandboxed() {
+		slog.Warn("Sandbox is unavailable within Flatpak, which is already sandboxed")
+		return nil
+	}
+
+	if err := b.Prefix.ServerKill(); err != nil {
+		slog.Warn("Failed to stop wineserver before sandboxing", "error", err)
+	}
+
+	o := sandbox.Options{
+		Network: b.Config.SandboxNet,
+		Home:    b.Config.SandboxHome,
+		Binds:   []string{BinaryPrefixDir(b.Type, b.Account), dirs.Versions, dirs.Shaders},
+	}
+	o.Binds = append(o.Binds, b.Config.SandboxBinds...)
+	o.Binds = append(o.Binds, b.Config.Passthrough.Dirs()...)
+
+	o.ROBinds = append(o.ROBinds, b.Prefix.Runner.Paths()...)
+
+	slog.Info("Sandboxing Roblox", "network", o.Network, "home", o.Home, "binds", o.Binds)
+
+	return sandbox.Wrap(cmd.Cmd, o)
+}
+
+// sandboxed determines if Roblox is ran within the sandbox.
+func (b *Binary) sandboxed() bool {
+	return b.Config.Sandbox && !sysinfo.InFlatpak
+}
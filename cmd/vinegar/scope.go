@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/wine"
+)
+
+// systemdScope wraps the given command to run within a transient systemd
+// user scope, named after the Binary and the launch, for each launch's
+// processes to be accounted and stopped together.
+func (b *Binary) systemdScope(cmd *wine.Cmd) error {
+	b.scopeUnit = fmt.Sprintf("vinegar-%s-%d-%d", strings.ToLower(b.Alias), os.Getpid(), b.launches)
+
+	args := b.Config.Scope.Args(b.scopeUnit)
+	p, err := exec.LookPath(args[0])
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Using systemd scope", "unit", b.scopeUnit, "args", args)
+	cmd.Args = append(args, cmd.Args...)
+	cmd.Path = p
+
+	return nil
+}
+
+// stopScope stops the systemd scope of the last Roblox command if it is
+// still active, which kills the processes left behind within it.
+func (b *Binary) stopScope() {
+	if b.scopeUnit == "" {
+		return
+	}
+
+	unit := b.scopeUnit + ".scope"
+	b.scopeUnit = ""
+
+	if exec.Command("systemctl", "--user", "is-active", "--quiet", unit).Run() != nil {
+		return
+	}
+
+	slog.Info("Stopping systemd scope", "unit", unit)
+
+	if err := exec.Command("systemctl", "--user", "stop", unit).Run(); err != nil {
+		slog.Error("Failed to stop systemd scope", "unit", unit, "error", err)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/events"
+	"github.com/vinegarhq/vinegar/internal/scripts"
+)
+
+// runLaunchScripts loads the scripts within the scripts directory, and
+// calls their launch hooks, which may modify the Binary's environment and
+// FFlags for the launch.
+func (b *Binary) runLaunchScripts() error {
+	ss, err := scripts.Load(dirs.Scripts)
+	if err != nil {
+		return err
+	}
+	if len(ss) == 0 {
+		return nil
+	}
+
+	slog.Info("Running launch scripts", "dir", dirs.Scripts, "count", len(ss))
+
+	l := scripts.Launch{
+		Binary:  b.Alias,
+		Account: b.Account,
+		URI:     b.URI,
+		Env:     b.Config.Env,
+		FFlags:  b.Config.FFlags,
+	}
+	for _, s := range ss {
+		if err := s.Launch(&l); err != nil {
+			return err
+		}
+	}
+
+	// The environment was already set in Main.
+	for name := range b.Config.Env {
+		if _, ok := l.Env[name]; !ok {
+			os.Unsetenv(name)
+		}
+	}
+
+	b.Config.Env = l.Env
+	b.Config.FFlags = l.FFlags
+	b.scripts = ss
+
+	return nil
+}
+
+// handleScriptEvent calls the event hooks of the scripts loaded by
+// runLaunchScripts.
+func (b *Binary) handleScriptEvent(e events.Event) {
+	for _, s := range b.scripts {
+		if err := s.Event(e); err != nil {
+			slog.Error("Script failed", "script", s.Name, "event", e.Type, "error", err)
+		}
+	}
+}
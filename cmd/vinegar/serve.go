@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vinegarhq/vinegar/config"
+)
+
+const (
+	// RojoPort is the port Rojo serves projects on by default.
+	RojoPort = 34872
+
+	// RojoProject is the project file of a Rojo project.
+	RojoProject = "default.project.json"
+
+	// ServeTimeout is the maximum time taken by the serving command
+	// to open its port before Studio is launched.
+	ServeTimeout = 15 * time.Second
+)
+
+var (
+	ErrServeDrive    = errors.New("serve drive must be a drive letter from d to y")
+	ErrServeNetwork  = errors.New("studio cannot reach the served port without sandbox_network")
+	ErrServeExited   = errors.New("serving command exited before opening its port")
+	ErrServeNotReady = errors.New("serving command did not open its port in time")
+)
+
+// ServeOptions are the options of the studio serve command.
+type ServeOptions struct {
+	// Dir is the project directory, mapped as Drive within Studio's
+	// wineprefix.
+	Dir   string
+	Drive string
+
+	// Command serves the project on the localhost Port, and is
+	// `rojo serve` for Rojo projects if empty.
+	Command string
+	Port    int
+}
+
+// Serve handles the studio serve command, which runs Studio with the given
+// arguments alongside the command serving the project directory on a
+// localhost port, such as `rojo serve`, which is stopped once Studio exits.
+// The project directory is mapped as a Wine drive, for project files such as
+// built places to be opened within Studio.
+func (b *Binary) Serve(o ServeOptions, args ...string) int {
+	stop, err := b.serve(&o)
+	if err != nil {
+		slog.Error(fmt.Sprintf("serve: %s", err))
+		return 1
+	}
+	defer stop()
+
+	return b.Main(args...)
+}
+
+// serve maps the project directory within the wineprefix, and starts the
+// serving command if any, returning once its port is reachable by Studio
+// with the function to stop it.
+func (b *Binary) serve(o *ServeOptions) (func(), error) {
+	dir, err := filepath.Abs(o.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	o.Drive = strings.ToLower(strings.TrimSuffix(o.Drive, ":"))
+	if !config.ValidDrive(o.Drive) {
+		return nil, fmt.Errorf("%w: %s", ErrServeDrive, o.Drive)
+	}
+
+	// Studio within the sandbox has its own loopback interface.
+	if b.Config.Sandbox && !b.Config.SandboxNet {
+		return nil, ErrServeNetwork
+	}
+
+	pt := &b.Config.Passthrough
+	if d, ok := pt.Drives[o.Drive]; ok && d != dir {
+		slog.Warn("Serving project over passed through drive", "drive", o.Drive, "dir", d)
+	}
+	if pt.Drives == nil {
+		pt.Drives = make(map[string]string)
+	}
+	pt.Drives[o.Drive] = dir
+
+	slog.Info("Serving project to Studio", "dir", dir, "drive", strings.ToUpper(o.Drive)+`:\`, "port", o.Port)
+
+	command := strings.Fields(o.Command)
+	if len(command) == 0 {
+		if _, err := os.Stat(filepath.Join(dir, RojoProject)); err != nil {
+			if !portOpen(o.Port) {
+				slog.Warn("Nothing is serving the port, and no serving command was given", "port", o.Port)
+			}
+			return func() {}, nil
+		}
+
+		command = []string{"rojo", "serve", "--port", strconv.Itoa(o.Port)}
+	}
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	slog.Info("Starting serving command", "cmd", cmd)
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	stop := func() {
+		slog.Info("Stopping serving command", "cmd", cmd)
+
+		cmd.Process.Signal(os.Interrupt)
+		select {
+		case <-exited:
+		case <-time.After(5 * time.Second):
+			cmd.Process.Kill()
+			<-exited
+		}
+	}
+
+	deadline := time.After(ServeTimeout)
+	for !portOpen(o.Port) {
+		select {
+		case err := <-exited:
+			return nil, fmt.Errorf("%w: %v", ErrServeExited, err)
+		case <-deadline:
+			stop()
+			return nil, fmt.Errorf("%w: %d", ErrServeNotReady, o.Port)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	slog.Info("Served port is reachable", "port", o.Port)
+	return stop, nil
+}
+
+// portOpen determines if the given port is open on the loopback interface,
+// which Wine shares with the host.
+func portOpen(port int) bool {
+	c, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)), time.Second)
+	if err != nil {
+		return false
+	}
+	c.Close()
+
+	return true
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/roblox"
+)
+
+// settingsPath returns the path to the Player's settings file within
+// the Binary's wineprefix.
+func (b *Binary) settingsPath() (string, error) {
+	ad, err := b.Prefix.AppDataDir()
+	if err != nil {
+		return "", fmt.Errorf("get appdata: %w", err)
+	}
+
+	return filepath.Join(ad, "Local", "Roblox", roblox.SettingsFile), nil
+}
+
+// settingsBackupPath returns the path to the backup of the Player's
+// settings file, which is kept for each wineprefix.
+func (b *Binary) settingsBackupPath() string {
+	return filepath.Join(dirs.Settings, filepath.Base(BinaryPrefixDir(b.Type, b.Account))+".xml")
+}
+
+// RestoreSettings restores the Player's settings file from its backup if
+// it is missing from the wineprefix, such as after the wineprefix was
+// deleted, and sets the configured in-game settings.
+func (b *Binary) RestoreSettings() error {
+	if b.Type != roblox.Player {
+		return nil
+	}
+
+	path, err := b.settingsPath()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		backup := b.settingsBackupPath()
+		if _, err := os.Stat(backup); err == nil {
+			slog.Info("Restoring Roblox settings", "path", backup)
+
+			if err := copySettings(backup, path); err != nil {
+				return fmt.Errorf("restore: %w", err)
+			}
+		}
+	}
+
+	return b.syncSettings(path)
+}
+
+// syncSettings sets the configured in-game settings within the named
+// Player settings file, which is made if Roblox hasn't made it yet.
+func (b *Binary) syncSettings(path string) error {
+	type setting struct{ typ, name, value string }
+	var set []setting
+
+	if b.Config.GraphicsQuality != 0 {
+		set = append(set, setting{"token", roblox.QualityLevelSetting, strconv.Itoa(b.Config.GraphicsQuality)})
+	}
+
+	if b.Config.Volume != nil {
+		v := strconv.FormatFloat(float64(*b.Config.Volume)/100, 'f', -1, 64)
+		set = append(set, setting{"float", roblox.VolumeSetting, v})
+	}
+
+	if b.Config.Fullscreen != nil {
+		set = append(set, setting{"bool", roblox.FullscreenSetting, strconv.FormatBool(*b.Config.Fullscreen)})
+	}
+
+	if b.Config.Resolution != "" {
+		w, h, err := b.Config.ScreenSize()
+		if err != nil {
+			return err
+		}
+		set = append(set, setting{"Vector2", roblox.ScreenSizeSetting, fmt.Sprintf("<X>%d</X><Y>%d</Y>", w, h)})
+	}
+
+	if len(set) == 0 {
+		return nil
+	}
+
+	s, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		s = []byte(roblox.DefaultSettings)
+	} else if err != nil {
+		return err
+	}
+
+	for _, st := range set {
+		var ok bool
+		if s, ok = roblox.SetSetting(s, st.typ, st.name, st.value); !ok {
+			return fmt.Errorf("%s: no user game settings", st.name)
+		}
+	}
+
+	slog.Info("Setting Roblox settings", "path", path)
+
+	if err := dirs.Mkdirs(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, s, 0o644)
+}
+
+// BackupSettings backs up the Player's settings file from the wineprefix,
+// to be restored by [Binary.RestoreSettings].
+func (b *Binary) BackupSettings() error {
+	if b.Type != roblox.Player {
+		return nil
+	}
+
+	path, err := b.settingsPath()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return copySettings(path, b.settingsBackupPath())
+}
+
+func copySettings(src, dst string) error {
+	s, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	if err := dirs.Mkdirs(filepath.Dir(dst)); err != nil {
+		return err
+	}
+
+	return os.WriteFile(dst, s, 0o644)
+}
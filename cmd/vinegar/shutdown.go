@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/roblox"
+	"github.com/vinegarhq/vinegar/wine"
+)
+
+// StopTimeout is the time given to Roblox to exit after each step
+// of stopping it.
+const StopTimeout = 4 * time.Second
+
+// ReapTimeout is the time given to the processes left behind in
+// a wineprefix to exit after its wineserver is killed.
+const ReapTimeout = 2 * time.Second
+
+// stop stops the running Roblox command, escalating until it has exited:
+//
+//   - Roblox is asked to close its windows (WM_CLOSE) with taskkill,
+//     which lets it clean up, such as saving its local storage.
+//   - Roblox is forcefully terminated with taskkill.
+//   - Roblox's process group is killed.
+//   - The wineserver is killed, which kills all of the wineprefix's processes.
+//
+// If Roblox had already shut down by itself, it is not asked to close.
+func (b *Binary) stop(cmd *wine.Cmd, exited <-chan struct{}) {
+	exe := b.executable()
+
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{"close", func() error {
+			return b.Prefix.Wine("taskkill", "/im", exe).Run()
+		}},
+		{"terminate", func() error {
+			return b.Prefix.Wine("taskkill", "/f", "/im", exe).Run()
+		}},
+		{"kill process group", func() error {
+			return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		}},
+		{"kill wineserver", b.Prefix.ServerKill},
+	}
+
+	if b.shutdown.Load() {
+		steps = steps[1:]
+	}
+
+	// Wine outside of the sandbox has its own wineserver, which cannot
+	// reach the processes within it.
+	if b.sandboxed() {
+		steps = steps[len(steps)-2 : len(steps)-1]
+	}
+
+	for _, s := range steps {
+		select {
+		case <-exited:
+			return
+		default:
+		}
+
+		slog.Warn("Stopping Roblox", "step", s.name, "pid", cmd.Process.Pid)
+
+		if err := s.fn(); err != nil {
+			slog.Error("Failed to stop Roblox", "step", s.name, "error", err)
+		}
+
+		select {
+		case <-exited:
+			return
+		case <-time.After(StopTimeout):
+		}
+	}
+
+	slog.Error("Roblox did not exit after being stopped!")
+}
+
+// reap kills the wineserver and the processes left behind in the Binary's
+// wineprefix once Roblox has exited, unless another instance of Roblox
+// is still running within it.
+func (b *Binary) reap() {
+	exe := b.executable()
+	for _, pid := range PrefixProcesses(b.Prefix.Dir()) {
+		if runsExecutable(pid, exe) {
+			slog.Info("Roblox is still running within the wineprefix, not killing it", "pid", pid)
+			return
+		}
+	}
+
+	if err := Reap(b.Prefix); err != nil {
+		slog.Error("Failed to kill wineprefix", "error", err)
+	}
+}
+
+// reapOrphaned kills the processes left behind in the Binary's wineprefix
+// by previous sessions which did not exit cleanly, such as a stuck
+// wineserver, which prevent Roblox from launching. The user is asked
+// first if dialogs can be shown.
+func (b *Binary) reapOrphaned() {
+	pids := OrphanedProcesses(PrefixProcesses(b.Prefix.Dir()))
+	if len(pids) == 0 {
+		return
+	}
+
+	slog.Warn("Found processes left behind in the wineprefix", "pids", pids)
+
+	if b.dialogs() && !b.Splash.Dialog(DialogOrphaned, true) {
+		slog.Info("Declined killing the left behind processes")
+		return
+	}
+
+	if err := Reap(b.Prefix); err != nil {
+		slog.Error("Failed to kill wineprefix", "error", err)
+	}
+}
+
+// Reap kills the wineserver of the given wineprefix, and forcefully kills
+// the wineprefix's processes which haven't exited after [ReapTimeout],
+// to not leave any orphaned processes behind.
+func Reap(pfx *wine.Prefix) error {
+	pids := PrefixProcesses(pfx.Dir())
+	if len(pids) == 0 {
+		return nil
+	}
+
+	slog.Info("Killing wineprefix", "dir", pfx.Dir(), "processes", len(pids))
+
+	if err := pfx.ServerKill(); err != nil {
+		slog.Warn("Failed to kill wineserver", "error", err)
+	}
+
+	deadline := time.Now().Add(ReapTimeout)
+	for time.Now().Before(deadline) {
+		if pids = PrefixProcesses(pfx.Dir()); len(pids) == 0 {
+			return nil
+		}
+		time.Sleep(ReapTimeout / 10)
+	}
+
+	var errs []error
+	for _, pid := range pids {
+		slog.Warn("Killing orphaned wineprefix process", "pid", pid)
+		if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+			errs = append(errs, fmt.Errorf("kill %d: %w", pid, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Kill handles the kill command, which kills the wineprefixes of the given
+// Binaries, or of both if none are given, including those of accounts.
+func Kill(cfg *config.Config, names []string) error {
+	if len(names) == 0 {
+		names = []string{"player", "studio"}
+	}
+
+	var errs []error
+	for _, name := range names {
+		var bt roblox.BinaryType
+		var bcfg *config.Binary
+		switch name {
+		case "player":
+			bt, bcfg = roblox.Player, &cfg.Player
+		case "studio":
+			bt, bcfg = roblox.Studio, &cfg.Studio
+		default:
+			commandUsage("kill")
+		}
+
+		dirs, _ := filepath.Glob(BinaryPrefixDir(bt, "*"))
+		if _, err := os.Stat(BinaryPrefixDir(bt, "")); err == nil {
+			dirs = append(dirs, BinaryPrefixDir(bt, ""))
+		}
+
+		r, err := LoadBinaryRunner(bt, bcfg)
+		if err != nil {
+			return fmt.Errorf("%s runner: %w", bt, err)
+		}
+
+		for _, dir := range dirs {
+			pfx, err := wine.New(dir, r)
+			if err != nil {
+				return fmt.Errorf("%s prefix: %w", bt, err)
+			}
+
+			if err := Reap(pfx); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", dir, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
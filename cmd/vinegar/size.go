@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+)
+
+// SizeDirs is the order of the directories measured by Size.
+var SizeDirs = []struct {
+	Name string
+	Dir  *string
+}{
+	{"Versions", &dirs.Versions},
+	{"Downloads", &dirs.Downloads},
+	{"Wineprefixes", &dirs.Prefixes},
+	{"Shader caches", &dirs.Shaders},
+}
+
+// Size prints the disk usage of the installed versions, cached package
+// downloads, wineprefixes and shader caches, and of each of their entries.
+func Size() error {
+	var total int64
+
+	for _, sd := range SizeDirs {
+		es, err := dirEntries(*sd.Dir)
+		if err != nil {
+			return err
+		}
+
+		var size int64
+		for _, e := range es {
+			size += e.size
+		}
+		total += size
+
+		fmt.Printf("* %s: %s\n", sd.Name, formatSize(size))
+
+		// Downloads are listed by their checksum, which are of no use.
+		if *sd.Dir == dirs.Downloads {
+			continue
+		}
+
+		for _, e := range es {
+			fmt.Printf("  %s\t%s\n", formatSize(e.size), e.path)
+		}
+	}
+
+	fmt.Printf("Total: %s\n", formatSize(total))
+	return nil
+}
+
+type dirEntry struct {
+	path string
+	size int64
+}
+
+// dirEntries returns the entries within the named directory along with
+// their size. If the directory does not exist, no entries are returned.
+func dirEntries(dir string) ([]dirEntry, error) {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	es := make([]dirEntry, 0, len(files))
+	for _, f := range files {
+		path := filepath.Join(dir, f.Name())
+
+		size, err := diskSize(path)
+		if err != nil {
+			return nil, err
+		}
+
+		es = append(es, dirEntry{path, size})
+	}
+
+	return es, nil
+}
+
+func diskSize(path string) (int64, error) {
+	var size int64
+
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		i, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		size += i.Size()
+		return nil
+	})
+
+	return size, err
+}
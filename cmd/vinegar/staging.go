@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	boot "github.com/vinegarhq/vinegar/roblox/bootstrapper"
+)
+
+const tmpfsMagic = 0x01021994
+
+// InstallVersion installs the given packages to the Binary's version
+// directory. With staged extraction, the packages are installed to a staging
+// directory, in memory if there is enough of it, and moved into place once
+// complete, for an interrupted installation to never leave an incomplete
+// version directory behind.
+func (b *Binary) InstallVersion(pm *boot.PackageManifest) error {
+	if !b.GlobalConfig.StagedExtraction {
+		return b.InstallPackages(pm, b.Dir)
+	}
+
+	var size int64
+	for _, p := range pm.Packages {
+		size += p.Size
+	}
+
+	parent := dirs.Versions
+	if inMemory(dirs.Runtime, size) {
+		parent = dirs.Runtime
+	}
+
+	if err := dirs.Mkdirs(parent); err != nil {
+		return err
+	}
+
+	stage, err := os.MkdirTemp(parent, "."+pm.Deployment.GUID+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stage)
+
+	slog.Info("Staging version extraction", "dir", stage, "size", size)
+
+	if err := b.InstallPackages(pm, stage); err != nil {
+		return err
+	}
+
+	return moveDir(stage, b.Dir)
+}
+
+// inMemory determines if the named directory is on a tmpfs filesystem with
+// enough space and memory available to hold the given size, with some
+// memory to spare for Roblox itself.
+func inMemory(dir string, size int64) bool {
+	if err := dirs.Mkdirs(dir); err != nil {
+		return false
+	}
+
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil || st.Type != tmpfsMagic {
+		return false
+	}
+
+	avail, err := memAvailable()
+	if err != nil {
+		slog.Warn("Could not determine available memory", "error", err)
+		return false
+	}
+
+	return int64(st.Bavail)*int64(st.Bsize) > size && avail > size*2
+}
+
+// moveDir replaces the dst directory with the src directory. If they are on
+// different filesystems, src is first copied next to dst, to still replace
+// dst atomically.
+func moveDir(src, dst string) error {
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+
+	err := os.Rename(src, dst)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp(filepath.Dir(dst), "."+filepath.Base(dst)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	slog.Info("Copying staged version", "src", src, "dest", tmp)
+
+	if err := copyDir(src, tmp); err != nil {
+		return fmt.Errorf("copy %s: %w", src, err)
+	}
+
+	return os.Rename(tmp, dst)
+}
+
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+
+		s, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		t, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		defer t.Close()
+
+		_, err = io.Copy(t, s)
+		return err
+	})
+}
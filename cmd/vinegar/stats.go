@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/state"
+)
+
+// SetupPhases is the order of the setup phases measured during Setup.
+var SetupPhases = []string{"fetch", "download", "extract", "mods", "dxvk"}
+
+// PrintSetupStats prints the time taken by each setup phase of the most
+// recent setups of both Binaries, comparing the last setup to the average
+// of the recent setups.
+func PrintSetupStats() error {
+	s, err := state.Load()
+	if err != nil {
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	for _, b := range []struct {
+		name string
+		sts  []state.SetupTiming
+	}{
+		{"Player", s.Player.SetupTimings},
+		{"Studio", s.Studio.SetupTimings},
+	} {
+		if len(b.sts) == 0 {
+			fmt.Printf("* %s: no setups recorded\n", b.name)
+			continue
+		}
+
+		updates := 0
+		for _, st := range b.sts {
+			if st.Updated {
+				updates++
+			}
+		}
+
+		fmt.Printf("* %s: %d setups, %d updates\n", b.name, len(b.sts), updates)
+
+		for _, name := range SetupPhases {
+			printPhase(name, b.sts, func(st *state.SetupTiming) (time.Duration, bool) {
+				return st.Phase(name)
+			})
+		}
+
+		printPhase("total", b.sts, func(st *state.SetupTiming) (time.Duration, bool) {
+			return st.Total(), true
+		})
+	}
+
+	return nil
+}
+
+func printPhase(name string, sts []state.SetupTiming, dur func(*state.SetupTiming) (time.Duration, bool)) {
+	var last, sum time.Duration
+	n := 0
+
+	for i := range sts {
+		d, ok := dur(&sts[i])
+		if !ok {
+			continue
+		}
+
+		last = d
+		sum += d
+		n++
+	}
+
+	if n == 0 {
+		return
+	}
+
+	avg := sum / time.Duration(n)
+	fmt.Printf("  * %s: last %s, average %s (%d setups)\n", name,
+		last.Round(time.Millisecond), avg.Round(time.Millisecond), n)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/vinegarhq/vinegar/internal/steam"
+	"github.com/vinegarhq/vinegar/roblox/api"
+	"github.com/vinegarhq/vinegar/roblox/protocol"
+)
+
+var ErrSteamRunning = errors.New("steam is running, close it before adding shortcuts")
+
+// SteamShortcuts returns the Steam shortcut for Player, and for each of the
+// given place IDs, a shortcut launching Player into the place, with its
+// artwork fetched from the Roblox API.
+func SteamShortcuts(placeIDs []string) ([]steam.Shortcut, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("executable: %w", err)
+	}
+
+	scs := []steam.Shortcut{{
+		AppName:       "Roblox Player",
+		Exe:           exe,
+		LaunchOptions: "player run",
+		Tags:          []string{"Roblox"},
+	}}
+
+	for _, id := range placeIDs {
+		if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+			return nil, fmt.Errorf("bad place id: %s", id)
+		}
+
+		uid, err := api.GetUniverseID(id)
+		if err != nil {
+			return nil, fmt.Errorf("place %s: %w", id, err)
+		}
+
+		gd, err := api.GetGameDetails(uid)
+		if err != nil {
+			return nil, fmt.Errorf("place %s: %w", id, err)
+		}
+
+		sc := steam.Shortcut{
+			AppName:       gd.Name,
+			Exe:           exe,
+			LaunchOptions: "player run " + (&protocol.Join{PlaceID: id}).Experience(),
+			Tags:          []string{"Roblox"},
+			Artwork:       make(map[string]string),
+		}
+
+		if tn, err := api.GetGameIcon(uid, "PlaceHolder", "512x512", "Png", false); err == nil {
+			sc.Artwork[steam.ArtworkIcon] = tn.ImageURL
+		} else {
+			slog.Error("Failed to fetch game icon", "place", id, "error", err)
+		}
+
+		if tn, err := api.GetGameThumbnail(uid, "768x432", "Png"); err == nil {
+			sc.Artwork[steam.ArtworkWide] = tn.ImageURL
+			sc.Artwork[steam.ArtworkHero] = tn.ImageURL
+		} else {
+			slog.Error("Failed to fetch game thumbnail", "place", id, "error", err)
+		}
+
+		scs = append(scs, sc)
+	}
+
+	return scs, nil
+}
+
+// AddSteamShortcuts adds the shortcuts from SteamShortcuts to
+// all local Steam users.
+func AddSteamShortcuts(placeIDs []string) error {
+	if steam.Running() {
+		return ErrSteamRunning
+	}
+
+	users, err := steam.Users()
+	if err != nil {
+		return err
+	}
+
+	scs, err := SteamShortcuts(placeIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		for _, sc := range scs {
+			slog.Info("Adding Steam shortcut", "name", sc.AppName, "user", u)
+
+			if err := steam.AddShortcut(u, &sc); err != nil {
+				return fmt.Errorf("%s: %w", sc.AppName, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -2,25 +2,86 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
+	"os"
 	"path"
 	"runtime/debug"
 
 	"github.com/vinegarhq/vinegar/config"
+	"github.com/vinegarhq/vinegar/internal/state"
 	"github.com/vinegarhq/vinegar/roblox"
 	"github.com/vinegarhq/vinegar/sysinfo"
 	"github.com/vinegarhq/vinegar/wine"
 )
 
+// LoadHardware sets the host's hardware information from the state's cache,
+// which is detected again if the kernel or GPU drivers have changed since,
+// or if refresh is true.
+func LoadHardware(refresh bool) error {
+	s, err := state.Load()
+	if err != nil {
+		sysinfo.SetHardware(sysinfo.DetectHardware())
+		return fmt.Errorf("load state: %w", err)
+	}
+
+	if !refresh && s.Hardware != nil && s.Hardware.Key == sysinfo.HardwareKey() {
+		sysinfo.SetHardware(*s.Hardware)
+		return nil
+	}
+
+	h := sysinfo.DetectHardware()
+	sysinfo.SetHardware(h)
+	s.Hardware = &h
+
+	slog.Info("Detected hardware information", "key", h.Key, "cpu", h.CPU.Name, "cards", len(h.Cards))
+
+	return s.Save()
+}
+
+// loadHardware loads the hardware information, refreshing it if asked
+// to by the -refresh-sysinfo flag, for the commands which require it.
+func loadHardware() {
+	if err := LoadHardware(RefreshSysinfo); err != nil {
+		slog.Warn("Could not cache hardware information", "error", err)
+	}
+}
+
 func PrintSysinfo(cfg *config.Config) {
-	playerPfx, err := wine.New(BinaryPrefixDir(roblox.Player), cfg.Player.WineRoot)
+	if err := WriteSysinfo(os.Stdout, cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// WriteSysinfo writes the system's information to w, as included
+// in bug reports.
+func WriteSysinfo(w io.Writer, cfg *config.Config) error {
+	emu, emuErr := cfg.EmulatorPath()
+
+	s, err := state.Load()
 	if err != nil {
-		log.Fatalf("player prefix: %s", err)
+		return fmt.Errorf("load state: %w", err)
 	}
 
-	studioPfx, err := wine.New(BinaryPrefixDir(roblox.Studio), cfg.Studio.WineRoot)
+	playerRunner, err := BinaryRunner(&cfg.Player, &s.Player)
 	if err != nil {
-		log.Fatalf("studio prefix: %s", err)
+		return fmt.Errorf("player runner: %w", err)
+	}
+
+	playerPfx, err := wine.New(BinaryPrefixDir(roblox.Player, ""), wine.Emulate(playerRunner, emu))
+	if err != nil {
+		return fmt.Errorf("player prefix: %w", err)
+	}
+
+	studioRunner, err := BinaryRunner(&cfg.Studio, &s.Studio)
+	if err != nil {
+		return fmt.Errorf("studio runner: %w", err)
+	}
+
+	studioPfx, err := wine.New(BinaryPrefixDir(roblox.Studio, ""), wine.Emulate(studioRunner, emu))
+	if err != nil {
+		return fmt.Errorf("studio prefix: %w", err)
 	}
 
 	var revision string
@@ -37,26 +98,61 @@ func PrintSysinfo(cfg *config.Config) {
   * Supports AVX: %t
   * Supports split lock detection: %t
 * Kernel: %s
-* Wine (Player): %s
-* Wine (Studio): %s
+* Architecture: %s
+* Wine (Player): %s (%s)
+* Wine (Studio): %s (%s)
 `
 
-	fmt.Printf(info,
+	fmt.Fprintf(w, info,
 		Version, revision,
 		sysinfo.Distro,
 		sysinfo.CPU.Name,
 		sysinfo.CPU.AVX, sysinfo.CPU.SplitLockDetect,
 		sysinfo.Kernel,
-		playerPfx.Version(),
-		studioPfx.Version(),
+		sysinfo.Arch,
+		playerPfx.Version(), playerPfx.Runner,
+		studioPfx.Version(), studioPfx.Runner,
 	)
 
+	if config.Emulated() {
+		switch {
+		case emuErr != nil:
+			fmt.Fprintf(w, "* Emulator: [ ] %s\n", emuErr)
+		case emu == "":
+			fmt.Fprintln(w, "* Emulator: binfmt_misc")
+		default:
+			fmt.Fprintf(w, "* Emulator: %s\n", emu)
+		}
+	}
+
+	if sysinfo.Locale != "" {
+		fmt.Fprintf(w, "* Locale: %s\n", sysinfo.Locale)
+	}
+
+	if sysinfo.KeyboardLayout != "" {
+		fmt.Fprintf(w, "* Keyboard layout: %s\n", sysinfo.KeyboardLayout)
+	}
+
+	if sysinfo.InputMethod != "" {
+		fmt.Fprintf(w, "* Input method: %s\n", sysinfo.InputMethod)
+	}
+
 	if sysinfo.InFlatpak {
-		fmt.Println("* Flatpak: [x]")
+		fmt.Fprintln(w, "* Flatpak: [x]")
 	}
 
-	fmt.Println("* Cards:")
+	if sysinfo.SteamDeck {
+		fmt.Fprintln(w, "* Steam Deck: [x]")
+	}
+
+	if sysinfo.GamingMode {
+		fmt.Fprintln(w, "* Gaming Mode: [x]")
+	}
+
+	fmt.Fprintln(w, "* Cards:")
 	for i, c := range sysinfo.Cards {
-		fmt.Printf("  * Card %d: %s %s %s\n", i, c.Driver, path.Base(c.Device), c.Path)
+		fmt.Fprintf(w, "  * Card %d: %s %s %s\n", i, c.Driver, path.Base(c.Device), c.Path)
 	}
+
+	return nil
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+)
+
+const (
+	PrefixInitTimeout = 3 * time.Minute
+	WebViewTimeout    = 5 * time.Minute
+
+	// HeartbeatInterval is the interval in which progress of a step ran
+	// with a timeout is reported.
+	HeartbeatInterval = 15 * time.Second
+)
+
+var ErrTimeout = errors.New("timed out")
+
+// withTimeout runs fn, which runs Wine within the Binary's wineprefix,
+// reporting how long it has been running for in the splash message.
+//
+// If fn has not returned after the given duration, the wineprefix's
+// processes are killed to unblock it, and an error diagnosing the
+// hang is returned. This commonly happens when wineboot is unable
+// to open a display.
+func (b *Binary) withTimeout(msg string, d time.Duration, fn func() error) error {
+	errc := make(chan error, 1)
+	go func() {
+		errc <- fn()
+	}()
+
+	start := time.Now()
+
+	hb := time.NewTicker(HeartbeatInterval)
+	defer hb.Stop()
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	for {
+		select {
+		case err := <-errc:
+			return err
+		case <-hb.C:
+			elapsed := time.Since(start).Round(time.Second)
+			slog.Warn("Still waiting for Wine", "step", msg, "elapsed", elapsed)
+			b.Splash.SetMessage(fmt.Sprintf("%s (%s)", msg, elapsed))
+		case <-t.C:
+			slog.Error("Wine did not finish in time, killing wineprefix", "step", msg, "timeout", d)
+
+			if err := b.Prefix.ServerKill(); err != nil {
+				slog.Error("Failed to kill wineprefix", "error", err)
+			}
+
+			return fmt.Errorf("%w after %s: %s", ErrTimeout, d, hangDiagnostic())
+		}
+	}
+}
+
+func hangDiagnostic() string {
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return "no display is set (DISPLAY or WAYLAND_DISPLAY) for Wine to use"
+	}
+
+	return "Wine may be unable to open the display, or a wineserver is stuck; check the log for Wine errors"
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/trace"
+)
+
+// StartTrace starts writing an execution trace to TracePath if set, in
+// which the launch phases are annotated as regions of the returned
+// context's task. The returned function stops the trace.
+func StartTrace(name string) (context.Context, func(), error) {
+	if TracePath == "" {
+		return context.Background(), func() {}, nil
+	}
+
+	f, err := os.Create(TracePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := trace.Start(f); err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("start: %w", err)
+	}
+
+	slog.Info("Writing execution trace", "path", TracePath)
+
+	ctx, task := trace.NewTask(context.Background(), name)
+	return ctx, func() {
+		task.End()
+		trace.Stop()
+		f.Close()
+	}, nil
+}
+
+// traceContext returns the execution trace task of the launch, which is
+// absent when the Binary is not launched, such as when prefetching.
+func (b *Binary) traceContext() context.Context {
+	if b.trace == nil {
+		return context.Background()
+	}
+
+	return b.trace
+}
+
+// region annotates the named phase of the launch in the execution trace,
+// until the returned function is called on the same goroutine.
+func (b *Binary) region(name string) func() {
+	return trace.StartRegion(b.traceContext(), name).End
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/vinegarhq/vinegar/splash"
+	"golang.org/x/term"
+)
+
+// Keys read by the TUI to abort the launch.
+const (
+	KeyAbort     = 'q'
+	KeyInterrupt = 0x03 // Ctrl-C, as the terminal is in raw mode
+)
+
+// TUI is a terminal user interface which is used in place of the splash
+// window, drawing the Binary's progress and dialogs below its log, as
+// the log is written through it.
+type TUI struct {
+	mu sync.Mutex
+
+	in  *os.File
+	out *os.File
+
+	state  *term.State
+	drawn  int
+	closed bool
+	stop   chan struct{}
+
+	message  string
+	desc     string
+	progress float32
+
+	prompt string
+	answer chan byte
+}
+
+var _ splash.Backend = (*TUI)(nil)
+
+var escapes = regexp.MustCompile("\x1b\\[[0-9;]*[A-Za-z]")
+
+func NewTUI(in, out *os.File) *TUI {
+	return &TUI{
+		in:   in,
+		out:  out,
+		stop: make(chan struct{}),
+	}
+}
+
+// SplashKind returns the kind of splash backend to use with the given
+// configuration, which is always the TUI if requested and Vinegar is
+// ran in a terminal.
+func SplashKind(cfg *splash.Config) string {
+	terminal := term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+	if TUIMode && terminal {
+		return splash.KindTerminal
+	}
+
+	return cfg.ResolveKind(terminal)
+}
+
+// Run reads the keys from the terminal until the TUI is closed, or until the
+// launch is aborted, in which case [splash.ErrClosed] is returned.
+func (t *TUI) Run() error {
+	st, err := term.MakeRaw(int(t.in.Fd()))
+	if err != nil {
+		slog.Warn("Could not read keys from terminal", "error", err)
+		<-t.stop
+		return nil
+	}
+
+	t.mu.Lock()
+	t.state = st
+	t.draw()
+	t.mu.Unlock()
+
+	keys := make(chan byte)
+	go func() {
+		buf := make([]byte, 1)
+		for {
+			if _, err := t.in.Read(buf); err != nil {
+				return
+			}
+			keys <- buf[0]
+		}
+	}()
+
+	for {
+		select {
+		case <-t.stop:
+			return nil
+		case k := <-keys:
+			t.mu.Lock()
+			answer := t.answer
+			t.mu.Unlock()
+
+			if answer != nil {
+				answer <- k
+				continue
+			}
+
+			if k == KeyAbort || k == KeyInterrupt {
+				t.Close()
+				return splash.ErrClosed
+			}
+		}
+	}
+}
+
+// Write writes the given log output above the drawn progress.
+func (t *TUI) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed || t.state == nil {
+		return t.out.Write(p)
+	}
+
+	t.clear()
+	if _, err := t.out.Write(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n"))); err != nil {
+		return 0, err
+	}
+	t.draw()
+
+	return len(p), nil
+}
+
+func (t *TUI) SetMessage(msg string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.message = msg
+	t.draw()
+}
+
+func (t *TUI) SetDesc(desc string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.desc = desc
+	t.draw()
+}
+
+func (t *TUI) SetProgress(progress float32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.progress = progress
+	t.draw()
+}
+
+// SetLogPath does nothing, as the log is already shown.
+func (t *TUI) SetLogPath(string) {}
+
+// Close removes the drawn progress and restores the terminal, after which
+// the log is written as-is.
+func (t *TUI) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return
+	}
+
+	t.clear()
+	t.closed = true
+	close(t.stop)
+
+	if t.state != nil {
+		term.Restore(int(t.in.Fd()), t.state)
+	}
+}
+
+func (t *TUI) IsClosed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.closed
+}
+
+// Dialog shows the given text in place of the keybind hint, and waits for a
+// key to be pressed. If user is true, Dialog returns if 'y' was pressed
+// rather than 'n'.
+func (t *TUI) Dialog(txt string, user bool) bool {
+	t.mu.Lock()
+	if t.closed || t.state == nil {
+		t.mu.Unlock()
+		slog.Info("Dialog", "text", txt)
+		return false
+	}
+
+	hint := "[press any key]"
+	if user {
+		hint = "[y/n]"
+	}
+
+	answer := make(chan byte)
+	t.prompt = strings.TrimSpace(txt) + " " + hint
+	t.answer = answer
+	t.draw()
+	t.mu.Unlock()
+
+	r := false
+	for k := range answer {
+		if !user {
+			break
+		}
+
+		if k == 'y' || k == 'Y' {
+			r = true
+			break
+		}
+		if k == 'n' || k == 'N' || k == KeyInterrupt {
+			break
+		}
+	}
+
+	t.mu.Lock()
+	t.prompt = ""
+	t.answer = nil
+	t.draw()
+	t.mu.Unlock()
+
+	return r
+}
+
+// clear removes the drawn progress, leaving the cursor where it began.
+func (t *TUI) clear() {
+	if t.drawn > 0 {
+		fmt.Fprintf(t.out, "\r\x1b[%dA", t.drawn)
+	}
+	t.out.WriteString("\r\x1b[J")
+	t.drawn = 0
+}
+
+func (t *TUI) draw() {
+	if t.closed || t.state == nil {
+		return
+	}
+
+	width, _, err := term.GetSize(int(t.out.Fd()))
+	if err != nil || width < 20 {
+		width = 80
+	}
+
+	lines := []string{"\x1b[1m" + t.message + "\x1b[0m"}
+	if t.desc != "" {
+		lines[0] += " \x1b[2m" + t.desc + "\x1b[0m"
+	}
+
+	if t.progress > 0 {
+		bar := width - 8
+		n := int(float32(bar) * min(t.progress, 1))
+		lines = append(lines, fmt.Sprintf("[%s%s] %3.0f%%",
+			strings.Repeat("=", n), strings.Repeat(" ", bar-n), t.progress*100))
+	}
+
+	if t.prompt != "" {
+		lines = append(lines, strings.Split(t.prompt, "\n")...)
+	} else {
+		lines = append(lines, "\x1b[2mPress q to abort\x1b[0m")
+	}
+
+	t.clear()
+	for _, l := range lines {
+		t.out.WriteString(l + "\r\n")
+
+		// Lines longer than the terminal are wrapped.
+		n := len([]rune(escapes.ReplaceAllString(l, "")))
+		t.drawn += max(1, (n+width-1)/width)
+	}
+}
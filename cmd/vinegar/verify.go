@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	boot "github.com/vinegarhq/vinegar/roblox/bootstrapper"
+)
+
+var (
+	ErrNotInstalled   = errors.New("no version is installed")
+	ErrNoChecksums    = errors.New("installed version has no stored checksums")
+	ErrDamagedVersion = errors.New("files are missing or corrupted")
+)
+
+// FileChecksumsName is the name of the file within a version directory
+// which holds the checksums of the version's files.
+const FileChecksumsName = ".vinegar-checksums.json"
+
+// FileChecksums maps the paths of a version's files, relative to the
+// version directory, to their checksums held in the version's packages.
+type FileChecksums map[string]boot.FileChecksum
+
+func readFileChecksums(dir string) (FileChecksums, error) {
+	data, err := os.ReadFile(filepath.Join(dir, FileChecksumsName))
+	if err != nil {
+		return nil, err
+	}
+
+	var fc FileChecksums
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return nil, err
+	}
+
+	return fc, nil
+}
+
+func (fc FileChecksums) write(dir string) error {
+	data, err := json.Marshal(fc)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, FileChecksumsName), data, 0o644)
+}
+
+// installedFileChecksums returns the file checksums of the Binary's installed
+// version, unless it is the named version, or nil if they are unknown.
+func (b *Binary) installedFileChecksums(guid string) FileChecksums {
+	if b.State.Version == "" || b.State.Version == guid {
+		return nil
+	}
+
+	fc, err := readFileChecksums(filepath.Join(dirs.Versions, b.State.Version))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("Could not read installed file checksums", "error", err)
+		}
+		return nil
+	}
+
+	return fc
+}
+
+// Verify checks the files of the Binary's installed version against the
+// checksums stored when it was installed, without downloading anything, and
+// prints the files which are missing or corrupted. With repair, only the
+// packages holding those files are repaired. Versions installed without
+// stored checksums can only be verified by repairing all of their packages.
+// Files replaced by mods are left as-is.
+func (b *Binary) Verify(repair bool) error {
+	if b.State.Version == "" {
+		return ErrNotInstalled
+	}
+	dir := filepath.Join(dirs.Versions, b.State.Version)
+
+	sums, err := readFileChecksums(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		if !repair {
+			return fmt.Errorf("%w, verify it against its packages with -repair", ErrNoChecksums)
+		}
+		return b.repairAll()
+	} else if err != nil {
+		return fmt.Errorf("read checksums: %w", err)
+	}
+
+	slog.Info("Checking installed files", "guid", b.State.Version, "files", len(sums))
+
+	missing, corrupted, err := boot.CheckFiles(dir, sums, runtime.NumCPU(), func(rel string) bool {
+		_, ok := b.State.Mods[rel]
+		return ok
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, rel := range missing {
+		fmt.Println("Missing", rel)
+	}
+	for _, rel := range corrupted {
+		fmt.Println("Corrupted", rel)
+	}
+	fmt.Printf("Checked %d files of %s, %d missing and %d corrupted\n",
+		len(sums), b.State.Version, len(missing), len(corrupted))
+
+	damaged := len(missing) + len(corrupted)
+	if damaged == 0 {
+		return nil
+	}
+	if !repair {
+		return fmt.Errorf("%d %w, repair them with -repair", damaged, ErrDamagedVersion)
+	}
+
+	pf, err := readPackageFiles(dir)
+	if err != nil {
+		return fmt.Errorf("read package files: %w", err)
+	}
+
+	bad := make(map[string]bool, damaged)
+	for _, rel := range append(missing, corrupted...) {
+		bad[rel] = true
+	}
+
+	pm, err := b.installedManifest()
+	if err != nil {
+		return err
+	}
+
+	n, err := b.repairPackages(&pm, func(p *boot.Package) bool {
+		for _, rel := range pf[p.Checksum] {
+			if bad[rel] {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Repaired %d files\n", n)
+	return nil
+}
+
+// repairAll verifies the files of the Binary's installed version against
+// all of its packages, and repairs the files which are missing or corrupted.
+func (b *Binary) repairAll() error {
+	pm, err := b.installedManifest()
+	if err != nil {
+		return err
+	}
+
+	n, err := b.repairPackages(&pm, nil)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Verified %d packages of %s, repaired %d files\n", len(pm.Packages), pm.Deployment.GUID, n)
+	return nil
+}
+
+// installedManifest sets the Binary's deployment to its installed version,
+// and returns its package manifest.
+func (b *Binary) installedManifest() (boot.PackageManifest, error) {
+	if b.State.Version == "" {
+		return boot.PackageManifest{}, ErrNotInstalled
+	}
+
+	d := boot.NewDeployment(b.Type, b.Config.Channel, b.State.Version)
+	b.Deploy = &d
+	b.Dir = filepath.Join(dirs.Versions, d.GUID)
+
+	pm, err := boot.FetchPackageManifest(b.traceContext(), &d)
+	if err != nil {
+		return pm, fmt.Errorf("fetch package manifest: %w", err)
+	}
+
+	return pm, nil
+}
+
+// repairPackages repairs the files of the Binary's installed version from
+// the given packages for which include returns true, or from all of them if
+// include is nil, and returns the amount of files repaired.
+func (b *Binary) repairPackages(pm *boot.PackageManifest, include func(*boot.Package) bool) (int, error) {
+	if err := dirs.Mkdirs(dirs.Downloads); err != nil {
+		return 0, err
+	}
+
+	modded := func(path string) bool {
+		rel, err := filepath.Rel(b.Dir, path)
+		if err != nil {
+			return false
+		}
+		_, ok := b.State.Mods[rel]
+		return ok
+	}
+
+	pkgDirs := boot.BinaryDirectories(b.Type)
+	n := 0
+	for _, p := range pm.Packages {
+		p := p
+		if include != nil && !include(&p) {
+			continue
+		}
+
+		dir, err := b.packageDir(pkgDirs, p)
+		if err != nil {
+			return n, err
+		}
+		dest := filepath.Join(b.Dir, dir)
+
+		src := filepath.Join(dirs.Downloads, p.Checksum)
+		if err := p.Download(b.traceContext(), src, pm.DeployURL, nil); err != nil {
+			return n, err
+		}
+
+		slog.Info("Verifying package files", "name", p.Name, "dir", dest)
+
+		repaired, err := p.Repair(src, dest, runtime.NumCPU(), modded)
+		if err != nil {
+			return n, fmt.Errorf("repair %s: %w", p.Name, err)
+		}
+
+		for _, path := range repaired {
+			fmt.Println("Repaired", path)
+		}
+		n += len(repaired)
+	}
+
+	return n, nil
+}
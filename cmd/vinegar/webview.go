@@ -26,7 +26,10 @@ func (b *Binary) InstallWebView() error {
 	// that makes it work.
 	slog.Info("Setting Wineprefix version to win7")
 	b.Splash.SetMessage("Setting up wineprefix")
-	if err := b.Prefix.Wine("winecfg", "/v", "win7").Run(); err != nil {
+	err := b.withTimeout("Setting up wineprefix", PrefixInitTimeout, func() error {
+		return b.Prefix.Wine("winecfg", "/v", "win7").Run()
+	})
+	if err != nil {
 		return err
 	}
 
@@ -44,9 +47,11 @@ func (b *Binary) InstallWebView() error {
 	b.Splash.SetProgress(1.0)
 	slog.Info("Running WebView installer", "path", WebViewInstallerPath)
 
-	return b.Prefix.Wine(WebViewInstallerPath,
-		"--msedgewebview", "--do-not-launch-msedge", "--system-level",
-	).Run()
+	return b.withTimeout("Installing WebView", WebViewTimeout, func() error {
+		return b.Prefix.Wine(WebViewInstallerPath,
+			"--msedgewebview", "--do-not-launch-msedge", "--system-level",
+		).Run()
+	})
 }
 
 func (b *Binary) DownloadWebView() error {
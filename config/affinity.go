@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CPUs returns the CPUs of the Binary's CPU affinity, which is a comma
+// separated list of CPU numbers and ranges of CPU numbers, such as "0-3,6".
+func (b *Binary) CPUs() ([]int, error) {
+	var cpus []int
+	if b.CPUAffinity == "" {
+		return cpus, nil
+	}
+
+	for _, part := range strings.Split(b.CPUAffinity, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		if !isRange {
+			last = first
+		}
+
+		lo, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrBadCPUAffinity, part)
+		}
+		hi, err := strconv.Atoi(last)
+		if err != nil || lo < 0 || hi < lo || hi >= 1024 {
+			return nil, fmt.Errorf("%w: %s", ErrBadCPUAffinity, part)
+		}
+
+		for cpu := lo; cpu <= hi; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+
+	return cpus, nil
+}
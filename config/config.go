@@ -5,12 +5,23 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/geoip"
+	"github.com/vinegarhq/vinegar/internal/mods"
+	"github.com/vinegarhq/vinegar/internal/retry"
 	"github.com/vinegarhq/vinegar/roblox"
+	"github.com/vinegarhq/vinegar/roblox/api"
+	"github.com/vinegarhq/vinegar/roblox/bootstrapper"
 	"github.com/vinegarhq/vinegar/splash"
 	"github.com/vinegarhq/vinegar/sysinfo"
 	"github.com/vinegarhq/vinegar/wine"
@@ -23,52 +34,204 @@ var LogoPath string
 // Config is a representation of a Roblox binary Vinegar configuration.
 type Binary struct {
 	Channel       string        `toml:"channel"`
+	ChannelPolicy string        `toml:"channel_policy"`
 	Launcher      string        `toml:"launcher"`
 	Renderer      string        `toml:"renderer"`
 	WineRoot      string        `toml:"wineroot"`
+	Runner        string        `toml:"runner"`
 	DiscordRPC    bool          `toml:"discord_rpc"`
+	RPCGameInfo   bool          `toml:"discord_rpc_game_info"`
 	ForcedVersion string        `toml:"forced_version"`
+	Compat        string        `toml:"compat"`
+	UpdatePolicy  string        `toml:"update_policy"`
+	UpdateCheck   time.Duration `toml:"update_check_interval"`
 	Dxvk          bool          `toml:"dxvk"`
 	DxvkVersion   string        `toml:"dxvk_version"`
+	ShaderCache   bool          `toml:"shader_cache"`
+	ShaderSeed    string        `toml:"shader_cache_seed"`
 	FFlags        roblox.FFlags `toml:"fflags"`
+	FFlagProfile  string        `toml:"fflag_profile"`
 	Env           Environment   `toml:"env"`
 	ForcedGpu     string        `toml:"gpu"`
+	FPS           int           `toml:"fps"`
+	FrameLimiter  string        `toml:"frame_limiter"`
 	GameMode      bool          `toml:"gamemode"`
+	GameModeTree  bool          `toml:"gamemode_tree"`
+	Renice        int           `toml:"renice"`
+	CPUAffinity   string        `toml:"cpu_affinity"`
+	Inhibit       bool          `toml:"inhibit"`
+	MPRIS         bool          `toml:"mpris"`
+	Background    bool          `toml:"background"`
+	SecondLaunch  string        `toml:"second_launch"`
+	Mods          []string      `toml:"mods"`
+	Sandbox       bool          `toml:"sandbox"`
+	SandboxNet    bool          `toml:"sandbox_network"`
+	SandboxHome   bool          `toml:"sandbox_home"`
+	SandboxBinds  []string      `toml:"sandbox_binds"`
+	Gamescope     Gamescope     `toml:"gamescope"`
+	Scope         Scope         `toml:"scope"`
+	Playtime      Playtime      `toml:"playtime"`
+	OldCursor     bool          `toml:"oldcursor"`
+	DisablePostFX bool          `toml:"disable_postfx"`
+
+	// Passthrough is only supported by Studio.
+	Passthrough Passthrough `toml:"passthrough"`
+
+	Watchdog        bool `toml:"watchdog"`
+	WatchdogRetries int  `toml:"watchdog_retries"`
+
+	// MemoryWatchdog is the action taken once Roblox uses MemoryLimit
+	// percent of the memory it may use before being killed.
+	MemoryWatchdog string `toml:"memory_watchdog"`
+	MemoryLimit    int    `toml:"memory_limit"`
+
+	// The Player's in-game settings, set within its settings
+	// file before each launch if set.
+	GraphicsQuality int    `toml:"graphics_quality"`
+	Volume          *int   `toml:"volume"`
+	Fullscreen      *bool  `toml:"fullscreen"`
+	Resolution      string `toml:"resolution"`
+
+	Games    map[string]Game    `toml:"games"`
+	Profiles map[string]Profile `toml:"profiles"`
+}
+
+// Game is a representation of a Roblox game's configuration, keyed by
+// its place ID in Binary, to override the Binary's configuration when
+// launching into the game.
+type Game struct {
+	// Launcher overrides the Binary launcher, an empty launcher
+	// disables the Binary launcher.
+	Launcher *string `toml:"launcher"`
+
+	Renderer   string        `toml:"renderer"`
+	DiscordRPC *bool         `toml:"discord_rpc"`
+	FFlags     roblox.FFlags `toml:"fflags"`
+	Env        Environment   `toml:"env"`
+}
+
+// Profile is a representation of a named account's configuration, keyed
+// by its name in Binary, to override the Binary's configuration when
+// using the account, which has its own wineprefix.
+type Profile struct {
+	WineRoot string        `toml:"wineroot"`
+	FFlags   roblox.FFlags `toml:"fflags"`
+	Env      Environment   `toml:"env"`
 }
 
 // Config is a representation of the Vinegar configuration.
 type Config struct {
-	MultipleInstances bool        `toml:"multiple_instances"`
-	SanitizeEnv       bool        `toml:"sanitize_env"`
-	Player            Binary      `toml:"player"`
-	Studio            Binary      `toml:"studio"`
-	Env               Environment `toml:"env"`
+	MultipleInstances   bool        `toml:"multiple_instances"`
+	SanitizeEnv         bool        `toml:"sanitize_env"`
+	PresenceJoin        bool        `toml:"presence_join"`
+	Notifications       bool        `toml:"notifications"`
+	ServerLocation      bool        `toml:"server_location"`
+	ServerLocationAPI   string      `toml:"server_location_api"`
+	RobloxLogs          string      `toml:"roblox_logs"`
+	RobloxLogRate       int         `toml:"roblox_log_rate"`
+	KeepVersions        int         `toml:"keep_versions"`
+	DownloadConcurrency int         `toml:"download_concurrency"`
+	Mirror              string      `toml:"mirror"`
+	StagedExtraction    bool        `toml:"staged_extraction"`
+	MaxCacheSize        int         `toml:"max_cache_size_mb"`
+	Clipboard           string      `toml:"clipboard"`
+	InputMethod         string      `toml:"input_method"`
+	InputStyle          string      `toml:"input_style"`
+	Locale              string      `toml:"locale"`
+	KeyboardLayout      string      `toml:"keyboard_layout"`
+	SteamDeck           string      `toml:"deck"`
+	Emulator            string      `toml:"emulator"`
+	EmulatorRootFS      string      `toml:"emulator_rootfs"`
+	Player              Binary      `toml:"player"`
+	Studio              Binary      `toml:"studio"`
+	Env                 Environment `toml:"env"`
+
+	// API maps Roblox API services, such as clientsettings, to the base
+	// URL used for the service instead of the service's Roblox URL.
+	API map[string]string `toml:"api"`
 
+	OBS    OBS           `toml:"obs"`
+	Retry  Retry         `toml:"retry"`
 	Splash splash.Config `toml:"splash"`
+
+	// Locked are the dotted keys locked by the system configuration,
+	// only read from the system configuration.
+	Locked []string `toml:"locked"`
 }
 
 var (
 	ErrNeedDXVKRenderer = errors.New("dxvk is only valid with d3d renderers")
+	ErrNeedDXVK         = errors.New("dxvk is required")
 	ErrWineRootAbs      = errors.New("wine root path is not an absolute path")
 	ErrWineRootInvalid  = errors.New("no wine binary present in wine root")
+	ErrBadRunner        = errors.New("runner must be auto or a wine runner backend")
+	ErrBadCompat        = errors.New("compat must be auto, latest or legacy")
+	ErrBadChannelPolicy = errors.New("channel policy must be accept, ask or ignore")
+	ErrBadPlaceID       = errors.New("game place id must be numeric")
+	ErrBadFrameLimiter  = errors.New("unknown frame limiter")
+	ErrNeedFPS          = errors.New("frame limiter requires fps to be set")
+	ErrBadUpdatePolicy  = errors.New("unknown update policy")
+	ErrBadUpdateCheck   = errors.New("update check interval must not be negative")
+	ErrBadSecondLaunch  = errors.New("second launch must be replace, prompt or queue")
+	ErrBadRobloxLogs    = errors.New("roblox logs must be off, file, console or both")
+	ErrBadClipboard     = errors.New("clipboard must be clipboard or primary")
+	ErrBadSplashBackend = errors.New("splash backend must be auto, wayland or x11")
+	ErrBadSplashKind    = errors.New("splash kind must be auto, window, gtk, terminal or none")
+	ErrBadInputStyle    = errors.New("input style must be root, overthespot or offthespot")
+	ErrBadAPIURL        = errors.New("api service url must be an absolute http(s) url")
+	ErrBadShaderSeed    = errors.New("shader cache seed must be an absolute http(s) url")
+	ErrBadKeepVersions  = errors.New("atleast one version must be kept")
+	ErrBadConcurrency   = errors.New("download concurrency must be atleast 1")
+	ErrBadMirror        = errors.New("mirror must be auto or a deploy mirror URL")
+	ErrBadRenice        = errors.New("renice must be between -20 and 19")
+	ErrBadCPUAffinity   = errors.New("cpu affinity must be a list of cpus and cpu ranges")
+	ErrBadQuality       = errors.New("graphics quality must be between 1 and 10")
+	ErrBadVolume        = errors.New("volume must be between 0 and 100")
+	ErrBadResolution    = errors.New("resolution must be in the form of WIDTHxHEIGHT")
+	ErrBadSandboxBind   = errors.New("sandbox bind must be an absolute path")
+	ErrBadMemoryWatch   = errors.New("memory watchdog must be off, warn or kill")
+	ErrBadMemoryLimit   = errors.New("memory limit must be between 1 and 100")
 )
 
 // Load will load the named file to a Config; if it doesn't exist, it
 // will fallback to the default configuration.
 //
 // The returned configuration will always be appended ontop of the default
-// configuration.
+// configuration and the system configuration at [SystemPath], and overridden
+// by the environment variables prefixed with [EnvPrefix], except for the
+// keys locked by the system configuration.
 //
 // Load is required for any initialization for Config, as it calls routines
 // to setup certain variables and verifies the configuration.
 func Load(name string) (Config, error) {
 	cfg := Default()
 
-	if _, err := os.Stat(name); errors.Is(err, os.ErrNotExist) {
-		return cfg, nil
+	if err := cfg.decodeSystem(); err != nil {
+		return cfg, err
+	}
+
+	// The system configuration and the environment are applied and
+	// verified regardless of the user configuration existing.
+	var md *toml.MetaData
+	if _, err := os.Stat(name); !errors.Is(err, os.ErrNotExist) {
+		m, err := toml.DecodeFile(name, &cfg)
+		if err != nil {
+			return cfg, err
+		}
+
+		cfg.migrate(&m)
+		md = &m
 	}
 
-	if _, err := toml.DecodeFile(name, &cfg); err != nil {
+	if err := cfg.applyDeck(md); err != nil {
+		return cfg, err
+	}
+
+	if err := cfg.applyEnv(os.Environ()); err != nil {
+		return cfg, err
+	}
+
+	if err := cfg.lock(md); err != nil {
 		return cfg, err
 	}
 
@@ -78,6 +241,20 @@ func Load(name string) (Config, error) {
 // Default returns a sane default configuration for Vinegar.
 func Default() Config {
 	return Config{
+		RobloxLogs:          "console",
+		KeepVersions:        2,
+		DownloadConcurrency: 4,
+		Mirror:              "auto",
+		Notifications:       true,
+		ServerLocation:      true,
+		ServerLocationAPI:   geoip.DefaultAPI,
+		SteamDeck:           "auto",
+		Emulator:            "auto",
+		Clipboard:           "clipboard",
+		InputMethod:         "auto",
+		InputStyle:          "root",
+		Locale:              "auto",
+		KeyboardLayout:      "auto",
 		Env: Environment{
 			"WINEARCH":                    "win64",
 			"WINEDEBUG":                   "err-kerberos,err-ntlm",
@@ -90,34 +267,65 @@ func Default() Config {
 		},
 
 		Player: Binary{
-			Dxvk:        true,
-			DxvkVersion: "2.3",
-			GameMode:    true,
-			ForcedGpu:   "prime-discrete",
-			Renderer:    "D3D11",
-			Channel:     "", // Default upstream
-			DiscordRPC:  true,
-			FFlags: roblox.FFlags{
-				"DFIntTaskSchedulerTargetFps": 640,
-			},
+			Dxvk:            true,
+			DxvkVersion:     "2.3",
+			ShaderCache:     true,
+			GameMode:        true,
+			Inhibit:         true,
+			ForcedGpu:       "prime-discrete",
+			Renderer:        "D3D11",
+			Channel:         "", // Default upstream
+			ChannelPolicy:   "ask",
+			UpdatePolicy:    "auto",
+			SecondLaunch:    "replace",
+			DiscordRPC:      true,
+			RPCGameInfo:     true,
+			SandboxNet:      true,
+			WatchdogRetries: 3,
+			MemoryWatchdog:  "off",
+			MemoryLimit:     90,
+			Playtime:        Playtime{Warning: 5 * time.Minute},
+			FPS:             640,
+			FFlags:          make(roblox.FFlags),
 			Env: Environment{
 				"OBS_VKCAPTURE": "1",
 			},
 		},
 		Studio: Binary{
-			Dxvk:        true,
-			DxvkVersion: "2.3",
-			GameMode:    true,
-			Channel:     "", // Default upstream
-			ForcedGpu:   "prime-discrete",
-			Renderer:    "D3D11",
+			Dxvk:            true,
+			DxvkVersion:     "2.3",
+			ShaderCache:     true,
+			GameMode:        true,
+			Channel:         "", // Default upstream
+			ChannelPolicy:   "ask",
+			UpdatePolicy:    "auto",
+			ForcedGpu:       "prime-discrete",
+			Renderer:        "D3D11",
+			RPCGameInfo:     true,
+			SandboxNet:      true,
+			WatchdogRetries: 3,
+			MemoryWatchdog:  "off",
+			MemoryLimit:     90,
+			Playtime:        Playtime{Warning: 5 * time.Minute},
 			// TODO: fill with studio fflag/env goodies
 			FFlags: make(roblox.FFlags),
 			Env:    make(Environment),
 		},
 
+		OBS: OBS{
+			Address: "localhost:4455",
+		},
+
+		Retry: Retry{
+			Attempts: retry.Network.Attempts,
+			Delay:    retry.Network.Delay,
+			MaxDelay: retry.Network.MaxDelay,
+		},
+
 		Splash: splash.Config{
 			Enabled:     true,
+			Kind:        splash.KindAuto,
+			Backend:     "auto",
 			LogoPath:    LogoPath,
 			BgColor:     0x242424,
 			FgColor:     0xfafafa,
@@ -129,27 +337,267 @@ func Default() Config {
 	}
 }
 
+// Backend returns the name of the wine runner backend to run Wine
+// with, which is detected from the wine root if empty.
+func (b *Binary) Backend() string {
+	if b.Runner == "auto" {
+		return ""
+	}
+
+	return b.Runner
+}
+
 func (b *Binary) LauncherPath() (string, error) {
 	return exec.LookPath(strings.Fields(b.Launcher)[0])
 }
 
+// GameLauncher returns the launcher to use when launching into the game
+// with the named place ID.
+func (b *Binary) GameLauncher(placeID string) string {
+	if g, ok := b.Games[placeID]; ok && g.Launcher != nil {
+		return *g.Launcher
+	}
+
+	return b.Launcher
+}
+
+// ForGame returns the Binary's configuration with the configuration of
+// the game with the named place ID applied over it. The game's FFlags and
+// environment variables take precedence over the Binary's.
+func (b *Binary) ForGame(placeID string) (Binary, error) {
+	g, ok := b.Games[placeID]
+	if !ok {
+		return *b, nil
+	}
+
+	gb := *b
+	gb.Launcher = b.GameLauncher(placeID)
+
+	gb.FFlags = make(roblox.FFlags, len(b.FFlags)+len(g.FFlags))
+	for n, v := range b.FFlags {
+		gb.FFlags[n] = v
+	}
+
+	if g.Renderer != "" {
+		gb.Renderer = g.Renderer
+		if err := gb.FFlags.SetRenderer(g.Renderer); err != nil {
+			return Binary{}, fmt.Errorf("game %s: %w", placeID, err)
+		}
+	}
+
+	for n, v := range g.FFlags {
+		gb.FFlags[n] = v
+	}
+
+	gb.Env = make(Environment, len(b.Env)+len(g.Env))
+	for n, v := range b.Env {
+		gb.Env[n] = v
+	}
+	for n, v := range g.Env {
+		gb.Env[n] = v
+	}
+
+	if g.DiscordRPC != nil {
+		gb.DiscordRPC = *g.DiscordRPC
+	}
+
+	return gb, nil
+}
+
+// ForProfile returns the Binary's configuration with the configuration of
+// the named profile applied over it, if any. The profile's FFlags and
+// environment variables take precedence over the Binary's.
+func (b *Binary) ForProfile(name string) Binary {
+	p, ok := b.Profiles[name]
+	if !ok {
+		return *b
+	}
+
+	pb := *b
+	if p.WineRoot != "" {
+		pb.WineRoot = p.WineRoot
+	}
+
+	pb.FFlags = make(roblox.FFlags, len(b.FFlags)+len(p.FFlags))
+	for n, v := range b.FFlags {
+		pb.FFlags[n] = v
+	}
+	for n, v := range p.FFlags {
+		pb.FFlags[n] = v
+	}
+
+	pb.Env = make(Environment, len(b.Env)+len(p.Env))
+	for n, v := range b.Env {
+		pb.Env[n] = v
+	}
+	for n, v := range p.Env {
+		pb.Env[n] = v
+	}
+
+	return pb
+}
+
 func (b *Binary) validate() error {
 	if !strings.HasPrefix(b.Renderer, "D3D11") && b.Dxvk {
 		return ErrNeedDXVKRenderer
 	}
 
+	switch b.UpdatePolicy {
+	case "", "auto", "notify", "never":
+	default:
+		return fmt.Errorf("%w: %s", ErrBadUpdatePolicy, b.UpdatePolicy)
+	}
+
+	if b.UpdateCheck < 0 {
+		return fmt.Errorf("%w: %s", ErrBadUpdateCheck, b.UpdateCheck)
+	}
+
+	if b.ShaderSeed != "" {
+		u, err := url.Parse(b.ShaderSeed)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s", ErrBadShaderSeed, b.ShaderSeed)
+		}
+	}
+
+	switch b.SecondLaunch {
+	case "", "replace", "prompt", "queue":
+	default:
+		return fmt.Errorf("%w: %s", ErrBadSecondLaunch, b.SecondLaunch)
+	}
+
+	switch b.FrameLimiter {
+	case "":
+	case "dxvk":
+		if !b.Dxvk {
+			return fmt.Errorf("%s: %w", b.FrameLimiter, ErrNeedDXVK)
+		}
+		fallthrough
+	case "mangohud":
+		if b.FPS <= 0 {
+			return fmt.Errorf("%s: %w", b.FrameLimiter, ErrNeedFPS)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrBadFrameLimiter, b.FrameLimiter)
+	}
+
+	if b.Renice < -20 || b.Renice > 19 {
+		return fmt.Errorf("%w: %d", ErrBadRenice, b.Renice)
+	}
+
+	switch b.MemoryWatchdog {
+	case "", "off":
+	case "warn", "kill":
+		if b.MemoryLimit < 1 || b.MemoryLimit > 100 {
+			return fmt.Errorf("%w: %d", ErrBadMemoryLimit, b.MemoryLimit)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrBadMemoryWatch, b.MemoryWatchdog)
+	}
+
+	if _, err := b.CPUs(); err != nil {
+		return err
+	}
+
+	if err := b.validateSettings(); err != nil {
+		return err
+	}
+
+	if err := b.Gamescope.validate(); err != nil {
+		return fmt.Errorf("gamescope: %w", err)
+	}
+
+	if err := b.Scope.validate(); err != nil {
+		return fmt.Errorf("scope: %w", err)
+	}
+
+	if err := b.Playtime.validate(); err != nil {
+		return fmt.Errorf("playtime: %w", err)
+	}
+
+	if err := b.Passthrough.validate(); err != nil {
+		return err
+	}
+
+	if b.Sandbox && !sysinfo.InFlatpak {
+		if _, err := exec.LookPath("bwrap"); err != nil {
+			return fmt.Errorf("sandbox: %w", err)
+		}
+	}
+
+	for _, p := range b.SandboxBinds {
+		if !filepath.IsAbs(p) {
+			return fmt.Errorf("%w: %s", ErrBadSandboxBind, p)
+		}
+	}
+
 	if b.Launcher != "" {
 		if _, err := b.LauncherPath(); err != nil {
 			return fmt.Errorf("bad launcher: %w", err)
 		}
 	}
 
-	if b.WineRoot != "" {
-		if _, err := wine.Wine64(b.WineRoot); err != nil {
+	for _, m := range b.Mods {
+		if !mods.Exists(m, dirs.Mods) {
+			return fmt.Errorf("%w: %s", mods.ErrNoMod, m)
+		}
+	}
+
+	switch b.ChannelPolicy {
+	case "", "accept", "ask", "ignore":
+	default:
+		return fmt.Errorf("%w: %s", ErrBadChannelPolicy, b.ChannelPolicy)
+	}
+
+	switch b.Compat {
+	case "", "auto", "latest", "legacy":
+	default:
+		return fmt.Errorf("%w: %s", ErrBadCompat, b.Compat)
+	}
+
+	if b.Runner != "" && b.Runner != "auto" && !slices.Contains(wine.Backends(), b.Runner) {
+		return fmt.Errorf("%w: %s", ErrBadRunner, b.Runner)
+	}
+
+	if b.WineRoot != "" || b.Runner == "proton" {
+		if _, err := wine.Lookup(b.WineRoot, b.Backend()); err != nil {
 			return fmt.Errorf("bad wineroot: %w", err)
 		}
 	}
 
+	for name, p := range b.Profiles {
+		if p.WineRoot == "" {
+			continue
+		}
+
+		if _, err := wine.Lookup(p.WineRoot, b.Backend()); err != nil {
+			return fmt.Errorf("profile %s: bad wineroot: %w", name, err)
+		}
+	}
+
+	for id, g := range b.Games {
+		if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+			return fmt.Errorf("game %s: %w", id, ErrBadPlaceID)
+		}
+
+		if g.Renderer != "" {
+			if !roblox.ValidRenderer(g.Renderer) {
+				return fmt.Errorf("game %s: %w: %s", id, roblox.ErrInvalidRenderer, g.Renderer)
+			}
+
+			if !strings.HasPrefix(g.Renderer, "D3D11") && b.Dxvk {
+				return fmt.Errorf("game %s: %w", id, ErrNeedDXVKRenderer)
+			}
+		}
+
+		if g.Launcher == nil || *g.Launcher == "" {
+			continue
+		}
+
+		if _, err := exec.LookPath(strings.Fields(*g.Launcher)[0]); err != nil {
+			return fmt.Errorf("game %s: bad launcher: %w", id, err)
+		}
+	}
+
 	return nil
 }
 
@@ -158,10 +606,17 @@ func (b *Binary) setup() error {
 		return fmt.Errorf("invalid: %w", err)
 	}
 
+	if err := b.setupFFlagProfile(); err != nil {
+		return err
+	}
+
 	if err := b.FFlags.SetRenderer(b.Renderer); err != nil {
 		return err
 	}
 
+	b.setupFPS()
+	b.setupTweaks()
+
 	if b.Channel == "LIVE" || b.Channel == "live" {
 		b.Channel = ""
 	}
@@ -182,12 +637,100 @@ func (c *Config) setup() error {
 		slog.Warn("Multiple instances is broken on Flatpak! Please consider using a source installation!")
 	}
 
+	if c.ServerLocation {
+		u, err := url.Parse(c.ServerLocationAPI)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("server location: %w", ErrBadAPIURL)
+		}
+	}
+
+	for service, base := range c.API {
+		u, err := url.Parse(base)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("api %s: %w", service, ErrBadAPIURL)
+		}
+
+		slog.Warn("Using alternative Roblox API service URL!", "service", service, "url", base)
+		api.SetServiceURL(service, base)
+	}
+
+	if c.KeepVersions < 1 {
+		return fmt.Errorf("%w: %d", ErrBadKeepVersions, c.KeepVersions)
+	}
+
+	if c.DownloadConcurrency < 1 {
+		return fmt.Errorf("%w: %d", ErrBadConcurrency, c.DownloadConcurrency)
+	}
+
+	if c.Mirror != "" && c.Mirror != "auto" {
+		u, err := url.Parse(c.Mirror)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s", ErrBadMirror, c.Mirror)
+		}
+
+		slog.Warn("Using alternative deploy mirror!", "mirror", c.Mirror)
+		bootstrapper.SetMirror(c.Mirror)
+	}
+
+	if err := c.OBS.validate(); err != nil {
+		return fmt.Errorf("obs: %w", err)
+	}
+
+	if err := c.Retry.validate(); err != nil {
+		return fmt.Errorf("retry: %w", err)
+	}
+	c.Retry.apply(&retry.Network)
+
+	switch c.RobloxLogs {
+	case "", "off", "file", "console", "both":
+	default:
+		return fmt.Errorf("%w: %s", ErrBadRobloxLogs, c.RobloxLogs)
+	}
+
+	switch c.Clipboard {
+	case "", "clipboard", "primary":
+	default:
+		return fmt.Errorf("%w: %s", ErrBadClipboard, c.Clipboard)
+	}
+
+	if c.Splash.Kind != "" && !slices.Contains(splash.Kinds, c.Splash.Kind) {
+		return fmt.Errorf("%w: %s", ErrBadSplashKind, c.Splash.Kind)
+	}
+
+	switch c.Splash.Backend {
+	case "", "auto", "wayland", "x11":
+	default:
+		return fmt.Errorf("%w: %s", ErrBadSplashBackend, c.Splash.Backend)
+	}
+
+	switch c.InputStyle {
+	case "", "root", "overthespot", "offthespot":
+	default:
+		return fmt.Errorf("%w: %s", ErrBadInputStyle, c.InputStyle)
+	}
+
+	c.setupInputMethod()
+
+	if _, err := c.KeyboardLayoutID(); err != nil {
+		return err
+	}
+
+	c.setupLocale()
+
+	if err := c.setupEmulator(); err != nil {
+		return fmt.Errorf("emulator: %w", err)
+	}
+
 	c.Env.Setenv()
 
 	if err := c.Player.setup(); err != nil {
 		return fmt.Errorf("player: %w", err)
 	}
 
+	if c.Player.Passthrough.Enabled() {
+		return fmt.Errorf("player: %w", ErrPassthroughStudio)
+	}
+
 	if err := c.Studio.setup(); err != nil {
 		return fmt.Errorf("studio: %w", err)
 	}
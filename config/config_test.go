@@ -4,8 +4,13 @@ import (
 	"errors"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/mods"
 	"github.com/vinegarhq/vinegar/roblox"
 )
 
@@ -46,3 +51,683 @@ func TestBinarySetup(t *testing.T) {
 		t.Error("expected exec not found")
 	}
 }
+
+func TestGameLauncher(t *testing.T) {
+	none := ""
+	b := Binary{
+		Launcher: "gamemoderun",
+		Games: map[string]Game{
+			"1818": {Launcher: &none},
+			"1819": {},
+		},
+	}
+
+	if l := b.GameLauncher("1818"); l != "" {
+		t.Errorf("launcher %s, want disabled game launcher", l)
+	}
+
+	if l := b.GameLauncher("1819"); l != b.Launcher {
+		t.Errorf("launcher %s, want binary launcher for unset game launcher", l)
+	}
+
+	if l := b.GameLauncher("1"); l != b.Launcher {
+		t.Errorf("launcher %s, want binary launcher for unknown game", l)
+	}
+
+	b.Launcher = ""
+	b.Games["meow"] = Game{}
+	if err := b.validate(); !errors.Is(err, ErrBadPlaceID) {
+		t.Error("expected game place id check")
+	}
+}
+
+func TestForGame(t *testing.T) {
+	off := false
+	b := Binary{
+		Renderer:   "D3D11",
+		DiscordRPC: true,
+		FFlags:     roblox.FFlags{"FFlagMeow": true, "FIntPurr": 1},
+		Env:        Environment{"MEOW": "1"},
+		Games: map[string]Game{
+			"1818": {
+				Renderer:   "Vulkan",
+				DiscordRPC: &off,
+				FFlags:     roblox.FFlags{"FIntPurr": 2},
+				Env:        Environment{"HISS": "1"},
+			},
+		},
+	}
+
+	g, err := b.ForGame("1818")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Renderer != "Vulkan" || g.FFlags["FFlagDebugGraphicsPreferVulkan"] != true {
+		t.Error("expected game renderer")
+	}
+
+	if g.DiscordRPC {
+		t.Error("expected game discord rpc")
+	}
+
+	if g.FFlags["FIntPurr"] != 2 || g.FFlags["FFlagMeow"] != true {
+		t.Errorf("fflags %v, want game fflags over binary fflags", g.FFlags)
+	}
+
+	if g.Env["MEOW"] != "1" || g.Env["HISS"] != "1" {
+		t.Errorf("env %v, want game env over binary env", g.Env)
+	}
+
+	if b.FFlags["FIntPurr"] != 1 || len(b.Env) != 1 {
+		t.Error("expected binary configuration to be left as-is")
+	}
+
+	b.Dxvk = true
+	if err := b.validate(); !errors.Is(err, ErrNeedDXVKRenderer) {
+		t.Error("expected game dxvk renderer check")
+	}
+}
+
+func TestForProfile(t *testing.T) {
+	b := Binary{
+		WineRoot: "/usr",
+		FFlags:   roblox.FFlags{"FFlagMeow": true, "FIntPurr": 1},
+		Env:      Environment{"MEOW": "1"},
+		Profiles: map[string]Profile{
+			"alt": {
+				FFlags: roblox.FFlags{"FIntPurr": 2},
+				Env:    Environment{"HISS": "1"},
+			},
+		},
+	}
+
+	p := b.ForProfile("alt")
+	if p.WineRoot != "/usr" {
+		t.Error("expected binary wineroot")
+	}
+
+	if p.FFlags["FIntPurr"] != 2 || p.FFlags["FFlagMeow"] != true {
+		t.Errorf("fflags %v, want profile fflags over binary fflags", p.FFlags)
+	}
+
+	if p.Env["MEOW"] != "1" || p.Env["HISS"] != "1" {
+		t.Errorf("env %v, want profile env over binary env", p.Env)
+	}
+
+	if b.FFlags["FIntPurr"] != 1 || len(b.Env) != 1 {
+		t.Error("expected binary configuration to be left as-is")
+	}
+
+	if p := b.ForProfile("meow"); p.FFlags["FIntPurr"] != 1 {
+		t.Error("expected binary configuration without a profile")
+	}
+}
+
+func TestBinaryFPS(t *testing.T) {
+	b := Binary{
+		FPS:          144,
+		FrameLimiter: "dxvk",
+		Renderer:     "D3D11",
+		FFlags:       make(roblox.FFlags),
+		Env:          make(Environment),
+	}
+
+	if err := b.setup(); !errors.Is(err, ErrNeedDXVK) {
+		t.Error("expected dxvk frame limiter check")
+	}
+
+	b.Dxvk = true
+	if err := b.setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.FFlags[roblox.FPSFlag] != 144 {
+		t.Error("expected fps fflag")
+	}
+
+	if b.Env["DXVK_FRAME_RATE"] != "144" {
+		t.Error("expected dxvk frame rate")
+	}
+
+	b.FFlags[roblox.FPSFlag] = 60
+	if b.setupFPS(); b.FFlags[roblox.FPSFlag] != 60 {
+		t.Error("expected explicit fps fflag precedence")
+	}
+}
+
+func TestBinaryTweaks(t *testing.T) {
+	b := Binary{
+		OldCursor:     true,
+		DisablePostFX: true,
+		Mods:          []string{"meow"},
+		FFlags:        make(roblox.FFlags),
+	}
+
+	b.setupTweaks()
+	b.setupTweaks()
+
+	if !slices.Equal(b.Mods, []string{mods.ClassicCursor, "meow"}) {
+		t.Errorf("mods %v, want cursor mod applied first once", b.Mods)
+	}
+
+	if b.FFlags[PostFXFlag] != true {
+		t.Error("expected postfx fflag")
+	}
+
+	b.FFlags[PostFXFlag] = false
+	if b.setupTweaks(); b.FFlags[PostFXFlag] != false {
+		t.Error("expected explicit postfx fflag precedence")
+	}
+}
+
+func TestGamescopeArgs(t *testing.T) {
+	g := Gamescope{Width: 1280, Height: 800, Refresh: 60, Fullscreen: true}
+	want := []string{"gamescope", "-W", "1280", "-w", "1280", "-H", "800", "-h", "800", "-r", "60", "-f", "--"}
+	if args := g.Args(); !slices.Equal(args, want) {
+		t.Errorf("gamescope args %v, want %v", args, want)
+	}
+
+	if args := (&Gamescope{}).Args(); !slices.Equal(args, []string{"gamescope", "--"}) {
+		t.Errorf("default gamescope args %v", args)
+	}
+
+	g = Gamescope{Enabled: true, Width: -1}
+	if err := g.validate(); !errors.Is(err, ErrBadGamescope) {
+		t.Error("expected gamescope size check")
+	}
+}
+
+func TestScopeArgs(t *testing.T) {
+	s := Scope{MemoryMax: "8G", CPUWeight: 200}
+	want := []string{"systemd-run", "--user", "--scope", "--collect", "--quiet", "--unit=vinegar-meow",
+		"--property=MemoryMax=8G", "--property=CPUWeight=200", "--"}
+	if args := s.Args("vinegar-meow"); !slices.Equal(args, want) {
+		t.Errorf("scope args %v, want %v", args, want)
+	}
+
+	for _, m := range []string{"8GB", "meow", "-1"} {
+		s = Scope{Enabled: true, MemoryMax: m}
+		if err := s.validate(); !errors.Is(err, ErrBadScopeMemory) {
+			t.Errorf("expected scope memory max %s check", m)
+		}
+	}
+}
+
+func TestPlaytimeRemaining(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2024, 1, 1, h, m, 0, 0, time.UTC)
+	}
+
+	for _, tt := range []struct {
+		pt     Playtime
+		now    time.Time
+		played time.Duration
+		want   time.Duration
+		err    error
+	}{
+		{Playtime{DailyLimit: time.Hour}, at(12, 0), 20 * time.Minute, 40 * time.Minute, nil},
+		{Playtime{DailyLimit: time.Hour}, at(12, 0), time.Hour, 0, ErrPlaytimeLimit},
+		{Playtime{AllowedHours: "08:00-20:00"}, at(19, 30), 0, 30 * time.Minute, nil},
+		{Playtime{AllowedHours: "08:00-20:00"}, at(20, 0), 0, 0, ErrPlaytimeHours},
+		{Playtime{AllowedHours: "20:00-02:00"}, at(23, 0), 0, 3 * time.Hour, nil},
+		{Playtime{AllowedHours: "20:00-02:00"}, at(1, 0), 0, time.Hour, nil},
+		{Playtime{AllowedHours: "20:00-02:00"}, at(12, 0), 0, 0, ErrPlaytimeHours},
+		{Playtime{DailyLimit: time.Hour, AllowedHours: "08:00-20:00"}, at(19, 50), 0, 10 * time.Minute, nil},
+	} {
+		left, err := tt.pt.Remaining(tt.now, tt.played)
+		if left != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("%+v at %s: %s (%v), want %s (%v)",
+				tt.pt, tt.now.Format(time.Kitchen), left, err, tt.want, tt.err)
+		}
+	}
+
+	for _, h := range []string{"8-20", "08:00-08:00", "25:00-02:00", "08:60-20:00"} {
+		if err := (&Playtime{AllowedHours: h}).validate(); !errors.Is(err, ErrBadPlaytimeHours) {
+			t.Errorf("allowed hours %s: %v, want %v", h, err, ErrBadPlaytimeHours)
+		}
+	}
+}
+
+func TestBinaryCPUs(t *testing.T) {
+	b := Binary{CPUAffinity: "0-2, 6"}
+
+	cpus, err := b.CPUs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(cpus, []int{0, 1, 2, 6}) {
+		t.Errorf("cpus %v, want 0, 1, 2 and 6", cpus)
+	}
+
+	for _, a := range []string{"meow", "3-1", "-1", "0,"} {
+		b.CPUAffinity = a
+		if _, err := b.CPUs(); !errors.Is(err, ErrBadCPUAffinity) {
+			t.Errorf("expected cpu affinity %q check", a)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	r := Default().Retry
+	if err := r.validate(); err != nil {
+		t.Fatalf("default retry: %s", err)
+	}
+
+	for _, bad := range []Retry{
+		{Attempts: 0, Delay: time.Second, MaxDelay: time.Second},
+		{Attempts: 3, Delay: 2 * time.Second, MaxDelay: time.Second},
+	} {
+		if err := bad.validate(); !errors.Is(err, ErrBadRetry) {
+			t.Errorf("retry %+v: got %v, want %v", bad, err, ErrBadRetry)
+		}
+	}
+}
+
+func TestPassthrough(t *testing.T) {
+	pt := Passthrough{
+		Plugins: "/home/meow/plugins",
+		Drives:  map[string]string{"p": "/home/meow/projects"},
+		Links:   map[string]string{"Documents/Roblox": "/home/meow/places"},
+	}
+	if err := pt.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if d := pt.Dirs(); len(d) != 3 || d[0] != "/home/meow/places" {
+		t.Errorf("got dirs %v, want sorted dirs", d)
+	}
+
+	for _, tc := range []struct {
+		pt  Passthrough
+		err error
+	}{
+		{Passthrough{Plugins: "plugins"}, ErrBadPassthroughDir},
+		{Passthrough{Drives: map[string]string{"c": "/home/meow"}}, ErrBadPassthroughDrive},
+		{Passthrough{Drives: map[string]string{"pp": "/home/meow"}}, ErrBadPassthroughDrive},
+		{Passthrough{Links: map[string]string{"../../windows": "/home/meow"}}, ErrBadPassthroughLink},
+	} {
+		if err := tc.pt.validate(); !errors.Is(err, tc.err) {
+			t.Errorf("passthrough %+v: got %v, want %v", tc.pt, err, tc.err)
+		}
+	}
+}
+
+func TestEmulator(t *testing.T) {
+	c := Config{Emulator: "qemu"}
+
+	if err := c.validateEmulator(); !errors.Is(err, ErrBadEmulator) {
+		t.Error("expected emulator check")
+	}
+
+	c.Emulator = "fex"
+	if err := c.validateEmulator(); err != nil {
+		t.Fatal(err)
+	}
+
+	c.EmulatorRootFS = "rootfs"
+	if err := c.validateEmulator(); err == nil {
+		t.Error("expected emulator rootfs path check")
+	}
+}
+
+func TestDeck(t *testing.T) {
+	c := Default()
+	c.SteamDeck = "off"
+
+	if err := c.applyDeck(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Player.FPS == DeckFPS {
+		t.Error("expected no deck preset")
+	}
+
+	c.SteamDeck = "on"
+	if err := c.applyDeck(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Player.FPS != DeckFPS {
+		t.Error("expected deck preset fps")
+	}
+
+	c.SteamDeck = "meow"
+	if err := c.applyDeck(nil); !errors.Is(err, ErrBadDeck) {
+		t.Error("expected deck option check")
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	c := Default()
+	err := c.applyEnv([]string{
+		"VINEGAR_PLAYER_RENDERER=Vulkan",
+		"VINEGAR_PLAYER_DISCORD_RPC_GAME_INFO=false",
+		"vinegar_keep_versions=1",
+		"VINEGAR_SPLASH_BACKEND=x11",
+		"VINEGAR_STUDIO_UPDATE_CHECK_INTERVAL=1h",
+		"VINEGAR_PLAYER_MODS=classic_cursor, meow",
+		"VINEGAR_PLAYER_VOLUME=50",
+		"VINEGAR_ENV_MEOW=purr",
+		"VINEGAR_PLAYER_FFLAGS_FIntMeow=3",
+		"VINEGAR_DETACHED=1",
+		"PATH=/usr/bin",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	switch {
+	case c.Player.Renderer != "Vulkan":
+		t.Errorf("renderer is %s", c.Player.Renderer)
+	case c.Player.RPCGameInfo:
+		t.Error("discord_rpc_game_info not overridden")
+	case c.KeepVersions != 2:
+		t.Error("lowercase prefix overrides keep_versions")
+	case c.Splash.Backend != "x11":
+		t.Errorf("splash backend is %s", c.Splash.Backend)
+	case c.Studio.UpdateCheck != time.Hour:
+		t.Errorf("studio update check is %s", c.Studio.UpdateCheck)
+	case !slices.Equal(c.Player.Mods, []string{"classic_cursor", "meow"}):
+		t.Errorf("mods are %v", c.Player.Mods)
+	case c.Player.Volume == nil || *c.Player.Volume != 50:
+		t.Error("volume not overridden")
+	case c.Env["MEOW"] != "purr":
+		t.Errorf("env is %v", c.Env)
+	case c.Player.FFlags["FIntMeow"] != int64(3):
+		t.Errorf("fflags are %v", c.Player.FFlags)
+	}
+
+	if err := c.applyEnv([]string{"VINEGAR_PLAYER_FPS=meow"}); err == nil {
+		t.Error("expected bad value error")
+	}
+}
+
+func TestLoadMissingEnv(t *testing.T) {
+	defer func(p string) { SystemPath = p }(SystemPath)
+	SystemPath = filepath.Join(t.TempDir(), "system.toml")
+	name := filepath.Join(t.TempDir(), "config.toml")
+
+	t.Setenv("VINEGAR_PLAYER_RENDERER", "Vulkan")
+	t.Setenv("VINEGAR_PLAYER_DXVK", "false")
+	cfg, err := Load(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.Player.FFlags) == 0 {
+		t.Error("expected renderer fflags to be set up")
+	}
+
+	t.Setenv("VINEGAR_PLAYER_RENDERER", "Bogus")
+	if _, err := Load(name); err == nil {
+		t.Error("expected invalid renderer error")
+	}
+}
+
+func TestLoadTableEnv(t *testing.T) {
+	defer func(p string) { SystemPath = p }(SystemPath)
+	SystemPath = filepath.Join(t.TempDir(), "system.toml")
+	name := filepath.Join(t.TempDir(), "config.toml")
+
+	t.Setenv("VINEGAR_SPLASH", "none")
+	t.Setenv("VINEGAR_SPLASH_KIND", "terminal")
+	cfg, err := Load(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Splash.Kind != "terminal" {
+		t.Errorf("splash kind %s, want terminal", cfg.Splash.Kind)
+	}
+}
+
+func TestKeyboardLayout(t *testing.T) {
+	c := Config{KeyboardLayout: "de"}
+
+	if klid, err := c.KeyboardLayoutID(); err != nil || klid != "00000407" {
+		t.Errorf("got %s (%v), want de layout id", klid, err)
+	}
+
+	c.KeyboardLayout = "0000040C"
+	if klid, err := c.KeyboardLayoutID(); err != nil || klid != "0000040C" {
+		t.Errorf("got %s (%v), want given layout id", klid, err)
+	}
+
+	c.KeyboardLayout = "meow"
+	if _, err := c.KeyboardLayoutID(); !errors.Is(err, ErrBadKeyboardLayout) {
+		t.Error("expected keyboard layout check")
+	}
+}
+
+func TestBinarySettings(t *testing.T) {
+	b := Binary{Resolution: "1920x1080"}
+
+	if w, h, err := b.ScreenSize(); err != nil || w != 1920 || h != 1080 {
+		t.Errorf("screen size %dx%d (%v), want 1920x1080", w, h, err)
+	}
+
+	b.Resolution = "1920"
+	if err := b.validateSettings(); !errors.Is(err, ErrBadResolution) {
+		t.Error("expected resolution check")
+	}
+
+	b.Resolution = ""
+	b.GraphicsQuality = 11
+	if err := b.validateSettings(); !errors.Is(err, ErrBadQuality) {
+		t.Error("expected graphics quality check")
+	}
+}
+
+func TestFFlagProfile(t *testing.T) {
+	dirs.FFlags = t.TempDir()
+
+	if _, err := FFlagProfile("meow"); !errors.Is(err, ErrNoFFlagProfile) {
+		t.Error("expected unknown profile check")
+	}
+
+	path := filepath.Join(t.TempDir(), "meow.json")
+	if err := os.WriteFile(path, []byte(`{"FIntPurr": 2}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if name, err := ImportFFlagProfile(path); err != nil || name != "meow" {
+		t.Fatalf("imported profile %s (%v), want meow", name, err)
+	}
+
+	b := Binary{
+		FFlagProfile: "meow",
+		FFlags:       roblox.FFlags{"FFlagHiss": true},
+	}
+	if err := b.setupFFlagProfile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.FFlags["FIntPurr"] != float64(2) || b.FFlags["FFlagHiss"] != true {
+		t.Errorf("fflags %v, want profile fflags merged", b.FFlags)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "config.toml")
+	data := "meow = true\n\n[player]\ndxvk = false\nrenice = 40\n\n[purr]\nhiss = 1\n"
+	if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := Validate(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		line int
+		err  error
+	}{
+		{1, ErrUnknownKey},
+		{7, ErrUnknownKey},
+		{5, ErrBadRenice},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("got problems %v, want %d", problems, len(want))
+	}
+	for i, w := range want {
+		if problems[i].Line != w.line || !errors.Is(problems[i].Err, w.err) {
+			t.Errorf("problem %d is %v, want %v at line %d", i, problems[i], w.err, w.line)
+		}
+	}
+
+	if err := os.WriteFile(name, []byte("[player]\nrenice = meow\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err = Validate(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0].Line != 2 {
+		t.Errorf("got problems %v, want a syntax error at line 2", problems)
+	}
+}
+
+func TestLock(t *testing.T) {
+	dir := t.TempDir()
+	defer func(p string) { SystemPath = p }(SystemPath)
+	SystemPath = filepath.Join(dir, "system.toml")
+
+	sys := "locked = [\"player.renice\", \"env.MEOW\", \"env.PURR\"]\n\n" +
+		"[player]\nrenice = 5\n\n[env]\nMEOW = \"meow\"\n"
+	if err := os.WriteFile(SystemPath, []byte(sys), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	name := filepath.Join(dir, "config.toml")
+	data := "[player]\nrenice = 10\ngamemode = false\n\n[env]\nMEOW = \"hiss\"\nPURR = \"mrrp\"\n"
+	if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Player.Renice != 5 || cfg.Player.GameMode {
+		t.Errorf("player renice %d and gamemode %t, want locked 5 and unlocked false",
+			cfg.Player.Renice, cfg.Player.GameMode)
+	}
+	if v, ok := cfg.Env["PURR"]; cfg.Env["MEOW"] != "meow" || ok {
+		t.Errorf("env MEOW %q and PURR %q, want locked meow and unset", cfg.Env["MEOW"], v)
+	}
+
+	problems, err := Validate(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, line := range []int{2, 6, 7} {
+		if i >= len(problems) || problems[i].Line != line || !errors.Is(problems[i].Err, ErrLockedKey) {
+			t.Errorf("got problems %v, want locked keys at lines 2, 6 and 7", problems)
+			break
+		}
+	}
+}
+
+func TestLockOverrides(t *testing.T) {
+	dir := t.TempDir()
+	defer func(p string) { SystemPath = p }(SystemPath)
+	SystemPath = filepath.Join(dir, "system.toml")
+
+	sys := "locked = [\"player.env.MEOW\", \"player.wineroot\"]\n\n[player.env]\nMEOW = \"meow\"\n"
+	if err := os.WriteFile(SystemPath, []byte(sys), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	name := filepath.Join(dir, "config.toml")
+	data := "[player.profiles.alt]\nwineroot = \"/meow\"\n\n" +
+		"[player.games.1818.env]\nMEOW = \"hiss\"\nPURR = \"mrrp\"\n"
+	if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b := cfg.Player.ForProfile("alt"); b.WineRoot != "" {
+		t.Errorf("profile wineroot %s, want locked", b.WineRoot)
+	}
+
+	b, err := cfg.Player.ForGame("1818")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Env["MEOW"] != "meow" || b.Env["PURR"] != "mrrp" {
+		t.Errorf("game env %v, want locked MEOW and unlocked PURR", b.Env)
+	}
+
+	problems, err := Validate(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, line := range []int{2, 5} {
+		if i >= len(problems) || problems[i].Line != line || !errors.Is(problems[i].Err, ErrLockedKey) {
+			t.Errorf("got problems %v, want locked keys at lines 2 and 5", problems)
+			break
+		}
+	}
+}
+
+func TestLoadSystemOnly(t *testing.T) {
+	dir := t.TempDir()
+	defer func(p string) { SystemPath = p }(SystemPath)
+	SystemPath = filepath.Join(dir, "system.toml")
+	name := filepath.Join(dir, "config.toml")
+
+	sys := "locked = [\"player.renderer\"]\n\n[player]\nrenderer = \"Vulkan\"\ndxvk = false\n"
+	if err := os.WriteFile(SystemPath, []byte(sys), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("VINEGAR_PLAYER_RENDERER", "D3D11")
+	cfg, err := Load(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Player.Renderer != "Vulkan" || len(cfg.Player.FFlags) == 0 {
+		t.Errorf("renderer %s with fflags %v, want locked Vulkan set up",
+			cfg.Player.Renderer, cfg.Player.FFlags)
+	}
+
+	if err := os.WriteFile(SystemPath, []byte("keep_versions = 0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(name); !errors.Is(err, ErrBadKeepVersions) {
+		t.Errorf("got %v, want system configuration verified", err)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	c := Default()
+	c.OBS.Password = "meow"
+	c.Env = Environment{"GITHUB_TOKEN": "purr", "MEOW": "hiss"}
+	c.Player.Games = map[string]Game{"1818": {Env: Environment{"Api_Key": "mrrp"}}}
+
+	r := c.Redact()
+
+	if r.OBS.Password != Redacted {
+		t.Errorf("obs password is %q, want redacted", r.OBS.Password)
+	}
+	if r.Env["GITHUB_TOKEN"] != Redacted || r.Env["MEOW"] != "hiss" {
+		t.Errorf("env is %v, want only GITHUB_TOKEN redacted", r.Env)
+	}
+	if v := r.Player.Games["1818"].Env["Api_Key"]; v != Redacted {
+		t.Errorf("game env Api_Key is %q, want redacted", v)
+	}
+
+	if c.Env["GITHUB_TOKEN"] != "purr" || c.Player.Games["1818"].Env["Api_Key"] != "mrrp" {
+		t.Error("redact modified the original configuration")
+	}
+}
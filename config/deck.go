@@ -0,0 +1,67 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/BurntSushi/toml"
+	"github.com/vinegarhq/vinegar/sysinfo"
+)
+
+// DeckFPS is the power-friendly frame rate cap used by the Deck preset,
+// matching the Steam Deck's display refresh rate.
+const DeckFPS = 60
+
+var ErrBadDeck = errors.New("deck must be auto, on or off")
+
+// Deck determines if the Deck preset should be applied, which is
+// automatically done on a Steam Deck, SteamOS or within Gaming Mode.
+func (c *Config) Deck() bool {
+	switch c.SteamDeck {
+	case "on":
+		return true
+	case "off":
+		return false
+	}
+
+	return sysinfo.SteamDeck || sysinfo.DistroID == "steamos" || sysinfo.GamingMode
+}
+
+// applyDeck applies the Deck preset to the configuration, leaving the
+// options set in the configuration by the user, according to the given
+// metadata, as-is.
+func (c *Config) applyDeck(md *toml.MetaData) error {
+	switch c.SteamDeck {
+	case "", "auto", "on", "off":
+	default:
+		return fmt.Errorf("%w: %s", ErrBadDeck, c.SteamDeck)
+	}
+
+	if !c.Deck() {
+		return nil
+	}
+
+	defined := func(key ...string) bool {
+		return md != nil && md.IsDefined(key...)
+	}
+
+	slog.Info("Applying Steam Deck preset", "gaming_mode", sysinfo.GamingMode)
+
+	if !defined("player", "fps") {
+		c.Player.FPS = DeckFPS
+	}
+
+	// Roblox handles fullscreen by itself, which misbehaves under gamescope
+	if _, ok := c.Player.FFlags["FFlagHandleAltEnterFullscreenManually"]; !ok {
+		c.Player.FFlags["FFlagHandleAltEnterFullscreenManually"] = false
+	}
+
+	// The splash window is shown as Gaming Mode's focused window
+	// instead of Roblox, and cannot be interacted with
+	if sysinfo.GamingMode && !defined("splash", "enabled") {
+		c.Splash.Enabled = false
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/vinegarhq/vinegar/sysinfo"
+)
+
+var (
+	ErrBadEmulator = errors.New("unknown emulator")
+	ErrNoEmulator  = errors.New("no x86_64 emulator found")
+)
+
+type emulator struct {
+	exe    string // executable used to run x86_64 programs
+	binfmt string // binfmt_misc entry which runs x86_64 programs transparently
+}
+
+// emulators is an ordered list of the supported emulators, in which
+// they are looked for when the emulator is set to auto.
+var emulators = []struct {
+	name string
+	emulator
+}{
+	{"fex", emulator{"FEXInterpreter", "FEX-x86_64"}},
+	{"box64", emulator{"box64", "box64"}},
+}
+
+// Emulated determines if the host is not x86_64, and Wine requires
+// an x86_64 emulator to run.
+func Emulated() bool {
+	return sysinfo.Arch != "" && sysinfo.Arch != "x86_64"
+}
+
+func registered(binfmt string) bool {
+	_, err := os.Stat(filepath.Join("/proc/sys/fs/binfmt_misc", binfmt))
+	return err == nil
+}
+
+// EmulatorPath returns the path to the emulator to run Wine with. An
+// empty path is returned if Wine can be ran directly, either because
+// the host is x86_64 or an emulator is registered with binfmt_misc.
+func (c *Config) EmulatorPath() (string, error) {
+	if c.Emulator == "none" || c.Emulator == "" || !Emulated() {
+		return "", nil
+	}
+
+	for _, e := range emulators {
+		if c.Emulator != "auto" && c.Emulator != e.name {
+			continue
+		}
+
+		if registered(e.binfmt) {
+			return "", nil
+		}
+
+		p, err := exec.LookPath(e.exe)
+		if err == nil {
+			return p, nil
+		}
+
+		if c.Emulator == e.name {
+			return "", fmt.Errorf("%s: %w", e.name, err)
+		}
+	}
+
+	return "", ErrNoEmulator
+}
+
+func (c *Config) validateEmulator() error {
+	switch c.Emulator {
+	case "", "auto", "none":
+	default:
+		found := false
+		for _, e := range emulators {
+			found = found || e.name == c.Emulator
+		}
+		if !found {
+			return fmt.Errorf("%w: %s", ErrBadEmulator, c.Emulator)
+		}
+	}
+
+	if c.EmulatorRootFS != "" && !filepath.IsAbs(c.EmulatorRootFS) {
+		return fmt.Errorf("emulator rootfs %s is not an absolute path", c.EmulatorRootFS)
+	}
+
+	return nil
+}
+
+// setupEmulator warns about the capabilities of emulated hosts, and
+// sets up the emulator's configuration.
+func (c *Config) setupEmulator() error {
+	if err := c.validateEmulator(); err != nil {
+		return err
+	}
+
+	if !Emulated() {
+		return nil
+	}
+
+	slog.Warn("Host is not x86_64, Wine and Roblox will be emulated! Expect degraded performance and compatibility.",
+		"arch", sysinfo.Arch)
+
+	if c.Emulator == "none" {
+		slog.Warn("Emulator is disabled, Wine will most likely fail to run!")
+		return nil
+	}
+
+	p, err := c.EmulatorPath()
+	if err != nil {
+		slog.Warn("Wine will most likely fail to run! Install FEX-Emu or box64.", "error", err)
+		return nil
+	}
+
+	if p != "" {
+		slog.Info("Using emulator", "path", p)
+	}
+
+	// FEX-Emu requires an x86_64 rootfs to look for the libraries
+	// required by Wine.
+	if c.EmulatorRootFS != "" {
+		c.Env.Set("FEX_ROOTFS", c.EmulatorRootFS)
+	}
+
+	return nil
+}
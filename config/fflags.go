@@ -0,0 +1,143 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/roblox"
+)
+
+var ErrNoFFlagProfile = errors.New("fflag profile not found")
+
+// FFlagPresets holds the FFlag profiles shipped with Vinegar.
+var FFlagPresets = map[string]roblox.FFlags{
+	"low-latency": {
+		"FFlagTaskSchedulerLimitTargetFpsTo2402": false,
+		"FFlagHandleAltEnterFullscreenManually":  false,
+	},
+	"potato-graphics": {
+		"DFFlagDebugRenderForceTechnologyVoxel": true,
+		"FFlagDisablePostFx":                    true,
+		"FIntDebugForceMSAASamples":             0,
+		"DFFlagTextureQualityOverrideEnabled":   true,
+		"DFIntTextureQualityOverride":           0,
+		"FIntRenderShadowIntensity":             0,
+		"FIntFRMMinGrassDistance":               0,
+		"FIntFRMMaxGrassDistance":               0,
+	},
+	"high-fidelity": {
+		"FFlagDebugForceFutureIsBrightPhase3": true,
+		"FIntDebugForceMSAASamples":           4,
+		"DFFlagTextureQualityOverrideEnabled": true,
+		"DFIntTextureQualityOverride":         3,
+	},
+}
+
+func fflagProfilePath(name string) string {
+	return filepath.Join(dirs.FFlags, name+".json")
+}
+
+// FFlagProfile returns the named FFlag profile. User-defined profiles,
+// which are JSON files within [dirs.FFlags], take precedence over the
+// presets in [FFlagPresets].
+func FFlagProfile(name string) (roblox.FFlags, error) {
+	f, err := os.ReadFile(fflagProfilePath(name))
+	if errors.Is(err, os.ErrNotExist) {
+		if p, ok := FFlagPresets[name]; ok {
+			return p, nil
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrNoFFlagProfile, name)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var p roblox.FFlags
+	if err := json.Unmarshal(f, &p); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", name, err)
+	}
+
+	return p, nil
+}
+
+// FFlagProfiles returns the names of all available FFlag profiles.
+func FFlagProfiles() ([]string, error) {
+	names := make([]string, 0, len(FFlagPresets))
+	for name := range FFlagPresets {
+		names = append(names, name)
+	}
+
+	entries, err := os.ReadDir(dirs.FFlags)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+
+		if _, preset := FFlagPresets[name]; !preset {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// ImportFFlagProfile imports the named JSON FFlags file as a
+// user-defined FFlag profile, named after the file, and returns
+// the profile's name.
+func ImportFFlagProfile(path string) (string, error) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return name, ImportFFlagProfileAs(path, name)
+}
+
+// ImportFFlagProfileAs is like [ImportFFlagProfile], importing the
+// profile with the given name instead.
+func ImportFFlagProfileAs(path, name string) error {
+	f, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var p roblox.FFlags
+	if err := json.Unmarshal(f, &p); err != nil {
+		return fmt.Errorf("invalid fflags: %w", err)
+	}
+
+	if err := dirs.Mkdirs(dirs.FFlags); err != nil {
+		return err
+	}
+
+	return os.WriteFile(fflagProfilePath(name), f, 0o644)
+}
+
+// setupFFlagProfile applies the Binary's FFlag profile to its FFlags,
+// where the Binary's own FFlags take precedence.
+func (b *Binary) setupFFlagProfile() error {
+	if b.FFlagProfile == "" {
+		return nil
+	}
+
+	p, err := FFlagProfile(b.FFlagProfile)
+	if err != nil {
+		return err
+	}
+
+	for name, v := range p {
+		if _, ok := b.FFlags[name]; !ok {
+			b.FFlags[name] = v
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/vinegarhq/vinegar/roblox"
+)
+
+// setupFPS limits the Binary's frame rate to FPS, with the FPS FFlag
+// and if set, the frame limiter. An explicitly set FPS FFlag takes
+// precedence over FPS.
+func (b *Binary) setupFPS() {
+	if b.FPS <= 0 {
+		return
+	}
+
+	if _, ok := b.FFlags[roblox.FPSFlag]; !ok {
+		b.FFlags.SetFPS(b.FPS)
+	}
+
+	fps := strconv.Itoa(b.FPS)
+
+	switch b.FrameLimiter {
+	case "dxvk":
+		b.Env.Set("DXVK_FRAME_RATE", fps)
+	case "mangohud":
+		b.Env.Set("MANGOHUD", "1")
+		b.Env.Set("MANGOHUD_CONFIG", "fps_limit="+fps)
+	}
+}
+
+// migrate migrates configuration keys and values used by older
+// versions of Vinegar, which are present in the given metadata.
+func (c *Config) migrate(md *toml.MetaData) {
+	for _, k := range md.Undecoded() {
+		name := strings.ToLower(k[len(k)-1])
+
+		if name == "autorfpsu" || strings.Contains(name, "fpsunlocker") {
+			slog.Warn("rbxfpsunlocker is no longer supported, configure the fps option instead!",
+				"key", k.String())
+		}
+	}
+
+	for _, b := range []*Binary{&c.Player, &c.Studio} {
+		if strings.Contains(strings.ToLower(b.Launcher), "rbxfpsunlocker") {
+			slog.Warn("rbxfpsunlocker is no longer supported, removing it from the launcher!",
+				"launcher", b.Launcher)
+			b.Launcher = ""
+		}
+	}
+}
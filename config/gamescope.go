@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"os/exec"
+	"strconv"
+)
+
+var ErrBadGamescope = errors.New("gamescope width, height and refresh must not be negative")
+
+// Gamescope is a representation of the gamescope integration configuration,
+// which runs Roblox within a nested gamescope session.
+type Gamescope struct {
+	Enabled    bool `toml:"enabled"`
+	Width      int  `toml:"width"`
+	Height     int  `toml:"height"`
+	Refresh    int  `toml:"refresh"`
+	Fullscreen bool `toml:"fullscreen"`
+}
+
+// Args returns the gamescope command to run a command within, the command
+// is appended after it.
+func (g *Gamescope) Args() []string {
+	args := []string{"gamescope"}
+
+	// Roblox is rendered at the same resolution as the gamescope window.
+	if g.Width > 0 {
+		w := strconv.Itoa(g.Width)
+		args = append(args, "-W", w, "-w", w)
+	}
+	if g.Height > 0 {
+		h := strconv.Itoa(g.Height)
+		args = append(args, "-H", h, "-h", h)
+	}
+
+	if g.Refresh > 0 {
+		args = append(args, "-r", strconv.Itoa(g.Refresh))
+	}
+	if g.Fullscreen {
+		args = append(args, "-f")
+	}
+
+	return append(args, "--")
+}
+
+func (g *Gamescope) validate() error {
+	if !g.Enabled {
+		return nil
+	}
+
+	if g.Width < 0 || g.Height < 0 || g.Refresh < 0 {
+		return ErrBadGamescope
+	}
+
+	_, err := exec.LookPath("gamescope")
+	return err
+}
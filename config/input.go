@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+
+	"github.com/vinegarhq/vinegar/sysinfo"
+)
+
+// InputMethodName returns the name of the X input method server used by
+// Wine for text entry, such as with CJK input, and an empty name if no
+// input method is used.
+func (c *Config) InputMethodName() string {
+	switch c.InputMethod {
+	case "off", "":
+		return ""
+	case "auto":
+		return sysinfo.InputMethod
+	}
+
+	return c.InputMethod
+}
+
+// setupInputMethod sets the X input method server used by Wine, unless
+// it was already set in the environment.
+func (c *Config) setupInputMethod() {
+	im := c.InputMethodName()
+	if im == "" || os.Getenv("XMODIFIERS") != "" {
+		return
+	}
+
+	c.Env.Set("XMODIFIERS", "@im="+im)
+}
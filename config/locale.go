@@ -0,0 +1,107 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/vinegarhq/vinegar/sysinfo"
+)
+
+var ErrBadKeyboardLayout = errors.New("unknown keyboard layout")
+
+// keyboardLayouts maps XKB keyboard layouts to their Windows keyboard
+// layout identifier (KLID).
+var keyboardLayouts = map[string]string{
+	"us":    "00000409",
+	"gb":    "00000809",
+	"ie":    "00001809",
+	"ca":    "00001009",
+	"de":    "00000407",
+	"at":    "00000c07",
+	"ch":    "00000807",
+	"fr":    "0000040c",
+	"be":    "0000080c",
+	"es":    "0000040a",
+	"latam": "0000080a",
+	"pt":    "00000816",
+	"br":    "00010416",
+	"it":    "00000410",
+	"nl":    "00000413",
+	"dk":    "00000406",
+	"no":    "00000414",
+	"se":    "0000041d",
+	"fi":    "0000040b",
+	"is":    "0000040f",
+	"pl":    "00000415",
+	"cz":    "00000405",
+	"sk":    "0000041b",
+	"hu":    "0000040e",
+	"ro":    "00010418",
+	"hr":    "0000041a",
+	"si":    "00000424",
+	"lt":    "00010427",
+	"lv":    "00000426",
+	"ee":    "00000425",
+	"gr":    "00000408",
+	"tr":    "0000041f",
+	"ru":    "00000419",
+	"ua":    "00000422",
+	"by":    "00000423",
+	"bg":    "00000402",
+	"il":    "0000040d",
+	"jp":    "00000411",
+	"kr":    "00000412",
+	"cn":    "00000804",
+	"tw":    "00000404",
+}
+
+// LocaleName returns the locale used by Wine, and an empty name to
+// leave it to the environment.
+func (c *Config) LocaleName() string {
+	switch c.Locale {
+	case "off", "":
+		return ""
+	case "auto":
+		return sysinfo.Locale
+	}
+
+	return c.Locale
+}
+
+// KeyboardLayoutID returns the Windows keyboard layout identifier (KLID)
+// of the keyboard layout to be used by Roblox, and an empty identifier
+// if it should not be set, or if the host keyboard layout is unknown.
+//
+// The keyboard layout may either be an XKB layout or a KLID.
+func (c *Config) KeyboardLayoutID() (string, error) {
+	switch c.KeyboardLayout {
+	case "off", "":
+		return "", nil
+	case "auto":
+		return keyboardLayouts[sysinfo.KeyboardLayout], nil
+	}
+
+	if klid, ok := keyboardLayouts[c.KeyboardLayout]; ok {
+		return klid, nil
+	}
+
+	if _, err := strconv.ParseUint(c.KeyboardLayout, 16, 32); err == nil && len(c.KeyboardLayout) == 8 {
+		return c.KeyboardLayout, nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrBadKeyboardLayout, c.KeyboardLayout)
+}
+
+// setupLocale sets the locale used by Wine, in which all of the locale
+// categories are set to make Wine's Windows locale consistent, such as
+// with the decimal separator.
+func (c *Config) setupLocale() {
+	l := c.LocaleName()
+	if l == "" || os.Getenv("LC_ALL") == l {
+		return
+	}
+
+	c.Env.Set("LC_ALL", l)
+}
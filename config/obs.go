@@ -0,0 +1,31 @@
+package config
+
+import "errors"
+
+var ErrNeedOBSAddress = errors.New("obs websocket address is required")
+
+// OBS is a representation of the OBS Studio integration configuration,
+// which controls OBS through OBS WebSocket when joining and leaving
+// Roblox games.
+type OBS struct {
+	Enabled  bool   `toml:"enabled"`
+	Address  string `toml:"address"`
+	Password string `toml:"password"`
+
+	// Scene is switched to when joining a game, and LeaveScene
+	// when leaving the game.
+	Scene      string `toml:"scene"`
+	LeaveScene string `toml:"leave_scene"`
+
+	// Record starts recording when joining a game, and stops
+	// recording when leaving the game.
+	Record bool `toml:"record"`
+}
+
+func (o *OBS) validate() error {
+	if o.Enabled && o.Address == "" {
+		return ErrNeedOBSAddress
+	}
+
+	return nil
+}
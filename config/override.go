@@ -0,0 +1,164 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding the
+// configuration's keys, named after the key's table and name, such as
+// VINEGAR_PLAYER_RENDERER for the renderer key of the player table.
+const EnvPrefix = "VINEGAR_"
+
+var ErrBadOverride = errors.New("unsupported key type")
+
+// applyEnv overrides the configuration's keys with the values of the given
+// environment variables, in the form of "key=value". Variables which don't
+// name a configuration key are ignored, as they may be used elsewhere.
+//
+// Keys of tables such as env and fflags are appended to the table's name,
+// as-is: VINEGAR_PLAYER_FFLAGS_FFlagDebugGraphicsPreferVulkan=true.
+func (c *Config) applyEnv(environ []string) error {
+	for _, kv := range environ {
+		k, v, _ := strings.Cut(kv, "=")
+		name, ok := strings.CutPrefix(k, EnvPrefix)
+		if !ok {
+			continue
+		}
+
+		found, err := override(reflect.ValueOf(c).Elem(), name, v)
+		if err != nil {
+			return fmt.Errorf("override %s: %w", k, err)
+		}
+
+		if found {
+			slog.Info("Overriding configuration key", "env", k, "value", v)
+		}
+	}
+
+	return nil
+}
+
+// override sets the key with the given name within the given struct to
+// the value, returning if the key was found.
+func override(st reflect.Value, name, value string) (bool, error) {
+	t := st.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("toml"), ",")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		f := st.Field(i)
+
+		// Tables are only overridden by their keys, leaving variables
+		// named after tables to be used elsewhere.
+		if strings.EqualFold(name, tag) {
+			if f.Kind() == reflect.Struct || f.Kind() == reflect.Map {
+				continue
+			}
+			return true, set(f, value)
+		}
+
+		if len(name) <= len(tag)+1 || !strings.EqualFold(name[:len(tag)+1], tag+"_") {
+			continue
+		}
+		key := name[len(tag)+1:]
+
+		switch {
+		case f.Kind() == reflect.Struct:
+			if ok, err := override(f, key, value); ok || err != nil {
+				return ok, err
+			}
+		case f.Kind() == reflect.Map && f.Type().Key().Kind() == reflect.String:
+			elem := reflect.New(f.Type().Elem()).Elem()
+			if err := set(elem, value); err != nil {
+				// Tables of other tables, such as games, are unsupported.
+				if errors.Is(err, ErrBadOverride) {
+					continue
+				}
+				return true, err
+			}
+
+			if f.IsNil() {
+				f.Set(reflect.MakeMap(f.Type()))
+			}
+			f.SetMapIndex(reflect.ValueOf(key).Convert(f.Type().Key()), elem)
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// set sets v to the given value parsed as v's type.
+func set(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		p := reflect.New(v.Type().Elem())
+		if err := set(p.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(p)
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			v.SetInt(int64(d))
+			return nil
+		}
+
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return ErrBadOverride
+		}
+
+		s := reflect.MakeSlice(v.Type(), 0, 0)
+		for _, e := range strings.Split(value, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				s = reflect.Append(s, reflect.ValueOf(e).Convert(v.Type().Elem()))
+			}
+		}
+		v.Set(s)
+	case reflect.Interface:
+		// Such as FFlags, where the value's type is unknown.
+		var i any = value
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			i = n
+		} else if b, err := strconv.ParseBool(value); err == nil {
+			i = b
+		}
+		v.Set(reflect.ValueOf(i))
+	default:
+		return ErrBadOverride
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+var (
+	ErrBadPassthroughDir   = errors.New("passthrough directory must be an absolute path")
+	ErrBadPassthroughDrive = errors.New("passthrough drive must be a drive letter from d to y")
+	ErrBadPassthroughLink  = errors.New("passthrough link must be a path within the wineprefix's user directory")
+	ErrPassthroughStudio   = errors.New("passthrough is only supported by studio")
+)
+
+// Passthrough is a representation of the host directories passed through
+// to Studio within its wineprefix, for native tooling such as Rojo to
+// exchange plugins and project files with Studio.
+type Passthrough struct {
+	// Plugins is the host directory used as Studio's local plugins
+	// directory, within the user's local AppData.
+	Plugins string `toml:"plugins"`
+
+	// Drives are the host directories mapped as Wine drives, keyed
+	// by their drive letter.
+	Drives map[string]string `toml:"drives"`
+
+	// Links are the host directories linked within the wineprefix's user
+	// directory, keyed by their path within it, such as "Documents/Roblox".
+	Links map[string]string `toml:"links"`
+}
+
+// Enabled determines if any host directories are passed through.
+func (pt *Passthrough) Enabled() bool {
+	return pt.Plugins != "" || len(pt.Drives) > 0 || len(pt.Links) > 0
+}
+
+// Dirs returns the host directories passed through, sorted.
+func (pt *Passthrough) Dirs() []string {
+	var dirs []string
+	if pt.Plugins != "" {
+		dirs = append(dirs, pt.Plugins)
+	}
+	for _, d := range pt.Drives {
+		dirs = append(dirs, d)
+	}
+	for _, d := range pt.Links {
+		dirs = append(dirs, d)
+	}
+
+	sort.Strings(dirs)
+	return dirs
+}
+
+// ValidDrive determines if the given drive letter may be mapped to a host
+// directory, which C: and Z: may not, as they are Wine's own drives.
+func ValidDrive(letter string) bool {
+	return len(letter) == 1 && letter[0] >= 'd' && letter[0] <= 'y'
+}
+
+func (pt *Passthrough) validate() error {
+	for _, d := range pt.Dirs() {
+		if !filepath.IsAbs(d) {
+			return fmt.Errorf("%w: %s", ErrBadPassthroughDir, d)
+		}
+	}
+
+	for letter := range pt.Drives {
+		if !ValidDrive(letter) {
+			return fmt.Errorf("%w: %s", ErrBadPassthroughDrive, letter)
+		}
+	}
+
+	for rel := range pt.Links {
+		if !filepath.IsLocal(rel) {
+			return fmt.Errorf("%w: %s", ErrBadPassthroughLink, rel)
+		}
+	}
+
+	return nil
+}
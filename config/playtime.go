@@ -0,0 +1,103 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	ErrBadPlaytimeLimit = errors.New("playtime daily limit and warning must not be negative")
+	ErrBadPlaytimeHours = errors.New("playtime allowed hours must be in the form of HH:MM-HH:MM")
+	ErrPlaytimeLimit    = errors.New("daily playtime limit was reached")
+	ErrPlaytimeHours    = errors.New("playtime is outside of the allowed hours")
+)
+
+// Playtime is a representation of the parental controls restricting the
+// time Roblox may be played each day, and the hours it may be played in,
+// which may span over midnight. Warning is the time before Roblox is stopped
+// at which it warns of it.
+//
+// These are meant to be locked by the system configuration.
+type Playtime struct {
+	DailyLimit   time.Duration `toml:"daily_limit"`
+	AllowedHours string        `toml:"allowed_hours"`
+	Warning      time.Duration `toml:"warning"`
+}
+
+// Enabled determines if playtime is restricted.
+func (p *Playtime) Enabled() bool {
+	return p.DailyLimit > 0 || p.AllowedHours != ""
+}
+
+// Remaining returns the time left for Roblox to be played at now, with
+// played being the time already played on now's day. ErrPlaytimeLimit or
+// ErrPlaytimeHours is returned if there is none left, and the maximum
+// duration if playtime is unrestricted.
+func (p *Playtime) Remaining(now time.Time, played time.Duration) (time.Duration, error) {
+	left := time.Duration(math.MaxInt64)
+
+	if p.DailyLimit > 0 {
+		left = p.DailyLimit - played
+		if left <= 0 {
+			return 0, ErrPlaytimeLimit
+		}
+	}
+
+	if p.AllowedHours != "" {
+		start, end, err := p.hours()
+		if err != nil {
+			return 0, err
+		}
+
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		since := now.Sub(midnight)
+
+		var until time.Duration
+		switch {
+		case start <= end && since >= start && since < end:
+			until = end - since
+		case start > end && since >= start:
+			until = 24*time.Hour - since + end
+		case start > end && since < end:
+			until = end - since
+		default:
+			return 0, ErrPlaytimeHours
+		}
+
+		left = min(left, until)
+	}
+
+	return left, nil
+}
+
+// hours returns the start and end of the allowed hours, since midnight.
+func (p *Playtime) hours() (start, end time.Duration, err error) {
+	var sh, sm, eh, em int
+	if _, err := fmt.Sscanf(p.AllowedHours, "%d:%d-%d:%d", &sh, &sm, &eh, &em); err != nil ||
+		sh < 0 || sh > 24 || eh < 0 || eh > 24 || sm < 0 || sm > 59 || em < 0 || em > 59 {
+		return 0, 0, fmt.Errorf("%w: %s", ErrBadPlaytimeHours, p.AllowedHours)
+	}
+
+	start = time.Duration(sh)*time.Hour + time.Duration(sm)*time.Minute
+	end = time.Duration(eh)*time.Hour + time.Duration(em)*time.Minute
+	if start == end || start > 24*time.Hour || end > 24*time.Hour {
+		return 0, 0, fmt.Errorf("%w: %s", ErrBadPlaytimeHours, p.AllowedHours)
+	}
+
+	return start, end, nil
+}
+
+func (p *Playtime) validate() error {
+	if p.DailyLimit < 0 || p.Warning < 0 {
+		return ErrBadPlaytimeLimit
+	}
+
+	if p.AllowedHours == "" {
+		return nil
+	}
+
+	_, _, err := p.hours()
+	return err
+}
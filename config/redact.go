@@ -0,0 +1,70 @@
+package config
+
+import (
+	"maps"
+	"strings"
+)
+
+// Redacted replaces the value of a redacted key.
+const Redacted = "[redacted]"
+
+// secretEnv are the words within the names of environment variables
+// which are assumed to hold secrets.
+var secretEnv = []string{"TOKEN", "SECRET", "PASSWORD", "PASSWD", "COOKIE", "KEY", "AUTH"}
+
+// Redact returns a copy of the configuration with its secrets replaced
+// with [Redacted], such as the OBS WebSocket password and environment
+// variables named after secrets, to be shared in bug reports.
+func (c Config) Redact() Config {
+	if c.OBS.Password != "" {
+		c.OBS.Password = Redacted
+	}
+
+	c.Env = c.Env.redact()
+	c.Player = c.Player.redact()
+	c.Studio = c.Studio.redact()
+
+	return c
+}
+
+func (b Binary) redact() Binary {
+	b.Env = b.Env.redact()
+
+	// The tables are copied, as they are shared with the original.
+	b.Games = maps.Clone(b.Games)
+	for id, g := range b.Games {
+		g.Env = g.Env.redact()
+		b.Games[id] = g
+	}
+
+	b.Profiles = maps.Clone(b.Profiles)
+	for name, p := range b.Profiles {
+		p.Env = p.Env.redact()
+		b.Profiles[name] = p
+	}
+
+	return b
+}
+
+func (e Environment) redact() Environment {
+	r := maps.Clone(e)
+	for name := range r {
+		if SecretEnv(name) {
+			r[name] = Redacted
+		}
+	}
+
+	return r
+}
+
+// SecretEnv determines if the named environment variable is assumed
+// to hold a secret.
+func SecretEnv(name string) bool {
+	for _, s := range secretEnv {
+		if strings.Contains(strings.ToUpper(name), s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/retry"
+)
+
+var ErrBadRetry = errors.New("retry attempts must be at least 1, with delay no longer than max_delay")
+
+// Retry is a representation of the retry policy of the network requests
+// made during setup, such as of fetching the deployment and its package
+// manifest, and downloading its packages. The delay before each retry is
+// doubled up to MaxDelay.
+type Retry struct {
+	Attempts int           `toml:"attempts"`
+	Delay    time.Duration `toml:"delay"`
+	MaxDelay time.Duration `toml:"max_delay"`
+}
+
+func (r *Retry) validate() error {
+	if r.Attempts < 1 || r.Delay < 0 || r.MaxDelay < r.Delay {
+		return ErrBadRetry
+	}
+
+	return nil
+}
+
+// apply sets the given retry policy's attempts and delays to the Retry's.
+func (r *Retry) apply(p *retry.Policy) {
+	p.Attempts = r.Attempts
+	p.Delay = r.Delay
+	p.MaxDelay = r.MaxDelay
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+)
+
+var (
+	ErrBadScopeMemory = errors.New("scope memory max must be a size, a percentage or infinity")
+	ErrBadScopeWeight = errors.New("scope cpu weight must be between 1 and 10000")
+)
+
+var scopeMemoryPattern = regexp.MustCompile(`^([0-9]+[KMGT]?|[0-9]+(\.[0-9]+)?%|infinity)$`)
+
+// Scope is a representation of the systemd scope configuration, which
+// runs Roblox within a transient systemd user scope with the given
+// resource limits, whose processes are all stopped with it.
+type Scope struct {
+	Enabled   bool   `toml:"enabled"`
+	MemoryMax string `toml:"memory_max"`
+	CPUWeight int    `toml:"cpu_weight"`
+}
+
+// Args returns the systemd-run command to run a command within a scope
+// with the given unit name, the command is appended after it.
+func (s *Scope) Args(unit string) []string {
+	args := []string{"systemd-run", "--user", "--scope", "--collect", "--quiet",
+		"--unit=" + unit}
+
+	if s.MemoryMax != "" {
+		args = append(args, "--property=MemoryMax="+s.MemoryMax)
+	}
+	if s.CPUWeight > 0 {
+		args = append(args, "--property=CPUWeight="+strconv.Itoa(s.CPUWeight))
+	}
+
+	return append(args, "--")
+}
+
+func (s *Scope) validate() error {
+	if !s.Enabled {
+		return nil
+	}
+
+	if s.MemoryMax != "" && !scopeMemoryPattern.MatchString(s.MemoryMax) {
+		return fmt.Errorf("%w: %s", ErrBadScopeMemory, s.MemoryMax)
+	}
+
+	if s.CPUWeight < 0 || s.CPUWeight > 10000 {
+		return fmt.Errorf("%w: %d", ErrBadScopeWeight, s.CPUWeight)
+	}
+
+	_, err := exec.LookPath("systemd-run")
+	return err
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ScreenSize returns the width and height of the Binary's resolution.
+func (b *Binary) ScreenSize() (int, int, error) {
+	ws, hs, ok := strings.Cut(b.Resolution, "x")
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %s", ErrBadResolution, b.Resolution)
+	}
+
+	w, werr := strconv.Atoi(ws)
+	h, herr := strconv.Atoi(hs)
+	if werr != nil || herr != nil || w <= 0 || h <= 0 {
+		return 0, 0, fmt.Errorf("%w: %s", ErrBadResolution, b.Resolution)
+	}
+
+	return w, h, nil
+}
+
+func (b *Binary) validateSettings() error {
+	if b.GraphicsQuality != 0 && (b.GraphicsQuality < 1 || b.GraphicsQuality > 10) {
+		return fmt.Errorf("%w: %d", ErrBadQuality, b.GraphicsQuality)
+	}
+
+	if b.Volume != nil && (*b.Volume < 0 || *b.Volume > 100) {
+		return fmt.Errorf("%w: %d", ErrBadVolume, *b.Volume)
+	}
+
+	if b.Resolution != "" {
+		if _, _, err := b.ScreenSize(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
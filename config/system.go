@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// SystemPath is the path to the system configuration file, set by the
+// system's administrator, beneath which the user's configuration is
+// applied. Its locked keys cannot be changed by the user.
+var SystemPath = "/etc/vinegar/config.toml"
+
+var ErrLockedKey = errors.New("key is locked by the system configuration")
+
+// decodeSystem decodes the system configuration file onto the
+// configuration, if it exists.
+func (c *Config) decodeSystem() error {
+	if _, err := toml.DecodeFile(SystemPath, c); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("system config %s: %w", SystemPath, err)
+	}
+
+	return nil
+}
+
+// lock sets the keys locked by the system configuration to their value in
+// the system configuration, regardless of the user configuration and the
+// environment. md is the metadata of the user configuration, if any, used
+// to warn about the locked keys it sets.
+func (c *Config) lock(md *toml.MetaData) error {
+	sys := Default()
+	if err := sys.decodeSystem(); err != nil {
+		return err
+	}
+	c.Locked = sys.Locked
+
+	for _, key := range sys.Locked {
+		k := strings.Split(key, ".")
+
+		if md != nil && md.IsDefined(k...) {
+			slog.Warn("Ignoring locked configuration key", "key", key)
+		}
+
+		if !lockKey(reflect.ValueOf(c).Elem(), reflect.ValueOf(sys), k) {
+			return fmt.Errorf("lock %s: %w", key, ErrUnknownKey)
+		}
+	}
+
+	c.Player.unlockOverrides("player", sys.Locked)
+	c.Studio.unlockOverrides("studio", sys.Locked)
+
+	return nil
+}
+
+// unlockOverrides removes the keys of the Binary's games and profiles
+// which override the given locked keys of the Binary, named by the
+// given Binary key, as they would otherwise take precedence once applied.
+func (b *Binary) unlockOverrides(name string, locked []string) {
+	tables := map[string]reflect.Value{
+		"games":    reflect.ValueOf(b.Games),
+		"profiles": reflect.ValueOf(b.Profiles),
+	}
+
+	for table, m := range tables {
+		iter := m.MapRange()
+		for iter.Next() {
+			e := reflect.New(m.Type().Elem()).Elem()
+			e.Set(iter.Value())
+
+			if unlockKeys(e, []string{name, table, iter.Key().String()}, locked) {
+				m.SetMapIndex(iter.Key(), e)
+			}
+		}
+	}
+}
+
+// unlockKeys resets the keys within v, a game or profile named by the
+// given key, which override the given locked keys, and reports whether
+// any key was reset.
+func unlockKeys(v reflect.Value, key []string, locked []string) bool {
+	reset := false
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("toml"), ",")
+		f := v.Field(i)
+
+		if overridesLocked(append(key, tag), locked) {
+			if !f.IsZero() {
+				slog.Warn("Ignoring locked configuration key", "key", strings.Join(append(key, tag), "."))
+				f.Set(reflect.Zero(f.Type()))
+				reset = true
+			}
+			continue
+		}
+
+		if f.Kind() != reflect.Map || f.Type().Key().Kind() != reflect.String {
+			continue
+		}
+
+		for _, k := range f.MapKeys() {
+			fk := append(append(key, tag), k.String())
+			if overridesLocked(fk, locked) {
+				slog.Warn("Ignoring locked configuration key", "key", strings.Join(fk, "."))
+				f.SetMapIndex(k, reflect.Value{})
+				reset = true
+			}
+		}
+	}
+
+	return reset
+}
+
+// overridesLocked reports whether the given key of a Binary's game or
+// profile, such as player.games.1818.env.MEOW, overrides one of the
+// given locked keys of the Binary, such as player.env.MEOW or player.env.
+func overridesLocked(key []string, locked []string) bool {
+	if len(key) < 4 || (key[1] != "games" && key[1] != "profiles") {
+		return false
+	}
+
+	k := strings.Join(append([]string{key[0]}, key[3:]...), ".")
+	for _, l := range locked {
+		if k == l || strings.HasPrefix(k, l+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lockKey sets the key within dst to its value within src, which are of
+// the same type, and reports whether the key was found.
+func lockKey(dst, src reflect.Value, key []string) bool {
+	if len(key) == 0 {
+		dst.Set(src)
+		return true
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		t := dst.Type()
+		for i := 0; i < t.NumField(); i++ {
+			tag, _, _ := strings.Cut(t.Field(i).Tag.Get("toml"), ",")
+			if tag == key[0] {
+				return lockKey(dst.Field(i), src.Field(i), key[1:])
+			}
+		}
+	case reflect.Map:
+		if dst.Type().Key().Kind() != reflect.String {
+			return false
+		}
+		k := reflect.ValueOf(key[0]).Convert(dst.Type().Key())
+
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+
+		// Keys undefined by the system configuration are removed.
+		v := src.MapIndex(k)
+		if len(key) == 1 {
+			dst.SetMapIndex(k, v)
+			return true
+		}
+
+		// Keys within tables of tables, such as games.
+		e := reflect.New(dst.Type().Elem()).Elem()
+		if cur := dst.MapIndex(k); cur.IsValid() {
+			e.Set(cur)
+		}
+		if !v.IsValid() {
+			v = reflect.New(dst.Type().Elem()).Elem()
+		}
+		if !lockKey(e, v, key[1:]) {
+			return false
+		}
+		dst.SetMapIndex(k, e)
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/vinegarhq/vinegar/internal/mods"
+)
+
+// PostFXFlag is the FFlag used by Roblox to disable its post-processing
+// effects, such as bloom, blur, depth of field and sun rays.
+const PostFXFlag = "FFlagDisablePostFx"
+
+// setupTweaks applies the Binary's built-in appearance tweaks: OldCursor
+// applies the classic cursor preset before all other mods, for them to take
+// precedence, and DisablePostFX sets the post-processing FFlag, unless it
+// is explicitly set.
+func (b *Binary) setupTweaks() {
+	if b.OldCursor && !slices.Contains(b.Mods, mods.ClassicCursor) {
+		b.Mods = append([]string{mods.ClassicCursor}, b.Mods...)
+	}
+
+	if _, ok := b.FFlags[PostFXFlag]; b.DisablePostFX && !ok {
+		b.FFlags[PostFXFlag] = true
+	}
+}
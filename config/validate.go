@@ -0,0 +1,220 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/vinegarhq/vinegar/internal/mods"
+	"github.com/vinegarhq/vinegar/roblox"
+)
+
+var ErrUnknownKey = errors.New("unknown key")
+
+// Problem is an error found in a configuration file, at the line of the
+// key it was caused by, or 0 if it could not be attributed to a line.
+type Problem struct {
+	Line int
+	Err  error
+}
+
+func (p Problem) Error() string {
+	if p.Line == 0 {
+		return p.Err.Error()
+	}
+	return fmt.Sprintf("line %d: %s", p.Line, p.Err)
+}
+
+// keys are the keys which cause the errors returned by setup. Keys of
+// a Binary are relative to its table.
+var keys = map[error]string{
+	ErrNeedDXVKRenderer:       "renderer",
+	ErrNeedDXVK:               "frame_limiter",
+	ErrBadRunner:              "runner",
+	ErrBadCompat:              "compat",
+	ErrBadChannelPolicy:       "channel_policy",
+	ErrBadFrameLimiter:        "frame_limiter",
+	ErrNeedFPS:                "fps",
+	ErrBadUpdatePolicy:        "update_policy",
+	ErrBadUpdateCheck:         "update_check_interval",
+	ErrBadSecondLaunch:        "second_launch",
+	ErrBadShaderSeed:          "shader_cache_seed",
+	ErrBadRenice:              "renice",
+	ErrBadMemoryWatch:         "memory_watchdog",
+	ErrBadMemoryLimit:         "memory_limit",
+	ErrBadCPUAffinity:         "cpu_affinity",
+	ErrBadQuality:             "graphics_quality",
+	ErrBadVolume:              "volume",
+	ErrBadResolution:          "resolution",
+	ErrBadSandboxBind:         "sandbox_binds",
+	ErrBadPassthroughDir:      "passthrough",
+	ErrBadPassthroughDrive:    "passthrough.drives",
+	ErrBadPassthroughLink:     "passthrough.links",
+	ErrPassthroughStudio:      "passthrough",
+	ErrBadGamescope:           "gamescope",
+	ErrBadScopeMemory:         "scope.memory_max",
+	ErrBadScopeWeight:         "scope.cpu_weight",
+	ErrBadPlaytimeLimit:       "playtime.daily_limit",
+	ErrBadPlaytimeHours:       "playtime.allowed_hours",
+	ErrNoFFlagProfile:         "fflag_profile",
+	ErrOpenGLBlind:            "gpu",
+	ErrNoCardFound:            "gpu",
+	ErrBadGpuIndex:            "gpu",
+	roblox.ErrInvalidRenderer: "renderer",
+	mods.ErrNoMod:             "mods",
+	ErrBadRobloxLogs:          "roblox_logs",
+	ErrBadClipboard:           "clipboard",
+	ErrBadSplashBackend:       "splash.backend",
+	ErrBadSplashKind:          "splash.kind",
+	ErrBadInputStyle:          "input_style",
+	ErrBadKeepVersions:        "keep_versions",
+	ErrBadConcurrency:         "download_concurrency",
+	ErrBadMirror:              "mirror",
+	ErrBadKeyboardLayout:      "keyboard_layout",
+	ErrBadDeck:                "deck",
+	ErrBadEmulator:            "emulator",
+	ErrNoEmulator:             "emulator",
+	ErrNeedOBSAddress:         "obs.enabled",
+	ErrBadRetry:               "retry",
+}
+
+// Validate checks the named configuration file for errors, returning every
+// problem found: syntax and type errors, unknown keys, and the first
+// invalid value, as [Load] stops at the first one.
+func Validate(name string) ([]Problem, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if err := cfg.decodeSystem(); err != nil {
+		return []Problem{{Err: err}}, nil
+	}
+
+	md, err := toml.Decode(string(data), &cfg)
+	if err != nil {
+		p := Problem{Err: err}
+
+		var pe toml.ParseError
+		if errors.As(err, &pe) {
+			p.Line = pe.Position.Line
+			if pe.Message != "" {
+				p.Err = errors.New(pe.Message)
+			}
+		}
+
+		return []Problem{p}, nil
+	}
+
+	var problems []Problem
+	undecoded := make(map[string]bool)
+	for _, k := range md.Undecoded() {
+		undecoded[k.String()] = true
+
+		// Only the tables themselves are reported for unknown tables.
+		if len(k) > 1 && undecoded[k[:len(k)-1].String()] {
+			continue
+		}
+
+		problems = append(problems, Problem{
+			Line: keyLine(data, k.String()),
+			Err:  fmt.Errorf("%w: %s", ErrUnknownKey, k),
+		})
+	}
+
+	cfg.migrate(&md)
+
+	sys := Default()
+	if err := sys.decodeSystem(); err == nil {
+		for _, key := range sys.Locked {
+			if md.IsDefined(strings.Split(key, ".")...) {
+				problems = append(problems, Problem{
+					Line: keyLine(data, key),
+					Err:  fmt.Errorf("%w: %s", ErrLockedKey, key),
+				})
+			}
+		}
+
+		// Games and profiles cannot override the locked keys either.
+		for _, k := range md.Keys() {
+			if md.Type(k...) != "Hash" && overridesLocked(k, sys.Locked) {
+				problems = append(problems, Problem{
+					Line: keyLine(data, k.String()),
+					Err:  fmt.Errorf("%w: %s", ErrLockedKey, k),
+				})
+			}
+		}
+	}
+
+	if err := cfg.applyDeck(&md); err != nil {
+		problems = append(problems, Problem{Line: keyLine(data, "deck"), Err: err})
+	} else if err := cfg.applyEnv(os.Environ()); err != nil {
+		problems = append(problems, Problem{Err: err})
+	} else if err := cfg.lock(&md); err != nil {
+		problems = append(problems, Problem{Err: err})
+	} else if err := cfg.setup(); err != nil {
+		problems = append(problems, Problem{Line: errorLine(data, err), Err: err})
+	}
+
+	return problems, nil
+}
+
+// errorLine returns the line of the key which caused the given error
+// returned by setup.
+func errorLine(data []byte, err error) int {
+	for e, key := range keys {
+		if !errors.Is(err, e) {
+			continue
+		}
+
+		for _, table := range []string{"player", "studio"} {
+			if strings.HasPrefix(err.Error(), table+": ") {
+				return keyLine(data, table+"."+key)
+			}
+		}
+
+		return keyLine(data, key)
+	}
+
+	return 0
+}
+
+// keyLine returns the line at which the given dotted key or table is
+// defined in the TOML data, or 0 if it isn't found.
+func keyLine(data []byte, key string) int {
+	table := ""
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; s.Scan(); n++ {
+		l := strings.TrimSpace(s.Text())
+
+		if strings.HasPrefix(l, "[") {
+			l, _, _ = strings.Cut(l, "#")
+			table = strings.Trim(strings.TrimSpace(l), "[] ")
+			if table == key {
+				return n
+			}
+			continue
+		}
+
+		k, _, ok := strings.Cut(l, "=")
+		if !ok || strings.HasPrefix(l, "#") {
+			continue
+		}
+
+		k = strings.Trim(strings.TrimSpace(k), `"'`)
+		if table != "" {
+			k = table + "." + k
+		}
+
+		if k == key {
+			return n
+		}
+	}
+
+	return 0
+}
@@ -5,7 +5,6 @@ go 1.21
 require (
 	github.com/BurntSushi/toml v1.3.2
 	github.com/adrg/xdg v0.4.0
-	github.com/otiai10/copy v1.14.0
 	golang.org/x/sync v0.6.0
 )
 
@@ -15,9 +14,11 @@ require (
 	github.com/folbricht/pefile v0.1.0
 	github.com/fsnotify/fsnotify v1.7.0
 	github.com/godbus/dbus/v5 v5.1.0
+	github.com/gorilla/websocket v1.5.1
 	github.com/lmittmann/tint v1.0.4
 	github.com/nxadm/tail v1.4.11
 	github.com/samber/slog-multi v1.0.2
+	go.starlark.net v0.0.0-20240123142251-f86470692795
 	golang.org/x/sys v0.17.0
 	golang.org/x/term v0.17.0
 )
@@ -31,6 +32,7 @@ require (
 	golang.org/x/exp v0.0.0-20240213143201-ec583247a57a // indirect
 	golang.org/x/exp/shiny v0.0.0-20240213143201-ec583247a57a // indirect
 	golang.org/x/image v0.15.0 // indirect
+	golang.org/x/net v0.17.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 	gopkg.in/natefinch/npipe.v2 v2.0.0-20160621034901-c1b8fa8bdcce // indirect
 	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
@@ -0,0 +1,157 @@
+// Package bloxstrap implements reading a Bloxstrap installation, for its
+// FFlags, mods and settings to be migrated to Vinegar.
+package bloxstrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/internal/mods"
+)
+
+var ErrNotFound = errors.New("bloxstrap installation not found")
+
+// searchDirs are the directories a Bloxstrap installation is looked for
+// in, relative to a given directory, which may also be a Windows user's
+// home directory.
+var searchDirs = []string{".", "AppData/Local/Bloxstrap"}
+
+const (
+	// ModsDir is the directory of Bloxstrap's modifications, laid out
+	// like a Roblox version directory.
+	ModsDir = "Modifications"
+
+	// ClientSettings is the directory within ModsDir holding the FFlags
+	// set by Bloxstrap, which aren't modifications.
+	ClientSettings = "ClientSettings"
+)
+
+// Settings is a representation of the Bloxstrap settings which have a
+// Vinegar equivalent. Settings of older Bloxstrap versions are included.
+type Settings struct {
+	Channel                string
+	UseDiscordRichPresence *bool
+	UseOldMouseCursor      bool
+	CursorType             string
+}
+
+// Installation is a Bloxstrap installation within Dir.
+type Installation struct {
+	Dir      string
+	Settings Settings
+}
+
+// Find returns the Bloxstrap installation within the named directory, or
+// within the Bloxstrap directory of the Windows user's home directory dir.
+func Find(dir string) (*Installation, error) {
+	for _, d := range searchDirs {
+		d = filepath.Join(dir, d)
+
+		f, err := os.ReadFile(filepath.Join(d, "Settings.json"))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		i := Installation{Dir: d}
+		if err := json.Unmarshal(f, &i.Settings); err != nil {
+			return nil, fmt.Errorf("settings: %w", err)
+		}
+
+		return &i, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, dir)
+}
+
+// DeploymentChannel returns the Roblox deployment channel used by
+// Bloxstrap, with the default channel being empty.
+func (s *Settings) DeploymentChannel() string {
+	switch strings.ToLower(s.Channel) {
+	case "live", "production":
+		return ""
+	}
+
+	return s.Channel
+}
+
+// Presets returns the Vinegar mod presets equivalent to the Bloxstrap
+// mods enabled in the settings.
+func (s *Settings) Presets() []string {
+	if s.UseOldMouseCursor || s.CursorType == "From2013" {
+		return []string{mods.ClassicCursor}
+	}
+
+	return nil
+}
+
+// FFlagsPath returns the path to the FFlags set by Bloxstrap, if any.
+func (i *Installation) FFlagsPath() (string, bool) {
+	p := filepath.Join(i.Dir, ModsDir, ClientSettings, "ClientAppSettings.json")
+	_, err := os.Stat(p)
+	return p, err == nil
+}
+
+// CopyMods copies Bloxstrap's modifications to the mod directory dst,
+// and returns the amount of files copied.
+func (i *Installation) CopyMods(dst string) (int, error) {
+	src := filepath.Join(i.Dir, ModsDir)
+	n := 0
+
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) && path == src {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+
+		if rel == ClientSettings {
+			return filepath.SkipDir
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		n++
+		return copyFile(path, filepath.Join(dst, rel))
+	})
+
+	return n, err
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+
+	s, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	d, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	if _, err := io.Copy(d, s); err != nil {
+		return err
+	}
+
+	return d.Close()
+}
@@ -0,0 +1,57 @@
+package bloxstrap
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	home := t.TempDir()
+	dir := filepath.Join(home, "AppData", "Local", "Bloxstrap")
+
+	for name, data := range map[string]string{
+		"Settings.json": `{"Channel": "LIVE", "UseDiscordRichPresence": false, "CursorType": "From2013"}`,
+		"Modifications/ClientSettings/ClientAppSettings.json":    `{"FIntMeow": 1}`,
+		"Modifications/content/textures/Cursors/ArrowCursor.png": "meow",
+	} {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	i, err := Find(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := i.Settings
+	if s.DeploymentChannel() != "" || s.UseDiscordRichPresence == nil || *s.UseDiscordRichPresence {
+		t.Errorf("settings %+v, want default channel and rich presence disabled", s)
+	}
+	if !slices.Equal(s.Presets(), []string{"classic_cursor"}) {
+		t.Errorf("presets %v, want classic_cursor", s.Presets())
+	}
+
+	if _, ok := i.FFlagsPath(); !ok {
+		t.Error("want fflags")
+	}
+
+	dst := t.TempDir()
+	if n, err := i.CopyMods(dst); err != nil || n != 1 {
+		t.Fatalf("copied %d mod files (%v), want only the modification", n, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "content", "textures", "Cursors", "ArrowCursor.png")); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := Find(t.TempDir()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("find empty directory: %v, want %v", err, ErrNotFound)
+	}
+}
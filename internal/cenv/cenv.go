@@ -0,0 +1,26 @@
+// Package cenv changes the C environment of the process, read by C
+// libraries, without changing the environment of the Go runtime, which
+// is inherited by the processes started with os/exec.
+package cenv
+
+// #include <stdlib.h>
+import "C"
+
+import "unsafe"
+
+// Setenv sets the named variable within the C environment.
+func Setenv(name, value string) {
+	n, v := C.CString(name), C.CString(value)
+	defer C.free(unsafe.Pointer(n))
+	defer C.free(unsafe.Pointer(v))
+
+	C.setenv(n, v, 1)
+}
+
+// Unsetenv unsets the named variable within the C environment.
+func Unsetenv(name string) {
+	n := C.CString(name)
+	defer C.free(unsafe.Pointer(n))
+
+	C.unsetenv(n)
+}
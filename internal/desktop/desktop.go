@@ -0,0 +1,334 @@
+// Package desktop implements routines to install desktop entries and
+// register them as the default handlers of their MIME types.
+//
+// Within a Flatpak sandbox, the desktop entries and MIME associations are
+// those of the host, which the sandbox must have access to.
+package desktop
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/vinegarhq/vinegar/internal/portal"
+)
+
+var (
+	// Dir is the user's desktop entry directory.
+	Dir = filepath.Join(hostDir("HOST_XDG_DATA_HOME", xdg.DataHome, ".local/share"), "applications")
+
+	// MimeApps is the user's MIME type associations file.
+	MimeApps = filepath.Join(hostDir("HOST_XDG_CONFIG_HOME", xdg.ConfigHome, ".config"), "mimeapps.list")
+)
+
+// defaultApps is the group of MimeApps holding the default handlers.
+const defaultApps = "[Default Applications]"
+
+// hostDir returns the given XDG directory, or the host's equivalent within
+// a Flatpak sandbox, set by Flatpak in env or relative to the home directory.
+func hostDir(env, dir, rel string) string {
+	if !portal.Flatpak() {
+		return dir
+	}
+	if d := os.Getenv(env); d != "" {
+		return d
+	}
+
+	return filepath.Join(xdg.Home, rel)
+}
+
+var ErrNotDefault = errors.New("not the default handler")
+
+// Entry is a representation of a freedesktop desktop entry.
+type Entry struct {
+	ID        string // ID of the entry, without the .desktop suffix
+	Name      string
+	Icon      string
+	Exec      string
+	Terminal  bool
+	NoDisplay bool
+	MimeTypes []string
+}
+
+// execReserved are the characters which require an argument of
+// the Exec key to be quoted.
+const execReserved = " \t\n\"'\\><~|&;$*?#()`"
+
+// Quote quotes the named argument to be used within the Exec key, as
+// described by the Desktop Entry specification, with its '%' characters
+// escaped to not be interpreted as field codes.
+func Quote(arg string) string {
+	arg = strings.ReplaceAll(arg, "%", "%%")
+	if arg != "" && !strings.ContainsAny(arg, execReserved) {
+		return arg
+	}
+
+	q := strings.NewReplacer(`"`, `\"`, "`", "\\`", "$", `\$`, `\`, `\\`).Replace(arg)
+
+	// The Exec key is a string, for which backslashes are escaped again.
+	return `"` + strings.ReplaceAll(q, `\`, `\\`) + `"`
+}
+
+// File returns the file name of the desktop entry.
+func (e *Entry) File() string {
+	return e.ID + ".desktop"
+}
+
+// Path returns the path to the desktop entry within [Dir].
+func (e *Entry) Path() string {
+	return filepath.Join(Dir, e.File())
+}
+
+// String returns the contents of the desktop entry.
+func (e *Entry) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("[Desktop Entry]\n")
+	sb.WriteString("Type=Application\n")
+	sb.WriteString("Name=" + e.Name + "\n")
+	if e.NoDisplay {
+		sb.WriteString("NoDisplay=true\n")
+	}
+	if e.Icon != "" {
+		sb.WriteString("Icon=" + e.Icon + "\n")
+	}
+	sb.WriteString("Exec=" + e.Exec + "\n")
+	sb.WriteString(fmt.Sprintf("Terminal=%t\n", e.Terminal))
+	if len(e.MimeTypes) > 0 {
+		sb.WriteString("MimeType=" + strings.Join(e.MimeTypes, ";") + "\n")
+	}
+	sb.WriteString("Categories=Game\n")
+
+	return sb.String()
+}
+
+// Install writes the desktop entry to [Dir] and sets it as the default
+// handler for all of its MIME types with xdg-mime.
+func (e *Entry) Install() error {
+	if err := os.MkdirAll(Dir, 0o755); err != nil {
+		return err
+	}
+
+	slog.Info("Installing desktop entry", "path", e.Path())
+
+	if err := os.WriteFile(e.Path(), []byte(e.String()), 0o644); err != nil {
+		return err
+	}
+
+	// xdg-mime within the sandbox only sees the sandbox's associations.
+	if portal.Flatpak() {
+		slog.Info("Setting default MIME handlers", "mimeapps", MimeApps, "entry", e.File())
+
+		if err := e.associate(); err != nil {
+			return fmt.Errorf("associate: %w", err)
+		}
+
+		updateDatabase()
+		return nil
+	}
+
+	for _, mime := range e.MimeTypes {
+		slog.Info("Setting default MIME handler", "mime", mime, "entry", e.File())
+
+		if err := exec.Command("xdg-mime", "default", e.File(), mime).Run(); err != nil {
+			return fmt.Errorf("xdg-mime %s: %w", mime, err)
+		}
+	}
+
+	updateDatabase()
+	return nil
+}
+
+// Uninstall removes the desktop entry from [Dir], and removes its
+// associations from [MimeApps].
+func (e *Entry) Uninstall() error {
+	slog.Info("Removing desktop entry", "path", e.Path())
+
+	if err := os.Remove(e.Path()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := e.disassociate(); err != nil {
+		return fmt.Errorf("disassociate: %w", err)
+	}
+
+	updateDatabase()
+	return nil
+}
+
+// Registered checks if the desktop entry is installed, and is the
+// default handler for all of its MIME types.
+func (e *Entry) Registered() error {
+	if _, err := os.Stat(e.Path()); err != nil {
+		return err
+	}
+
+	var defaults map[string]string
+	if portal.Flatpak() {
+		lines, err := readMimeApps()
+		if err != nil {
+			return err
+		}
+		defaults = defaultHandlers(lines)
+	}
+
+	for _, mime := range e.MimeTypes {
+		def := defaults[mime]
+		if defaults == nil {
+			out, err := exec.Command("xdg-mime", "query", "default", mime).Output()
+			if err != nil {
+				return fmt.Errorf("xdg-mime %s: %w", mime, err)
+			}
+			def = strings.TrimSpace(string(out))
+		}
+
+		if def != e.File() {
+			return fmt.Errorf("%s: %w (%q)", mime, ErrNotDefault, def)
+		}
+	}
+
+	return nil
+}
+
+// readMimeApps returns the lines of MimeApps, which are none if it
+// does not exist.
+func readMimeApps() ([]string, error) {
+	b, err := os.ReadFile(MimeApps)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strings.TrimSuffix(string(b), "\n"), "\n"), nil
+}
+
+func writeMimeApps(lines []string) error {
+	if err := os.MkdirAll(filepath.Dir(MimeApps), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(MimeApps, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+}
+
+// defaultHandlers returns the preferred default handler of each MIME
+// type within the given lines of a MIME type associations file.
+func defaultHandlers(lines []string) map[string]string {
+	defaults := make(map[string]string)
+
+	group := ""
+	for _, l := range lines {
+		if strings.HasPrefix(l, "[") {
+			group = strings.TrimSpace(l)
+			continue
+		}
+
+		k, v, ok := strings.Cut(l, "=")
+		if !ok || group != defaultApps {
+			continue
+		}
+
+		def, _, _ := strings.Cut(v, ";")
+		defaults[strings.TrimSpace(k)] = strings.TrimSpace(def)
+	}
+
+	return defaults
+}
+
+// associate sets the desktop entry as the default handler of each of its
+// MIME types within MimeApps, as done by xdg-mime.
+func (e *Entry) associate() error {
+	lines, err := readMimeApps()
+	if err != nil {
+		return err
+	}
+
+	var out []string
+	group := ""
+	set := false
+	for _, l := range lines {
+		if strings.HasPrefix(l, "[") {
+			group = strings.TrimSpace(l)
+			out = append(out, l)
+
+			if group == defaultApps && !set {
+				out = append(out, e.defaultLines()...)
+				set = true
+			}
+			continue
+		}
+
+		// Replaced by the lines set above.
+		if k, _, ok := strings.Cut(l, "="); ok && group == defaultApps &&
+			slices.Contains(e.MimeTypes, strings.TrimSpace(k)) {
+			continue
+		}
+
+		out = append(out, l)
+	}
+
+	if !set {
+		out = append(out, defaultApps)
+		out = append(out, e.defaultLines()...)
+	}
+
+	return writeMimeApps(out)
+}
+
+func (e *Entry) defaultLines() []string {
+	lines := make([]string, 0, len(e.MimeTypes))
+	for _, mime := range e.MimeTypes {
+		lines = append(lines, mime+"="+e.File()+";")
+	}
+
+	return lines
+}
+
+// disassociate removes the desktop entry from every MIME type
+// association list in MimeApps.
+func (e *Entry) disassociate() error {
+	in, err := readMimeApps()
+	if err != nil || in == nil {
+		return err
+	}
+
+	var lines []string
+	for _, l := range in {
+		k, v, ok := strings.Cut(l, "=")
+		if !ok {
+			lines = append(lines, l)
+			continue
+		}
+
+		var keep []string
+		for _, entry := range strings.Split(strings.TrimSuffix(v, ";"), ";") {
+			if entry != e.File() {
+				keep = append(keep, entry)
+			}
+		}
+
+		if len(keep) > 0 {
+			lines = append(lines, k+"="+strings.Join(keep, ";")+";")
+		}
+	}
+
+	return writeMimeApps(lines)
+}
+
+// updateDatabase updates the MIME cache of [Dir], if update-desktop-database
+// is available.
+func updateDatabase() {
+	if _, err := exec.LookPath("update-desktop-database"); err != nil {
+		return
+	}
+
+	if err := exec.Command("update-desktop-database", Dir).Run(); err != nil {
+		slog.Warn("Failed to update desktop database", "error", err)
+	}
+}
@@ -0,0 +1,90 @@
+package desktop
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAssociate(t *testing.T) {
+	t.Setenv("FLATPAK_ID", "org.vinegarhq.Vinegar")
+
+	dir := t.TempDir()
+	Dir = dir
+	MimeApps = filepath.Join(dir, "mimeapps.list")
+
+	if err := os.WriteFile(MimeApps, []byte(`[Added Associations]
+x-scheme-handler/roblox=other.desktop;
+[Default Applications]
+text/plain=editor.desktop;
+x-scheme-handler/roblox=other.desktop;
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := Entry{ID: "meow", MimeTypes: []string{"x-scheme-handler/roblox", "x-scheme-handler/roblox-player"}}
+	if err := os.WriteFile(e.Path(), []byte(e.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.Registered(); !errors.Is(err, ErrNotDefault) {
+		t.Fatalf("registered before associating = %v, want %v", err, ErrNotDefault)
+	}
+
+	if err := e.associate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Registered(); err != nil {
+		t.Fatalf("registered: %s", err)
+	}
+
+	b, err := os.ReadFile(MimeApps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `[Added Associations]
+x-scheme-handler/roblox=other.desktop;
+[Default Applications]
+x-scheme-handler/roblox=meow.desktop;
+x-scheme-handler/roblox-player=meow.desktop;
+text/plain=editor.desktop;
+`
+	if string(b) != want {
+		t.Errorf("mimeapps after associating:\n%s\nwant:\n%s", b, want)
+	}
+
+	if err := e.disassociate(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err = os.ReadFile(MimeApps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = `[Added Associations]
+x-scheme-handler/roblox=other.desktop;
+[Default Applications]
+text/plain=editor.desktop;
+`
+	if string(b) != want {
+		t.Errorf("mimeapps after disassociating:\n%s\nwant:\n%s", b, want)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	for arg, want := range map[string]string{
+		"/usr/bin/vinegar":          "/usr/bin/vinegar",
+		"/home/me/My Games/vinegar": `"/home/me/My Games/vinegar"`,
+		`/opt/a"b$c`:                `"/opt/a\\"b\\$c"`,
+		`/opt/a\b`:                  `"/opt/a\\\\b"`,
+		"/opt/100%":                 "/opt/100%%",
+		"":                          `""`,
+	} {
+		if got := Quote(arg); got != want {
+			t.Errorf("Quote(%q) = %s, want %s", arg, got, want)
+		}
+	}
+}
@@ -12,10 +12,19 @@ var (
 	Config    = filepath.Join(xdg.ConfigHome, "vinegar")
 	Data      = filepath.Join(xdg.DataHome, "vinegar")
 	Overlays  = filepath.Join(Config, "overlays")
+	Mods      = filepath.Join(Config, "mods")
+	Plugins   = filepath.Join(Config, "plugins")
+	Scripts   = filepath.Join(Config, "scripts")
+	FFlags    = filepath.Join(Config, "fflags")
 	Downloads = filepath.Join(Cache, "downloads")
+	HTTPCache = filepath.Join(Cache, "http")
 	Logs      = filepath.Join(Cache, "logs")
+	Shaders   = filepath.Join(Cache, "shaders")
 	Prefixes  = filepath.Join(Data, "prefixes")
+	Runners   = filepath.Join(Data, "runners")
+	Settings  = filepath.Join(Data, "settings")
 	Versions  = filepath.Join(Data, "versions")
+	Runtime   = filepath.Join(xdg.RuntimeDir, "vinegar")
 
 	// Deprecated: Vinegar supports multiple wine prefixes
 	Prefix = filepath.Join(Data, "prefix")
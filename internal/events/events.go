@@ -0,0 +1,129 @@
+// Package events implements a bus of the events of a Roblox session,
+// such as joining and leaving games, which are parsed from the Roblox
+// log file and published to each of the bus's subscribers.
+package events
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Type is the type of an Event.
+type Type int
+
+const (
+	Joined     Type = iota // Joined a game
+	Left                   // Left the game
+	Teleported             // Teleported to another game or server
+	Message                // A BloxstrapRPC message was sent by the game
+	Shutdown               // Roblox is shutting down by itself
+	Crashed                // Roblox exited without shutting down
+	Located                // The game server was located, held in Data
+	Updated                // Roblox was updated to the version held in Data
+)
+
+func (t Type) String() string {
+	switch t {
+	case Joined:
+		return "joined"
+	case Left:
+		return "left"
+	case Teleported:
+		return "teleported"
+	case Message:
+		return "message"
+	case Shutdown:
+		return "shutdown"
+	case Crashed:
+		return "crashed"
+	case Located:
+		return "located"
+	case Updated:
+		return "updated"
+	default:
+		return "unknown"
+	}
+}
+
+// ServerType is the type of a Roblox game server.
+type ServerType int
+
+const (
+	Public ServerType = iota
+	Private
+	Reserved
+)
+
+func (s ServerType) String() string {
+	switch s {
+	case Public:
+		return "public"
+	case Private:
+		return "private"
+	case Reserved:
+		return "reserved"
+	default:
+		return "unknown"
+	}
+}
+
+// Game is the game Roblox is in.
+type Game struct {
+	PlaceID    string
+	UniverseID string
+	JobID      string
+	Server     ServerType
+	Address    string // IP address of the game server
+}
+
+// Event is an event of a Roblox session, with the game it was in at the
+// time of the event. Data holds the log entry of a Message event, the
+// location of the game server of a Located event, or the version of an
+// Updated event.
+type Event struct {
+	Type Type
+	Time time.Time
+	Game Game
+	Data string
+}
+
+// Handler is a function which handles published events.
+type Handler func(Event)
+
+// Bus is a set of event handlers, to which events are published.
+type Bus struct {
+	mu       sync.Mutex
+	handlers []Handler
+}
+
+// Subscribe adds the given handler to the bus.
+func (b *Bus) Subscribe(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, h)
+}
+
+// Publish calls each of the bus's handlers with the given event, in the
+// order they were subscribed. Handlers may publish events themselves, and
+// are called concurrently for events published by different goroutines.
+func (b *Bus) Publish(e Event) {
+	b.mu.Lock()
+	handlers := append([]Handler(nil), b.handlers...)
+	b.mu.Unlock()
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	if e.Type != Message {
+		slog.Info("Publishing Roblox event", "type", e.Type,
+			"placeid", e.Game.PlaceID, "universeid", e.Game.UniverseID, "jobid", e.Game.JobID,
+			"address", e.Game.Address)
+	}
+
+	for _, h := range handlers {
+		h(e)
+	}
+}
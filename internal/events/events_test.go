@@ -0,0 +1,21 @@
+package events
+
+import "testing"
+
+func TestBusPublish(t *testing.T) {
+	var b Bus
+	var got []Type
+
+	b.Subscribe(func(e Event) {
+		got = append(got, e.Type)
+		if e.Type == Joined {
+			b.Publish(Event{Type: Located})
+		}
+	})
+
+	b.Publish(Event{Type: Joined})
+
+	if len(got) != 2 || got[0] != Joined || got[1] != Located {
+		t.Fatalf("got events %v, want joined and located", got)
+	}
+}
@@ -0,0 +1,96 @@
+package events
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Roblox log entries which are parsed into events.
+const (
+	GameJoinRequestEntry = "[FLog::GameJoinUtil] GameJoinUtil::makePlaceLauncherRequest"
+	GameJoiningEntry     = "[FLog::Output] ! Joining game"
+	GameJoinReportEntry  = "[FLog::GameJoinLoadTime] Report game_join_loadtime:"
+	GameJoinedEntry      = "[FLog::Output] Connection accepted from"
+	GameJoinUDMUXEntry   = "[FLog::Network] UDMUX Address = "
+	BloxstrapRPCEntry    = "[FLog::Output] [BloxstrapRPC]"
+	GameLeaveEntry       = "[FLog::SingleSurfaceApp] leaveUGCGameInternal"
+	ShutdownEntry        = "[FLog::SingleSurfaceApp] shutDown:"
+)
+
+var (
+	GameJoinRequestEntryPattern = regexp.MustCompile(`makePlaceLauncherRequest(ForTeleport)?: requestCount: [0-9], url: https:\/\/gamejoin\.roblox\.com\/v1\/([^\s\/]+)`)
+	GameJoiningEntryPattern     = regexp.MustCompile(`! Joining game '([0-9a-f\-]{36})'`)
+	GameJoinReportEntryPattern  = regexp.MustCompile(`Report game_join_loadtime: placeid:([0-9]+).*universeid:([0-9]+)`)
+	GameJoinedEntryPattern      = regexp.MustCompile(`Connection accepted from ([0-9\.]+)\|[0-9]+`)
+	GameJoinUDMUXEntryPattern   = regexp.MustCompile(`UDMUX Address = ([0-9\.]+), Port = [0-9]+`)
+)
+
+// Keep up to date from upstream Roblox GameJoin API
+var serverTypes = map[string]ServerType{
+	"join-private-game":       Private,
+	"join-reserved-game":      Reserved,
+	"join-game":               Public,
+	"join-game-instance":      Public,
+	"join-play-together-game": Public,
+}
+
+// Parser parses the log entries of a Roblox log file into events, keeping
+// track of the game being joined in between log entries.
+type Parser struct {
+	game        Game
+	teleporting bool
+}
+
+// Parse parses the given Roblox log entry, and returns its event if
+// the entry had resulted in one.
+func (p *Parser) Parse(line string) (Event, bool) {
+	switch {
+	case strings.Contains(line, GameJoinRequestEntry):
+		// There are multiple outputs for makePlaceLauncherRequest
+		if m := GameJoinRequestEntryPattern.FindStringSubmatch(line); len(m) == 3 {
+			if m[1] == "ForTeleport" {
+				p.teleporting = true
+			}
+			p.game.Server = serverTypes[m[2]]
+			p.game.Address = ""
+		}
+	case strings.Contains(line, GameJoiningEntry):
+		if m := GameJoiningEntryPattern.FindStringSubmatch(line); len(m) == 2 {
+			p.game.JobID = m[1]
+		}
+	case strings.Contains(line, GameJoinReportEntry):
+		if m := GameJoinReportEntryPattern.FindStringSubmatch(line); len(m) == 3 {
+			p.game.PlaceID = m[1]
+			p.game.UniverseID = m[2]
+		}
+	case strings.Contains(line, GameJoinUDMUXEntry):
+		// The UDMUX proxy is the server's public address, used over
+		// the address of the server itself.
+		if m := GameJoinUDMUXEntryPattern.FindStringSubmatch(line); len(m) == 2 {
+			p.game.Address = m[1]
+		}
+	case strings.Contains(line, GameJoinedEntry):
+		if m := GameJoinedEntryPattern.FindStringSubmatch(line); len(m) == 2 && p.game.Address == "" {
+			p.game.Address = m[1]
+		}
+
+		t := Joined
+		if p.teleporting {
+			t = Teleported
+		}
+		p.teleporting = false
+
+		return Event{Type: t, Game: p.game}, true
+	case strings.Contains(line, BloxstrapRPCEntry):
+		return Event{Type: Message, Game: p.game, Data: line}, true
+	case strings.Contains(line, GameLeaveEntry):
+		e := Event{Type: Left, Game: p.game}
+		*p = Parser{}
+
+		return e, true
+	case strings.Contains(line, ShutdownEntry):
+		return Event{Type: Shutdown, Game: p.game}, true
+	}
+
+	return Event{}, false
+}
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParser(t *testing.T) {
+	var p Parser
+	var got []Event
+
+	lines := []string{
+		"[FLog::GameJoinUtil] GameJoinUtil::makePlaceLauncherRequest: requestCount: 0, url: https://gamejoin.roblox.com/v1/join-private-game",
+		"[FLog::Output] ! Joining game '2ff4d5a6-0d6f-4b2c-8e1a-fd6d1a0b1c2e' place 1818 at 10.0.0.1",
+		"[FLog::GameJoinLoadTime] Report game_join_loadtime: placeid:1818, loadtime:1203, universeid:13058, joinid:1",
+		"[FLog::Network] UDMUX Address = 128.116.1.2, Port = 55555 | RCC Server Address = 10.0.0.1, Port = 53640",
+		"[FLog::Output] Connection accepted from 10.0.0.1|53640",
+		"[FLog::Output] meow",
+		"[FLog::GameJoinUtil] GameJoinUtil::makePlaceLauncherRequestForTeleport: requestCount: 0, url: https://gamejoin.roblox.com/v1/join-game",
+		"[FLog::Output] Connection accepted from 10.0.0.2|53640",
+		"[FLog::SingleSurfaceApp] leaveUGCGameInternal",
+		"[FLog::SingleSurfaceApp] shutDown: 0",
+	}
+
+	for _, l := range lines {
+		if e, ok := p.Parse(l); ok {
+			got = append(got, e)
+		}
+	}
+
+	private := Game{"1818", "13058", "2ff4d5a6-0d6f-4b2c-8e1a-fd6d1a0b1c2e", Private, "128.116.1.2"}
+	public := private
+	public.Server = Public
+	public.Address = "10.0.0.2"
+
+	want := []Event{
+		{Type: Joined, Game: private},
+		{Type: Teleported, Game: public},
+		{Type: Left, Game: public},
+		{Type: Shutdown},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+// Package geoip implements looking up the location of IP addresses with
+// an ipinfo-style API, caching the looked up locations.
+package geoip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/netutil"
+)
+
+// DefaultAPI is the ipinfo-style API used to look up locations, which
+// returns the location of an IP address at API/<ip>/json.
+const DefaultAPI = "https://ipinfo.io"
+
+// CacheExpiry is the duration after which a cached location is looked
+// up again.
+const CacheExpiry = 7 * 24 * time.Hour
+
+var ErrPrivate = errors.New("address is not public")
+
+// CachePath is the file in which looked up locations are cached.
+var CachePath = filepath.Join(dirs.Cache, "locations.json")
+
+// Location is the location of an IP address.
+type Location struct {
+	City    string    `json:"city"`
+	Region  string    `json:"region"`
+	Country string    `json:"country"`
+	Fetched time.Time `json:"fetched"`
+}
+
+// String returns the location's city and country, such as "Frankfurt, DE".
+func (l Location) String() string {
+	var parts []string
+	for _, p := range []string{l.City, l.Country} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return l.Region
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+var (
+	mu    sync.Mutex
+	cache map[string]Location
+)
+
+// Lookup returns the location of the given IP address, looked up with the
+// given API. Locations are cached in [CachePath].
+func Lookup(api, addr string) (Location, error) {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return Location{}, fmt.Errorf("invalid address %s", addr)
+	}
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsUnspecified() {
+		return Location{}, fmt.Errorf("%w: %s", ErrPrivate, addr)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if cache == nil {
+		cache = load(CachePath)
+	}
+
+	if l, ok := cache[addr]; ok && time.Since(l.Fetched) < CacheExpiry {
+		return l, nil
+	}
+
+	l, err := lookup(api, addr)
+	if err != nil {
+		return Location{}, err
+	}
+
+	cache[addr] = l
+	if err := save(CachePath, cache); err != nil {
+		slog.Warn("Could not cache location", "error", err)
+	}
+
+	return l, nil
+}
+
+func lookup(api, addr string) (Location, error) {
+	body, err := netutil.Body(context.Background(), strings.TrimSuffix(api, "/")+"/"+addr+"/json")
+	if err != nil {
+		return Location{}, err
+	}
+
+	var l Location
+	if err := json.Unmarshal([]byte(body), &l); err != nil {
+		return Location{}, err
+	}
+	if l.City == "" && l.Region == "" && l.Country == "" {
+		return Location{}, fmt.Errorf("no location for %s", addr)
+	}
+	l.Fetched = time.Now()
+
+	return l, nil
+}
+
+func load(name string) map[string]Location {
+	ls := make(map[string]Location)
+
+	f, err := os.ReadFile(name)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("Could not read location cache", "error", err)
+		}
+		return ls
+	}
+
+	if err := json.Unmarshal(f, &ls); err != nil {
+		slog.Warn("Ignoring invalid location cache", "error", err)
+		return make(map[string]Location)
+	}
+
+	return ls
+}
+
+// save writes the given locations to the named file, removing expired
+// locations to keep the cache small.
+func save(name string, ls map[string]Location) error {
+	for addr, l := range ls {
+		if time.Since(l.Fetched) > CacheExpiry {
+			delete(ls, addr)
+		}
+	}
+
+	f, err := json.Marshal(ls)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, f, 0o644)
+}
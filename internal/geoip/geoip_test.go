@@ -0,0 +1,43 @@
+package geoip
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/128.116.1.2/json" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"ip":"128.116.1.2","city":"Frankfurt am Main","region":"Hesse","country":"DE"}`)
+	}))
+	defer srv.Close()
+
+	CachePath = filepath.Join(t.TempDir(), "locations.json")
+
+	l, err := Lookup(srv.URL, "128.116.1.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.String() != "Frankfurt am Main, DE" {
+		t.Errorf("location %s, want Frankfurt am Main, DE", l)
+	}
+
+	// Cached locations are read back from the cache file
+	cache = nil
+	if _, err := Lookup(srv.URL, "128.116.1.2"); err != nil || requests != 1 {
+		t.Errorf("looked up %d times, want cached location", requests)
+	}
+
+	if _, err := Lookup(srv.URL, "10.0.0.1"); !errors.Is(err, ErrPrivate) {
+		t.Error("expected private address check")
+	}
+}
@@ -0,0 +1,130 @@
+// Package grapejuice implements reading a Grapejuice installation, for its
+// wineprefixes and their settings to be migrated to Vinegar.
+package grapejuice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+)
+
+var (
+	// SettingsPath is the path to Grapejuice's settings.
+	SettingsPath = filepath.Join(xdg.ConfigHome, "brinkervii", "grapejuice", "user_settings.json")
+
+	// PrefixesDir is the directory holding Grapejuice's wineprefixes,
+	// named after their name on disk.
+	PrefixesDir = filepath.Join(xdg.DataHome, "grapejuice", "prefixes")
+)
+
+// Hints of the Roblox applications a wineprefix is used for.
+const (
+	PlayerHint = "player_app"
+	StudioHint = "studio_app"
+)
+
+var (
+	ErrNotFound   = errors.New("grapejuice installation not found")
+	ErrBadPrefix  = errors.New("bad wineprefix name")
+	ErrNoPrefixes = errors.New("grapejuice has no wineprefixes")
+)
+
+// Prefix is a representation of a Grapejuice wineprefix and its settings.
+type Prefix struct {
+	Name        string            `json:"name_on_disk"`
+	DisplayName string            `json:"display_name"`
+	WineHome    string            `json:"wine_home"`
+	Renderer    string            `json:"roblox_renderer"`
+	Env         map[string]string `json:"env"`
+	FFlags      map[string]any    `json:"fast_flags"`
+	Hints       []string          `json:"hints"`
+}
+
+// Settings is a representation of the Grapejuice settings which have
+// a Vinegar equivalent.
+type Settings struct {
+	DefaultWineHome string   `json:"default_wine_home"`
+	Prefixes        []Prefix `json:"wineprefixes"`
+}
+
+// Load returns the Grapejuice settings at the named path.
+func Load(path string) (*Settings, error) {
+	f, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var s Settings
+	if err := json.Unmarshal(f, &s); err != nil {
+		return nil, fmt.Errorf("settings: %w", err)
+	}
+
+	if len(s.Prefixes) == 0 {
+		return nil, ErrNoPrefixes
+	}
+
+	for _, p := range s.Prefixes {
+		if p.Name == "" || p.Name != filepath.Base(p.Name) || !filepath.IsLocal(p.Name) {
+			return nil, fmt.Errorf("%w: %q", ErrBadPrefix, p.Name)
+		}
+
+		// JSON numbers are decoded as floats, while integer FFlags
+		// must stay integers.
+		for name, v := range p.FFlags {
+			if f, ok := v.(float64); ok && f == math.Trunc(f) {
+				p.FFlags[name] = int64(f)
+			}
+		}
+	}
+
+	return &s, nil
+}
+
+// Prefix returns the first wineprefix with the given hint, if any.
+func (s *Settings) Prefix(hint string) *Prefix {
+	for i, p := range s.Prefixes {
+		for _, h := range p.Hints {
+			if h == hint {
+				return &s.Prefixes[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// Dir returns the wineprefix's directory within the named directory
+// holding Grapejuice's wineprefixes.
+func (p *Prefix) Dir(prefixesDir string) string {
+	return filepath.Join(prefixesDir, p.Name)
+}
+
+// VinegarRenderer returns the Vinegar equivalent of the wineprefix's
+// Roblox renderer, which is empty if it was left undetermined.
+func (p *Prefix) VinegarRenderer() string {
+	switch p.Renderer {
+	case "DX11":
+		return "D3D11"
+	case "Vulkan", "OpenGL":
+		return p.Renderer
+	}
+
+	return ""
+}
+
+// WineRoot returns the Wine installation used by the wineprefix, if
+// not the system's Wine installation.
+func (s *Settings) WineRoot(p *Prefix) string {
+	if p.WineHome != "" {
+		return p.WineHome
+	}
+
+	return s.DefaultWineHome
+}
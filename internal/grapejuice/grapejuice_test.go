@@ -0,0 +1,57 @@
+package grapejuice
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSettings(t *testing.T, data string) string {
+	p := filepath.Join(t.TempDir(), "user_settings.json")
+	if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLoad(t *testing.T) {
+	s, err := Load(writeSettings(t, `{
+		"default_wine_home": "/opt/wine",
+		"wineprefixes": [
+			{"name_on_disk": "player", "display_name": "Player", "roblox_renderer": "DX11",
+			 "fast_flags": {"FIntMeow": 60, "FFlagMeow": true}, "hints": ["player_app"]},
+			{"name_on_disk": "studio", "display_name": "Studio", "wine_home": "/opt/wine-ge",
+			 "roblox_renderer": "Undetermined", "hints": ["studio_app"]}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := s.Prefix(PlayerHint)
+	if p == nil || p.Name != "player" {
+		t.Fatalf("player prefix %+v, want player", p)
+	}
+	if r := p.VinegarRenderer(); r != "D3D11" {
+		t.Errorf("renderer %s, want D3D11", r)
+	}
+	if w := s.WineRoot(p); w != "/opt/wine" {
+		t.Errorf("wineroot %s, want default wine home", w)
+	}
+	if v, ok := p.FFlags["FIntMeow"].(int64); !ok || v != 60 {
+		t.Errorf("fflag %#v, want integer", p.FFlags["FIntMeow"])
+	}
+
+	p = s.Prefix(StudioHint)
+	if p == nil || p.VinegarRenderer() != "" || s.WineRoot(p) != "/opt/wine-ge" {
+		t.Errorf("studio prefix %+v, want undetermined renderer and own wine home", p)
+	}
+
+	if _, err := Load(writeSettings(t, `{"wineprefixes": [{"name_on_disk": "../meow"}]}`)); !errors.Is(err, ErrBadPrefix) {
+		t.Errorf("load bad prefix: %v, want %v", err, ErrBadPrefix)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "meow.json")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("load missing: %v, want %v", err, ErrNotFound)
+	}
+}
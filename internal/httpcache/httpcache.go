@@ -0,0 +1,170 @@
+// Package httpcache implements a HTTP transport caching responses along
+// with their HTTP validators, to revalidate them with conditional requests.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MaxStale is how long a cached response may still be used for since it
+// was last validated if it can't be revalidated, such as during an outage.
+const MaxStale = 6 * time.Hour
+
+// Transport is a [http.RoundTripper] which caches the responses of the GET
+// requests matched by Match in Dir, if they have a validator.
+type Transport struct {
+	Dir   string
+	Match func(*http.Request) bool
+
+	// Base is the transport used to make requests, [http.DefaultTransport]
+	// if nil.
+	Base http.RoundTripper
+}
+
+type entry struct {
+	ETag         string `json:",omitempty"`
+	LastModified string `json:",omitempty"`
+	Header       http.Header
+	Body         []byte
+	Validated    time.Time
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if req.Method != http.MethodGet || (t.Match != nil && !t.Match(req)) {
+		return base.RoundTrip(req)
+	}
+
+	name := t.path(req.URL.String())
+	e, err := load(name)
+	if err != nil && !os.IsNotExist(err) {
+		slog.Warn("Could not load cached response", "url", req.URL, "error", err)
+	}
+
+	r := req
+	if e != nil {
+		r = req.Clone(req.Context())
+		if e.ETag != "" {
+			r.Header.Set("If-None-Match", e.ETag)
+		}
+		if e.LastModified != "" {
+			r.Header.Set("If-Modified-Since", e.LastModified)
+		}
+	}
+
+	resp, err := base.RoundTrip(r)
+	if err != nil || resp.StatusCode >= 500 {
+		if e == nil || time.Since(e.Validated) > MaxStale {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		slog.Warn("Using cached response, as it could not be revalidated",
+			"url", req.URL, "validated", e.Validated)
+		return e.response(req), nil
+	}
+
+	switch {
+	case e != nil && resp.StatusCode == http.StatusNotModified:
+		resp.Body.Close()
+
+		e.Validated = time.Now()
+		if err := e.save(name); err != nil {
+			slog.Warn("Could not cache response", "url", req.URL, "error", err)
+		}
+
+		return e.response(req), nil
+	case resp.StatusCode == http.StatusOK:
+		if resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+			break
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		e := entry{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Header:       resp.Header,
+			Body:         body,
+			Validated:    time.Now(),
+		}
+		if err := e.save(name); err != nil {
+			slog.Warn("Could not cache response", "url", req.URL, "error", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func (t *Transport) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(t.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func load(name string) (*entry, error) {
+	f, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var e entry
+	if err := json.Unmarshal(f, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// save saves the entry to the named file atomically, as it may be read by
+// another instance at the same time.
+func (e *entry) save(name string) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, name)
+}
+
+func (e *entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
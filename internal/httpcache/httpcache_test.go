@@ -0,0 +1,55 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransport(t *testing.T) {
+	requests, down := 0, false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case down:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.Header.Get("If-None-Match") == `"meow"`:
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Header().Set("ETag", `"meow"`)
+			io.WriteString(w, "purr")
+		}
+	}))
+	defer srv.Close()
+
+	c := &http.Client{Transport: &Transport{Dir: t.TempDir()}}
+	get := func() string {
+		resp, err := c.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status %s", resp.Status)
+		}
+
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return string(b)
+	}
+
+	for i, state := range []string{"uncached", "revalidated", "stale"} {
+		down = state == "stale"
+		if b := get(); b != "purr" {
+			t.Errorf("%s response is %q", state, b)
+		}
+		if requests != i+1 {
+			t.Errorf("%s response made %d requests", state, requests)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Package mods implements routines to apply modifications ("mods") of
+// files over a Roblox version directory, tracking the applied files with
+// their checksums to allow cleanly reapplying or removing them.
+package mods
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// BackupDir is the directory within a version directory in which the
+// version's original files replaced by mods are kept.
+const BackupDir = ".vinegar-mods"
+
+// File is a file of a mod, to be applied over a version directory.
+type File struct {
+	Path     string
+	Checksum string
+}
+
+// Mod is a named set of files, keyed by their path relative to the
+// version directory.
+type Mod struct {
+	Name  string
+	Files map[string]File
+}
+
+// Conflict is a file provided by multiple mods with differing contents.
+type Conflict struct {
+	Path       string
+	Winner     string
+	Overridden []string
+}
+
+// Applied is the set of the files applied to a version directory, by their
+// path relative to the version directory and their checksum.
+type Applied map[string]string
+
+// Load returns the files within the mod directory dir, keyed by their path
+// relative to dir. If dir does not exist, no files are returned.
+func Load(dir string) (map[string]File, error) {
+	files := make(map[string]File)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) && path == dir {
+			return filepath.SkipDir
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		sum, err := checksum(path)
+		if err != nil {
+			return err
+		}
+
+		files[rel] = File{Path: path, Checksum: sum}
+		return nil
+	})
+
+	return files, err
+}
+
+// Merge merges the files of the given mods, ordered by ascending priority:
+// when multiple mods provide the same file, the file from the mod which
+// comes last is used, and a Conflict is reported.
+func Merge(ms []Mod) (map[string]File, []Conflict) {
+	files := make(map[string]File)
+	owners := make(map[string][]string)
+
+	for _, m := range ms {
+		for rel, f := range m.Files {
+			// A mod providing the same file as the mod before it replaces
+			// it as the owner, as the file is written by it last.
+			o := owners[rel]
+			if prev, ok := files[rel]; ok && prev.Checksum == f.Checksum {
+				o = o[:len(o)-1]
+			}
+			owners[rel] = append(o, m.Name)
+			files[rel] = f
+		}
+	}
+
+	var cs []Conflict
+	for rel, o := range owners {
+		if len(o) < 2 {
+			continue
+		}
+
+		cs = append(cs, Conflict{
+			Path:       rel,
+			Winner:     o[len(o)-1],
+			Overridden: o[:len(o)-1],
+		})
+	}
+
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].Path < cs[j].Path
+	})
+
+	return files, cs
+}
+
+// Apply applies the given files over the version directory dir, and removes
+// the applied files which are no longer present in files; applied is
+// updated respectively.
+//
+// Files of the version directory which are replaced by mods are backed up
+// to BackupDir to be restored when the mod file is removed. Applied files
+// which were modified by anything other than Apply are left as-is.
+func Apply(dir string, files map[string]File, applied Applied) error {
+	for rel, sum := range applied {
+		if _, ok := files[rel]; ok {
+			continue
+		}
+
+		if err := remove(dir, rel, sum); err != nil {
+			return fmt.Errorf("remove %s: %w", rel, err)
+		}
+
+		delete(applied, rel)
+	}
+
+	for rel, f := range files {
+		path := filepath.Join(dir, rel)
+
+		cur, err := checksum(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		if cur == f.Checksum {
+			applied[rel] = f.Checksum
+			continue
+		}
+
+		// Only back up the version's own files, which are files that
+		// have never been applied or have since been replaced.
+		if sum, ok := applied[rel]; cur != "" && (!ok || cur != sum) {
+			if err := backup(dir, rel); err != nil {
+				return fmt.Errorf("backup %s: %w", rel, err)
+			}
+		}
+
+		slog.Info("Applying mod file", "path", path, "src", f.Path)
+
+		if err := copyFile(f.Path, path); err != nil {
+			return fmt.Errorf("apply %s: %w", rel, err)
+		}
+
+		applied[rel] = f.Checksum
+	}
+
+	return nil
+}
+
+func remove(dir, rel, sum string) error {
+	path := filepath.Join(dir, rel)
+	orig := filepath.Join(dir, BackupDir, rel)
+
+	cur, err := checksum(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if cur != sum {
+		slog.Warn("Mod file was modified, leaving as-is", "path", path)
+		return os.RemoveAll(orig)
+	}
+
+	slog.Info("Removing mod file", "path", path)
+
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+
+	if err := os.Rename(orig, path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	return nil
+}
+
+func backup(dir, rel string) error {
+	orig := filepath.Join(dir, BackupDir, rel)
+
+	if err := os.MkdirAll(filepath.Dir(orig), 0o755); err != nil {
+		return err
+	}
+
+	return os.Rename(filepath.Join(dir, rel), orig)
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+
+	s, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	d, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	if _, err := io.Copy(d, s); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func checksum(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,165 @@
+package mods
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, data string) {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, name string) string {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(b)
+}
+
+func TestApply(t *testing.T) {
+	mod := t.TempDir()
+	ver := t.TempDir()
+	ouch := filepath.Join("content", "sounds", "ouch.ogg")
+	cursor := filepath.Join("content", "textures", "Cursors", "ArrowCursor.png")
+
+	writeFile(t, filepath.Join(ver, ouch), "oof")
+	writeFile(t, filepath.Join(mod, ouch), "uuhhh")
+	writeFile(t, filepath.Join(mod, cursor), "arrow")
+
+	files, err := Load(mod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied := make(Applied)
+	if err := Apply(ver, files, applied); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(applied) != 2 || readFile(t, filepath.Join(ver, ouch)) != "uuhhh" {
+		t.Fatal("expected applied mod files")
+	}
+
+	// Reapplying should leave the original backup untouched
+	if err := Apply(ver, files, applied); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.RemoveAll(mod); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err = Load(mod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Apply(ver, files, applied); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(applied) != 0 {
+		t.Error("expected no applied mod files")
+	}
+
+	if readFile(t, filepath.Join(ver, ouch)) != "oof" {
+		t.Error("expected restored original file")
+	}
+
+	if _, err := os.Stat(filepath.Join(ver, cursor)); err == nil {
+		t.Error("expected removed mod file")
+	}
+}
+
+func TestLoadPreset(t *testing.T) {
+	ver := t.TempDir()
+
+	if _, err := LoadPreset("classic_cursor", ver); err == nil {
+		t.Error("expected missing preset source file")
+	}
+
+	for _, src := range Presets["classic_cursor"].Files {
+		writeFile(t, filepath.Join(ver, src), "cursor")
+	}
+
+	files, err := LoadPreset("classic_cursor", ver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Apply(ver, files, make(Applied)); err != nil {
+		t.Fatal(err)
+	}
+
+	for dst := range Presets["classic_cursor"].Files {
+		if readFile(t, filepath.Join(ver, dst)) != "cursor" {
+			t.Errorf("expected applied preset file %s", dst)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	ouch := filepath.Join("content", "sounds", "ouch.ogg")
+	ms := []Mod{
+		{"meow", map[string]File{ouch: {"meow/ouch.ogg", "1"}}},
+		{"purr", map[string]File{ouch: {"purr/ouch.ogg", "2"}}},
+		{"hiss", map[string]File{ouch: {"hiss/ouch.ogg", "2"}}},
+	}
+
+	files, cs := Merge(ms)
+
+	if files[ouch].Path != "hiss/ouch.ogg" {
+		t.Error("expected last mod file to win")
+	}
+
+	if len(cs) != 1 || cs[0].Winner != "hiss" || len(cs[0].Overridden) != 1 || cs[0].Overridden[0] != "meow" {
+		t.Errorf("expected conflict between differing files only, got %v", cs)
+	}
+}
+
+func TestLoadConvertedPreset(t *testing.T) {
+	ver := t.TempDir()
+
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{255, 255, 255, 128})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	for _, src := range Presets[DarkCursor].Files {
+		writeFile(t, filepath.Join(ver, src), buf.String())
+	}
+
+	files, err := LoadPreset(DarkCursor, ver)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for dst, f := range files {
+		if !strings.HasPrefix(f.Path, filepath.Join(ver, PresetDir)) {
+			t.Errorf("preset file %s sourced from %s, want converted", dst, f.Path)
+		}
+
+		got, err := png.Decode(strings.NewReader(readFile(t, f.Path)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c := color.NRGBAModel.Convert(got.At(0, 0)); c != (color.NRGBA{0, 0, 0, 128}) {
+			t.Errorf("converted color %v, want inverted", c)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package mods
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+)
+
+var ErrNoMod = errors.New("no such mod preset or directory")
+
+// PresetDir is the directory within a version directory in which the
+// files converted by presets are kept.
+const PresetDir = ".vinegar-presets"
+
+// Names of the cursor presets, ClassicCursor is also applied by the
+// oldcursor tweak.
+const (
+	ClassicCursor = "classic_cursor"
+	DarkCursor    = "dark_cursor"
+)
+
+// Preset is a built-in mod, which replaces files of a version directory
+// with other files within the same version directory. As the files are
+// sourced from the version directory, they are always from the version
+// the Preset is applied to.
+type Preset struct {
+	Description string
+
+	// Files maps the files to be replaced to their replacement, with
+	// both relative to the version directory.
+	Files map[string]string
+
+	// Convert, if set, converts the contents of each replacement file,
+	// the converted files being kept within the PresetDir.
+	Convert func([]byte) ([]byte, error)
+}
+
+// classicCursorFiles are the files replaced by the cursor presets.
+var classicCursorFiles = map[string]string{
+	"content/textures/Cursors/KeyboardMouse/ArrowCursor.png":    "content/textures/ArrowCursor.png",
+	"content/textures/Cursors/KeyboardMouse/ArrowFarCursor.png": "content/textures/ArrowFarCursor.png",
+}
+
+// Presets is the set of built-in mods available.
+//
+// The old death sound is not a preset, as it is no longer distributed
+// by Roblox, and can be applied with the overlay directory instead.
+var Presets = map[string]Preset{
+	ClassicCursor: {
+		Description: "Cursor used by Roblox prior to 2023",
+		Files:       classicCursorFiles,
+	},
+	DarkCursor: {
+		Description: "Cursor used by Roblox prior to 2023, in dark colors",
+		Files:       classicCursorFiles,
+		Convert:     invertPNG,
+	},
+}
+
+// invertPNG inverts the colors of the given PNG image, keeping
+// its transparency.
+func invertPNG(data []byte) ([]byte, error) {
+	src, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	b := src.Bounds()
+	dst := image.NewNRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+			c.R, c.G, c.B = 255-c.R, 255-c.G, 255-c.B
+			dst.SetNRGBA(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Exists determines if the named mod is a preset or a mod directory
+// within modsDir.
+func Exists(name, modsDir string) bool {
+	if _, ok := Presets[name]; ok {
+		return true
+	}
+
+	fi, err := os.Stat(filepath.Join(modsDir, name))
+	return err == nil && fi.IsDir()
+}
+
+// Named returns the named mod for the version directory dir, which is
+// either a preset or otherwise the mod directory of the same name
+// within modsDir.
+func Named(name, modsDir, dir string) (Mod, error) {
+	if _, ok := Presets[name]; ok {
+		files, err := LoadPreset(name, dir)
+		return Mod{Name: name, Files: files}, err
+	}
+
+	if !Exists(name, modsDir) {
+		return Mod{}, fmt.Errorf("%w: %s", ErrNoMod, name)
+	}
+
+	files, err := Load(filepath.Join(modsDir, name))
+	return Mod{Name: name, Files: files}, err
+}
+
+// LoadPreset returns the files of the named preset for the version
+// directory dir, keyed by their path relative to dir.
+func LoadPreset(name string, dir string) (map[string]File, error) {
+	p, ok := Presets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoMod, name)
+	}
+
+	files := make(map[string]File, len(p.Files))
+
+	for dst, src := range p.Files {
+		path := filepath.Join(dir, filepath.FromSlash(src))
+
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s: %s is not present in this version", name, src)
+		}
+
+		if p.Convert != nil {
+			conv := filepath.Join(dir, PresetDir, name, filepath.FromSlash(dst))
+			if err := convertFile(path, conv, p.Convert); err != nil {
+				return nil, fmt.Errorf("%s: convert %s: %w", name, src, err)
+			}
+			path = conv
+		}
+
+		sum, err := checksum(path)
+		if err != nil {
+			return nil, err
+		}
+
+		files[filepath.FromSlash(dst)] = File{Path: path, Checksum: sum}
+	}
+
+	return files, nil
+}
+
+// convertFile writes the contents of the file src converted with
+// the given function to dst.
+func convertFile(src, dst string, convert func([]byte) ([]byte, error)) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	data, err = convert(data)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(dst, data, 0o644)
+}
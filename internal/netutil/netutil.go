@@ -2,12 +2,15 @@
 package netutil
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"os"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/internal/retry"
 )
 
 // DrawFunc is the callback type for drawing progress, it will
@@ -27,11 +30,63 @@ func (pc *progressCounter) Write(p []byte) (int, error) {
 	return n, nil
 }
 
-// ErrBadStatus is the error returned by Download and Body
-// if the returned HTTP status code is not http.StatusOK.
+// WriteFunc is the callback type for reporting the amount of bytes
+// written to a file by DownloadResume, including the bytes of the
+// partially downloaded file that was resumed.
+type WriteFunc func(written int64)
+
+type writeCounter struct {
+	written int64
+	fn      WriteFunc
+}
+
+func (wc *writeCounter) Write(p []byte) (int, error) {
+	wc.written += int64(len(p))
+	if wc.fn != nil {
+		wc.fn(wc.written)
+	}
+	return len(p), nil
+}
+
+// ErrBadStatus is the error matched by the StatusError returned by
+// Download and Body if the returned HTTP status code is not http.StatusOK.
 var ErrBadStatus = errors.New("bad status")
 
-// TODO: make ErrBadStatus a custom error type with interface
+// StatusError is the error returned if the returned HTTP status code
+// is not http.StatusOK.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return ErrBadStatus.Error() + ": " + e.Status
+}
+
+func (e *StatusError) Is(target error) bool {
+	return target == ErrBadStatus
+}
+
+// Transient determines if the given error of a request is likely to
+// be transient: a network error, a server error, or rate limiting.
+// Cancelled requests are never transient.
+func Transient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.StatusCode >= 500 || se.StatusCode == http.StatusTooManyRequests
+	}
+
+	var pe *os.PathError
+	return !errors.As(err, &pe)
+}
+
+func statusError(resp *http.Response) error {
+	return &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+}
 
 // DownloadProgress downloads the named url to the named file, using
 // df as the callback for progress. No retry will be checked here.
@@ -49,7 +104,7 @@ func DownloadProgress(url, file string, df DrawFunc) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: %s", ErrBadStatus, resp.Status)
+		return statusError(resp)
 	}
 
 	pc := &progressCounter{
@@ -65,28 +120,20 @@ func DownloadProgress(url, file string, df DrawFunc) error {
 	return nil
 }
 
-// Download downloads the named url to the named file. If an error
-// occurs when downloading the file. Download will retry 3 times before
-// returning a final error.
+// Download downloads the named url to the named file. Transient
+// failures are retried with [retry.Network].
 func Download(url, file string) error {
-	retries := 3
-	for i := 0; i < retries; i++ {
-		err := download(url, file)
-		if err == nil {
-			break
-		}
-
-		// additional condition for if the error was a file error or status error
-		if _, ok := err.(*os.PathError); err != nil &&
-			(i == retries-1 || ok || errors.Is(err, ErrBadStatus)) {
-			os.Remove(file) // just remove the thing anyway on failure
-			return err
-		}
+	p := retry.Network
+	p.Retryable = Transient
 
-		log.Printf("Download %s failed, retrying...", url)
+	err := retry.Do("download "+url, p, func() error {
+		return download(url, file)
+	})
+	if err != nil {
+		os.Remove(file) // just remove the thing anyway on failure
 	}
 
-	return nil
+	return err
 }
 
 func download(url, file string) error {
@@ -103,7 +150,7 @@ func download(url, file string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: %s", ErrBadStatus, resp.Status)
+		return statusError(resp)
 	}
 
 	_, err = io.Copy(out, resp.Body)
@@ -114,16 +161,109 @@ func download(url, file string) error {
 	return nil
 }
 
-// Body retrieves the body of the named url to string form.
-func Body(url string) (string, error) {
-	resp, err := http.Get(url)
+// DownloadResume downloads the named url to the named file, resuming the
+// download from the end of the file if it already exists with a HTTP Range
+// request. If the server does not support ranges, the file is downloaded
+// from the start.
+//
+// Transient failures are retried with [retry.Network], each resuming where
+// the previous one has left off. Unlike Download, the file is kept on
+// failure so that it may be resumed later; as such the file should be
+// verified by the caller. The download is stopped once ctx is done.
+func DownloadResume(ctx context.Context, url, file string, wf WriteFunc) error {
+	p := retry.Network
+	p.Retryable = Transient
+
+	return retry.DoContext(ctx, "download "+url, p, func() error {
+		return downloadResume(ctx, url, file, wf)
+	})
+}
+
+func downloadResume(ctx context.Context, url, file string, wf WriteFunc) error {
+	out, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	off, err := out.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if off > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", off))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		start := fmt.Sprintf("bytes %d-", off)
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), start) {
+			return fmt.Errorf("unexpected content range: %s", resp.Header.Get("Content-Range"))
+		}
+	case http.StatusOK:
+		if off > 0 {
+			if err := out.Truncate(0); err != nil {
+				return err
+			}
+			if _, err := out.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			off = 0
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The file is not smaller than the remote file, and is
+		// assumed to be complete.
+		return nil
+	default:
+		return statusError(resp)
+	}
+
+	wc := &writeCounter{written: off, fn: wf}
+	wc.Write(nil)
+
+	_, err = io.Copy(out, io.TeeReader(resp.Body, wc))
+	return err
+}
+
+// Body retrieves the body of the named url to string form. Transient
+// failures are retried with [retry.Network] until ctx is done.
+func Body(ctx context.Context, url string) (body string, err error) {
+	p := retry.Network
+	p.Retryable = Transient
+
+	err = retry.DoContext(ctx, "get "+url, p, func() error {
+		body, err = getBody(ctx, url)
+		return err
+	})
+
+	return
+}
+
+func getBody(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("%w: %s", ErrBadStatus, resp.Status)
+		return "", statusError(resp)
 	}
 
 	body, err := io.ReadAll(resp.Body)
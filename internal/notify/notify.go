@@ -0,0 +1,76 @@
+// Package notify implements sending desktop notifications with the
+// org.freedesktop.Notifications D-Bus interface.
+package notify
+
+import (
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	Destination = "org.freedesktop.Notifications"
+	Path        = "/org/freedesktop/Notifications"
+)
+
+// Urgency is the urgency level of a Notification.
+type Urgency byte
+
+const (
+	Low Urgency = iota
+	Normal
+	Critical
+)
+
+// Notification is a desktop notification.
+type Notification struct {
+	Summary string
+	Body    string
+	Urgency Urgency
+
+	// Replaces is the ID of a previously sent notification which should
+	// be replaced by this notification, rather than sent alongside it.
+	Replaces uint32
+
+	// Timeout is the duration after which the notification expires. If
+	// zero, the notification server's default timeout is used.
+	Timeout time.Duration
+}
+
+// Notifier sends desktop notifications on behalf of an application.
+type Notifier struct {
+	App  string // application name
+	Icon string // application icon name or path
+
+	conn *dbus.Conn
+}
+
+// New returns a new Notifier sending notifications with the given
+// D-Bus session bus connection.
+func New(conn *dbus.Conn, app, icon string) *Notifier {
+	return &Notifier{
+		App:  app,
+		Icon: icon,
+		conn: conn,
+	}
+}
+
+// Send sends the given notification, and returns its ID, which can be
+// used to replace the notification.
+func (n *Notifier) Send(nt Notification) (uint32, error) {
+	timeout := int32(-1)
+	if nt.Timeout > 0 {
+		timeout = int32(nt.Timeout.Milliseconds())
+	}
+
+	hints := map[string]dbus.Variant{
+		"urgency": dbus.MakeVariant(byte(nt.Urgency)),
+	}
+
+	var id uint32
+	err := n.conn.Object(Destination, Path).Call(Destination+".Notify", 0,
+		n.App, nt.Replaces, n.Icon, nt.Summary, nt.Body,
+		[]string{}, hints, timeout).Store(&id)
+
+	return id, err
+}
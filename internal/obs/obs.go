@@ -0,0 +1,200 @@
+// Package obs implements a minimal OBS WebSocket (version 5) client,
+// to make requests to a running OBS Studio instance.
+package obs
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const rpcVersion = 1
+
+// Timeout is the maximum time taken by connecting to the server, and by
+// each message sent to or read from it.
+var Timeout = 5 * time.Second
+
+// OpCodes of the OBS WebSocket protocol messages used by Client.
+const (
+	opHello           = 0
+	opIdentify        = 1
+	opIdentified      = 2
+	opRequest         = 6
+	opRequestResponse = 7
+)
+
+var ErrNeedPassword = errors.New("obs websocket requires a password")
+
+// RequestError is an unsuccessful response to a request.
+type RequestError struct {
+	Type    string
+	Code    int
+	Comment string
+}
+
+func (e *RequestError) Error() string {
+	if e.Comment == "" {
+		return fmt.Sprintf("obs request %s failed: code %d", e.Type, e.Code)
+	}
+	return fmt.Sprintf("obs request %s failed: %s (code %d)", e.Type, e.Comment, e.Code)
+}
+
+type message struct {
+	Op int             `json:"op"`
+	D  json.RawMessage `json:"d"`
+}
+
+// Client is a connection to an OBS WebSocket server.
+type Client struct {
+	conn *websocket.Conn
+	id   int
+}
+
+// Dial connects and identifies to the OBS WebSocket server at the given
+// address, such as localhost:4455. The password is only used if the server
+// requires authentication.
+func Dial(addr, password string) (*Client, error) {
+	d := websocket.Dialer{HandshakeTimeout: Timeout}
+
+	conn, _, err := d.Dial("ws://"+addr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{conn: conn}
+	if err := c.identify(password); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Client) identify(password string) error {
+	var hello struct {
+		Authentication *struct {
+			Challenge string `json:"challenge"`
+			Salt      string `json:"salt"`
+		} `json:"authentication"`
+	}
+
+	if err := c.read(opHello, &hello); err != nil {
+		return fmt.Errorf("hello: %w", err)
+	}
+
+	id := struct {
+		RPCVersion         int    `json:"rpcVersion"`
+		Authentication     string `json:"authentication,omitempty"`
+		EventSubscriptions int    `json:"eventSubscriptions"`
+	}{RPCVersion: rpcVersion}
+
+	if a := hello.Authentication; a != nil {
+		if password == "" {
+			return ErrNeedPassword
+		}
+		id.Authentication = Authentication(password, a.Salt, a.Challenge)
+	}
+
+	if err := c.write(opIdentify, id); err != nil {
+		return fmt.Errorf("identify: %w", err)
+	}
+
+	if err := c.read(opIdentified, nil); err != nil {
+		return fmt.Errorf("identify: %w", err)
+	}
+
+	return nil
+}
+
+// Authentication returns the authentication string for the given
+// password and the server's salt and challenge.
+func Authentication(password, salt, challenge string) string {
+	secret := sha256.Sum256([]byte(password + salt))
+	auth := sha256.Sum256([]byte(base64.StdEncoding.EncodeToString(secret[:]) + challenge))
+	return base64.StdEncoding.EncodeToString(auth[:])
+}
+
+// Request makes the named request with the given request data, which
+// may be nil, and waits for its response.
+func (c *Client) Request(typ string, data any) error {
+	c.id++
+	id := fmt.Sprint(c.id)
+
+	err := c.write(opRequest, struct {
+		RequestType string `json:"requestType"`
+		RequestID   string `json:"requestId"`
+		RequestData any    `json:"requestData,omitempty"`
+	}{typ, id, data})
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		RequestID     string `json:"requestId"`
+		RequestStatus struct {
+			Result  bool   `json:"result"`
+			Code    int    `json:"code"`
+			Comment string `json:"comment"`
+		} `json:"requestStatus"`
+	}
+
+	for resp.RequestID != id {
+		if err := c.read(opRequestResponse, &resp); err != nil {
+			return err
+		}
+	}
+
+	if s := resp.RequestStatus; !s.Result {
+		return &RequestError{Type: typ, Code: s.Code, Comment: s.Comment}
+	}
+
+	return nil
+}
+
+// Close closes the connection to the OBS WebSocket server.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) write(op int, d any) error {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(Timeout)); err != nil {
+		return err
+	}
+
+	return c.conn.WriteJSON(message{Op: op, D: b})
+}
+
+// read reads messages until a message with the given OpCode is found
+// within [Timeout], and decodes its data into v if v is not nil.
+func (c *Client) read(op int, v any) error {
+	if err := c.conn.SetReadDeadline(time.Now().Add(Timeout)); err != nil {
+		return err
+	}
+
+	for {
+		var m message
+		if err := c.conn.ReadJSON(&m); err != nil {
+			return err
+		}
+
+		if m.Op != op {
+			continue
+		}
+
+		if v == nil {
+			return nil
+		}
+
+		return json.Unmarshal(m.D, v)
+	}
+}
@@ -0,0 +1,114 @@
+package obs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func serve(t *testing.T, password string) string {
+	var up websocket.Upgrader
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := up.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteJSON(map[string]any{"op": opHello, "d": map[string]any{
+			"rpcVersion":     rpcVersion,
+			"authentication": map[string]string{"challenge": "purr", "salt": "hiss"},
+		}})
+
+		var m message
+		var id struct{ Authentication string }
+		if conn.ReadJSON(&m) != nil || json.Unmarshal(m.D, &id) != nil {
+			return
+		}
+		if id.Authentication != Authentication(password, "hiss", "purr") {
+			conn.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(4009, "Authentication failed."))
+			return
+		}
+		conn.WriteJSON(map[string]any{"op": opIdentified, "d": map[string]any{}})
+
+		for {
+			var req struct {
+				RequestType string
+				RequestID   string
+			}
+			if conn.ReadJSON(&m) != nil || json.Unmarshal(m.D, &req) != nil {
+				return
+			}
+
+			conn.WriteJSON(map[string]any{"op": opRequestResponse, "d": map[string]any{
+				"requestType": req.RequestType,
+				"requestId":   req.RequestID,
+				"requestStatus": map[string]any{
+					"result": req.RequestType == "StartRecord",
+					"code":   604,
+				},
+			}})
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func TestClient(t *testing.T) {
+	addr := serve(t, "meow")
+
+	if _, err := Dial(addr, ""); !errors.Is(err, ErrNeedPassword) {
+		t.Fatalf("got %v, want password required", err)
+	}
+
+	if _, err := Dial(addr, "woof"); err == nil {
+		t.Fatal("want authentication failure")
+	}
+
+	c, err := Dial(addr, "meow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := c.Request("StartRecord", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var rerr *RequestError
+	if err := c.Request("SetCurrentProgramScene", map[string]string{"sceneName": "Roblox"}); !errors.As(err, &rerr) || rerr.Code != 604 {
+		t.Fatalf("got %v, want request error", err)
+	}
+}
+
+func TestDialTimeout(t *testing.T) {
+	defer func(d time.Duration) { Timeout = d }(Timeout)
+	Timeout = 50 * time.Millisecond
+
+	var up websocket.Upgrader
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := up.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Never say hello.
+		conn.ReadMessage()
+	}))
+	defer s.Close()
+
+	if _, err := Dial(strings.TrimPrefix(s.URL, "http://"), ""); err == nil {
+		t.Fatal("want timeout")
+	}
+}
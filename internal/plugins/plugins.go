@@ -0,0 +1,188 @@
+// Package plugins implements running executables as plugins of a Roblox
+// session, each of which is run for every event of the session with the
+// event as JSON on its standard input.
+package plugins
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/events"
+)
+
+const (
+	// Timeout is the maximum time taken by a plugin to handle an event
+	// before it is killed.
+	Timeout = 10 * time.Second
+
+	// QueueSize is the amount of events kept while plugins are still
+	// handling an earlier event, after which events are dropped.
+	QueueSize = 64
+)
+
+// Game is the game of an Event.
+type Game struct {
+	PlaceID    string `json:"place_id,omitempty"`
+	UniverseID string `json:"universe_id,omitempty"`
+	JobID      string `json:"job_id,omitempty"`
+	Server     string `json:"server,omitempty"`
+	Address    string `json:"address,omitempty"`
+}
+
+// Event is the representation of a session event sent to plugins.
+type Event struct {
+	Event   string    `json:"event"`
+	Time    time.Time `json:"time"`
+	Binary  string    `json:"binary"`
+	Account string    `json:"account,omitempty"`
+	Game    *Game     `json:"game,omitempty"`
+	Data    string    `json:"data,omitempty"`
+}
+
+// Host runs its plugins for each of the events sent to it, one event at
+// a time in the order they were sent.
+type Host struct {
+	Plugins []string
+	Binary  string
+	Account string
+
+	queue chan Event
+	done  chan struct{}
+}
+
+// Load returns the paths to the plugins within the named directory, which
+// are its executable files not starting with a dot, sorted by name. There
+// are no plugins if the directory does not exist.
+func Load(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var plugins []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, e.Name())
+
+		// Links to executables are followed.
+		fi, err := os.Stat(path)
+		if err != nil {
+			slog.Warn("Skipping plugin", "path", path, "error", err)
+			continue
+		}
+		if !fi.Mode().IsRegular() || fi.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+
+		plugins = append(plugins, path)
+	}
+
+	return plugins, nil
+}
+
+// Start returns a new Host running the given plugins for the named Binary
+// and account, which must be closed once no events are sent to it.
+func Start(plugins []string, binary, account string) *Host {
+	h := &Host{
+		Plugins: plugins,
+		Binary:  binary,
+		Account: account,
+		queue:   make(chan Event, QueueSize),
+		done:    make(chan struct{}),
+	}
+
+	go h.run()
+
+	return h
+}
+
+// Send queues the given session event to be handled by the plugins,
+// without waiting for them to handle it.
+func (h *Host) Send(e events.Event) {
+	pe := Event{
+		Event:   e.Type.String(),
+		Time:    e.Time,
+		Binary:  h.Binary,
+		Account: h.Account,
+		Data:    e.Data,
+	}
+
+	if e.Game.PlaceID != "" {
+		pe.Game = &Game{
+			PlaceID:    e.Game.PlaceID,
+			UniverseID: e.Game.UniverseID,
+			JobID:      e.Game.JobID,
+			Server:     e.Game.Server.String(),
+			Address:    e.Game.Address,
+		}
+	}
+
+	select {
+	case h.queue <- pe:
+	default:
+		slog.Warn("Plugins are busy, dropping event", "event", pe.Event)
+	}
+}
+
+// Close waits for the plugins to handle the events still queued.
+func (h *Host) Close() {
+	close(h.queue)
+	<-h.done
+}
+
+func (h *Host) run() {
+	defer close(h.done)
+
+	for e := range h.queue {
+		input, err := json.Marshal(e)
+		if err != nil {
+			slog.Error("Could not encode plugin event", "event", e.Event, "error", err)
+			continue
+		}
+
+		for _, p := range h.Plugins {
+			if err := Run(p, e.Event, input); err != nil {
+				slog.Error("Plugin failed", "plugin", filepath.Base(p), "event", e.Event, "error", err)
+			}
+		}
+	}
+}
+
+// Run runs the named plugin for the named event with the given input, the
+// event encoded as JSON. The plugin's output is logged.
+func Run(plugin, event string, input []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, plugin, event)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.WaitDelay = time.Second
+
+	out, err := cmd.CombinedOutput()
+
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		if l := strings.TrimSpace(s.Text()); l != "" {
+			slog.Info("Plugin: "+l, "plugin", filepath.Base(plugin))
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
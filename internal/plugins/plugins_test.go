@@ -0,0 +1,99 @@
+package plugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/vinegarhq/vinegar/internal/events"
+)
+
+func writePlugin(t *testing.T, path, script string, mode os.FileMode) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	writePlugin(t, filepath.Join(dir, "b"), "", 0o755)
+	writePlugin(t, filepath.Join(dir, "a"), "", 0o755)
+	writePlugin(t, filepath.Join(dir, "readme"), "", 0o644)
+	writePlugin(t, filepath.Join(dir, ".hidden"), "", 0o755)
+	if err := os.Mkdir(filepath.Join(dir, "dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "a"), filepath.Join(dir, "c")); err != nil {
+		t.Fatal(err)
+	}
+
+	ps, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{filepath.Join(dir, "a"), filepath.Join(dir, "b"), filepath.Join(dir, "c")}
+	if !slices.Equal(ps, want) {
+		t.Errorf("plugins = %v, want %v", ps, want)
+	}
+
+	ps, err = Load(filepath.Join(dir, "missing"))
+	if err != nil || ps != nil {
+		t.Errorf("missing dir = %v, %v, want none", ps, err)
+	}
+}
+
+func TestHost(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "events")
+
+	p := filepath.Join(dir, "record")
+	writePlugin(t, p, `[ "$1" = "crashed" ] && exit 1
+cat >> `+out+`
+echo >> `+out+`
+`, 0o755)
+
+	h := Start([]string{p}, "Player", "alt")
+	h.Send(events.Event{Type: events.Updated, Data: "version-meow"})
+	h.Send(events.Event{Type: events.Joined, Game: events.Game{PlaceID: "1818", Server: events.Private}})
+	h.Send(events.Event{Type: events.Crashed})
+	h.Send(events.Event{Type: events.Left})
+	h.Close()
+
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Event
+	d := json.NewDecoder(bytes.NewReader(b))
+	for d.More() {
+		var e Event
+		if err := d.Decode(&e); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, e)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d events, want 3: %s", len(got), b)
+	}
+
+	if e := got[0]; e.Event != "updated" || e.Data != "version-meow" || e.Game != nil ||
+		e.Binary != "Player" || e.Account != "alt" {
+		t.Errorf("updated event = %+v", e)
+	}
+	if e := got[1]; e.Event != "joined" || e.Game == nil ||
+		e.Game.PlaceID != "1818" || e.Game.Server != "private" {
+		t.Errorf("joined event = %+v", e)
+	}
+	if e := got[2]; e.Event != "left" {
+		t.Errorf("last event = %s, want left", e.Event)
+	}
+}
@@ -0,0 +1,116 @@
+// Package portal implements opening files and URIs on the host through
+// xdg-desktop-portal, which is required within a Flatpak sandbox, where
+// xdg-open only has access to the sandbox's applications.
+package portal
+
+import (
+	"bufio"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// InfoPath is the path to the Flatpak metadata within a Flatpak sandbox.
+const InfoPath = "/.flatpak-info"
+
+const (
+	dest    = "org.freedesktop.portal.Desktop"
+	path    = "/org/freedesktop/portal/desktop"
+	openURI = "org.freedesktop.portal.OpenURI"
+)
+
+// FlatpakID returns the ID of the Flatpak application running the program,
+// if it is running within a Flatpak sandbox.
+func FlatpakID() (string, bool) {
+	if id := os.Getenv("FLATPAK_ID"); id != "" {
+		return id, true
+	}
+
+	f, err := os.Open(InfoPath)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if id, ok := strings.CutPrefix(s.Text(), "name="); ok {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// Flatpak reports whether the program is running within a Flatpak sandbox.
+func Flatpak() bool {
+	_, ok := FlatpakID()
+	return ok
+}
+
+func call(method string, args ...any) error {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return err
+	}
+
+	return conn.Object(dest, path).Call(openURI+"."+method, 0, args...).Err
+}
+
+// OpenURI opens the given URI with the host's preferred application.
+func OpenURI(uri string) error {
+	return call("OpenURI", "", uri, map[string]dbus.Variant{})
+}
+
+// OpenFile opens the named file with the host's preferred application,
+// or the named directory with the host's file manager. As the file is
+// passed to the portal, it does not need to be accessible by the host.
+func OpenFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return call("OpenFile", "", dbus.UnixFD(f.Fd()), map[string]dbus.Variant{})
+}
+
+// Open opens the named file, directory or URI on the host, through the
+// portal within a Flatpak sandbox, and with xdg-open otherwise or if the
+// portal failed. It does not wait for xdg-open to exit.
+func Open(name string) error {
+	if Flatpak() {
+		open := OpenFile
+		if u, err := url.Parse(name); err == nil && u.Scheme != "" {
+			open = OpenURI
+		}
+
+		err := open(name)
+		if err == nil {
+			return nil
+		}
+
+		slog.Warn("Failed to open through portal, using xdg-open", "name", name, "error", err)
+	}
+
+	cmd := XDGOpen(name)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+
+	return nil
+}
+
+// XDGOpen makes a *exec.Cmd with xdg-open as the named program.
+func XDGOpen(name string) *exec.Cmd {
+	cmd := exec.Command("xdg-open", name)
+	cmd.Stderr = os.Stderr
+	cmd.Stdout = os.Stdout
+
+	return cmd
+}
@@ -0,0 +1,129 @@
+// Package retry implements retrying of operations which may fail
+// transiently, such as network requests, with jittered exponential
+// backoff between attempts.
+package retry
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"time"
+)
+
+// Policy determines how an operation is retried.
+type Policy struct {
+	// Attempts is the maximum amount of attempts, including the first.
+	Attempts int
+
+	// Delay is the delay before the first retry, which is doubled
+	// on each retry up to MaxDelay. Delays are jittered by up to half.
+	Delay    time.Duration
+	MaxDelay time.Duration
+
+	// Retryable reports whether the given error is transient and
+	// the operation should be retried. If nil, all errors are.
+	Retryable func(error) bool
+
+	// OnRetry is called before waiting for each retry, if non-nil.
+	OnRetry func(Attempt)
+}
+
+// Attempt is a failed attempt of an operation which will be retried.
+type Attempt struct {
+	Name     string
+	Attempt  int
+	Attempts int
+	Delay    time.Duration
+	Err      error
+}
+
+var (
+	// Network is the policy used for network requests.
+	Network = Policy{Attempts: 4, Delay: time.Second, MaxDelay: 8 * time.Second}
+
+	// IPC is the policy used for connecting to local services, such
+	// as Discord and D-Bus, which may still be starting up.
+	IPC = Policy{Attempts: 3, Delay: 500 * time.Millisecond, MaxDelay: 2 * time.Second}
+)
+
+// sleep waits for the given delay or until ctx is done, and is
+// replaced in tests.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks the given error to not be retried by Do,
+// regardless of the policy.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// Do calls fn until it succeeds, returns a permanent or non-retryable
+// error, or the policy runs out of attempts; whichever error fn had
+// last returned is returned. Each retry is logged with the given name
+// of the operation.
+func Do(name string, p Policy, fn func() error) error {
+	return DoContext(context.Background(), name, p, fn)
+}
+
+// DoContext is like Do, but stops retrying once ctx is done, in which
+// case the error fn had last returned is returned.
+func DoContext(ctx context.Context, name string, p Policy, fn func() error) error {
+	delay := p.Delay
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+
+		if attempt >= p.Attempts || ctx.Err() != nil ||
+			(p.Retryable != nil && !p.Retryable(err)) {
+			return err
+		}
+
+		wait := jitter(delay)
+		slog.Warn("Retrying after failure", "op", name,
+			"attempt", attempt, "attempts", p.Attempts, "delay", wait, "error", err)
+		if p.OnRetry != nil {
+			p.OnRetry(Attempt{Name: name, Attempt: attempt, Attempts: p.Attempts, Delay: wait, Err: err})
+		}
+		if sleep(ctx, wait) != nil {
+			return err
+		}
+
+		delay = min(delay*2, p.MaxDelay)
+	}
+}
+
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
@@ -0,0 +1,80 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	var slept []time.Duration
+	sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+
+	errMeow := errors.New("meow")
+	p := Policy{Attempts: 3, Delay: time.Second, MaxDelay: time.Second}
+
+	calls := 0
+	err := Do("meow", p, func() error {
+		calls++
+		return errMeow
+	})
+	if !errors.Is(err, errMeow) || calls != 3 {
+		t.Fatalf("got %v after %d calls, want error after 3 calls", err, calls)
+	}
+
+	for _, d := range slept {
+		if d < p.Delay/2 || d > p.MaxDelay {
+			t.Errorf("delay %s is out of bounds", d)
+		}
+	}
+
+	calls = 0
+	if err := Do("meow", p, func() error {
+		if calls++; calls < 2 {
+			return errMeow
+		}
+		return nil
+	}); err != nil || calls != 2 {
+		t.Fatalf("got %v after %d calls, want success after 2 calls", err, calls)
+	}
+
+	var attempts []Attempt
+	p.OnRetry = func(a Attempt) { attempts = append(attempts, a) }
+	Do("meow", p, func() error { return errMeow })
+	if len(attempts) != 2 || attempts[1].Attempt != 2 || attempts[1].Attempts != 3 || attempts[1].Name != "meow" {
+		t.Errorf("got retries %+v, want the 2 failed attempts before the last", attempts)
+	}
+	p.OnRetry = nil
+
+	calls = 0
+	p.Retryable = func(err error) bool { return !errors.Is(err, errMeow) }
+	if err := Do("meow", p, func() error {
+		calls++
+		return errMeow
+	}); calls != 1 {
+		t.Fatalf("got %v after %d calls, want no retries", err, calls)
+	}
+
+	calls = 0
+	p.Retryable = nil
+	if err := Do("meow", p, func() error {
+		calls++
+		return Permanent(errMeow)
+	}); err != errMeow || calls != 1 {
+		t.Fatalf("got %v after %d calls, want unwrapped permanent error", err, calls)
+	}
+
+	calls = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := DoContext(ctx, "meow", p, func() error {
+		calls++
+		cancel()
+		return errMeow
+	}); !errors.Is(err, errMeow) || calls != 1 {
+		t.Fatalf("got %v after %d calls, want no retries once cancelled", err, calls)
+	}
+}
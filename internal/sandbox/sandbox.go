@@ -0,0 +1,113 @@
+// Package sandbox implements running commands within a bubblewrap sandbox,
+// with a minimal view of the host's filesystem and its own processes.
+//
+// The sandbox limits what is visible to the command by accident, and is
+// not intended as a security boundary: the sockets of the display and
+// audio servers, and the bound paths, are all shared with the host.
+package sandbox
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Options are the parts of the host a sandboxed command has access to, in
+// addition to the system files, devices and sockets required to run Wine.
+type Options struct {
+	Network bool     // Share the host's network
+	Home    bool     // Bind the home directory
+	Binds   []string // Paths bound read-write, such as the wineprefix
+	ROBinds []string // Paths bound read-only, such as the Wine installation
+}
+
+// system are the host's system directories bound read-only, the merged
+// directories of /usr are kept as symlinks.
+var (
+	system = []string{"/usr", "/etc", "/opt", "/sys", "/nix", "/gnu"}
+	merged = []string{"/bin", "/sbin", "/lib", "/lib32", "/lib64"}
+)
+
+// devices are the host's devices used by Roblox: GPUs, controllers, audio
+// and shared memory, which is used by Wine's esync and fsync.
+var devices = []string{"/dev/dri", "/dev/input", "/dev/snd", "/dev/shm"}
+
+// Args returns the bubblewrap arguments for the options.
+//
+// The sandbox has its own /tmp, and with it its own wineserver socket
+// directory: Wine ran within the sandbox starts its own wineserver, as
+// a wineserver outside of it opens files on behalf of its clients.
+func (o *Options) Args() []string {
+	args := []string{"--die-with-parent", "--unshare-pid", "--unshare-ipc", "--unshare-uts", "--unshare-cgroup-try"}
+	if !o.Network {
+		args = append(args, "--unshare-net")
+	}
+
+	for _, p := range merged {
+		if t, err := os.Readlink(p); err == nil {
+			args = append(args, "--symlink", t, p)
+		} else {
+			args = append(args, "--ro-bind-try", p, p)
+		}
+	}
+	for _, p := range system {
+		args = append(args, "--ro-bind-try", p, p)
+	}
+
+	args = append(args, "--proc", "/proc", "--dev", "/dev", "--tmpfs", "/tmp")
+
+	nvidia, _ := filepath.Glob("/dev/nvidia*")
+	for _, d := range append(devices, nvidia...) {
+		args = append(args, "--dev-bind-try", d, d)
+	}
+
+	args = append(args, "--ro-bind-try", "/tmp/.X11-unix", "/tmp/.X11-unix")
+	if xauth := os.Getenv("XAUTHORITY"); xauth != "" {
+		args = append(args, "--ro-bind-try", xauth, xauth)
+	}
+
+	if rt := os.Getenv("XDG_RUNTIME_DIR"); rt != "" {
+		sockets := []string{"pulse", "pipewire-0"}
+		if wl := os.Getenv("WAYLAND_DISPLAY"); wl != "" {
+			sockets = append(sockets, wl)
+		}
+
+		for _, s := range sockets {
+			if !filepath.IsAbs(s) {
+				s = filepath.Join(rt, s)
+			}
+			args = append(args, "--bind-try", s, s)
+		}
+	}
+
+	if o.Home {
+		if home, err := os.UserHomeDir(); err == nil {
+			args = append(args, "--bind", home, home)
+		}
+	}
+
+	for _, p := range o.ROBinds {
+		args = append(args, "--ro-bind-try", p, p)
+	}
+	for _, p := range o.Binds {
+		args = append(args, "--bind-try", p, p)
+	}
+
+	return args
+}
+
+// Wrap wraps the given command to be ran within a bubblewrap sandbox
+// with the given options.
+func Wrap(cmd *exec.Cmd, o Options) error {
+	bwrap, err := exec.LookPath("bwrap")
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{"bwrap"}, o.Args()...)
+	args = append(args, "--", cmd.Path)
+	cmd.Args = append(args, cmd.Args[1:]...)
+	cmd.Path = bwrap
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package sandbox
+
+import (
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestArgs(t *testing.T) {
+	o := Options{
+		Binds:   []string{"/meow"},
+		ROBinds: []string{"/purr"},
+	}
+
+	args := o.Args()
+	if !slices.Contains(args, "--unshare-net") {
+		t.Error("want network to be unshared")
+	}
+
+	if !slices.Contains(args, "--unshare-pid") || !slices.Contains(args, "--unshare-ipc") {
+		t.Error("want processes to be unshared")
+	}
+
+	if slices.Contains(args, "/tmp/.wine-"+strconv.Itoa(os.Getuid())) {
+		t.Error("want wineserver directory to not be shared")
+	}
+
+	for _, want := range [][]string{
+		{"--bind-try", "/meow", "/meow"},
+		{"--ro-bind-try", "/purr", "/purr"},
+	} {
+		i := slices.Index(args, want[1])
+		if i < 1 || args[i-1] != want[0] || args[i+1] != want[2] {
+			t.Errorf("want %v in %v", want, args)
+		}
+	}
+
+	o.Network = true
+	if slices.Contains(o.Args(), "--unshare-net") {
+		t.Error("want network to be shared")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	if _, err := exec.LookPath("bwrap"); err != nil {
+		t.Skip("bwrap not installed")
+	}
+
+	cmd := exec.Command("/usr/bin/true", "meow")
+	if err := Wrap(cmd, Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(cmd.Args); cmd.Args[0] != "bwrap" ||
+		cmd.Args[n-3] != "--" || cmd.Args[n-2] != "/usr/bin/true" || cmd.Args[n-1] != "meow" {
+		t.Errorf("wrapped args %v", cmd.Args)
+	}
+}
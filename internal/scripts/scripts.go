@@ -0,0 +1,324 @@
+// Package scripts implements running Starlark scripts as hooks of a Roblox
+// launch, to customize it beyond the configuration.
+//
+// A script may define the following functions, which are called with
+// a struct describing the launch or event:
+//
+//	def launch(ctx):
+//	    # ctx.binary, ctx.account, and ctx.uri: the parsed launch URI or None,
+//	    # with its scheme, launch_mode, place_id, universe_id, user_id, job_id,
+//	    # channel, task and fields.
+//	    if ctx.uri and ctx.uri.place_id == "1818":
+//	        ctx.env["DXVK_HUD"] = "fps"
+//	        ctx.fflags["DFIntTaskSchedulerTargetFps"] = 144
+//
+//	def event(e):
+//	    # e.event, such as "joined", e.time, e.game: None or its place_id,
+//	    # universe_id, job_id, server and address, and e.data.
+//	    print(e.event, e.game.place_id if e.game else "")
+//
+// Scripts are sandboxed: they cannot load other files or access the system,
+// with only the json, math, struct and time modules available, and each call
+// is cancelled after [Timeout].
+package scripts
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/events"
+	"github.com/vinegarhq/vinegar/roblox/protocol"
+	"go.starlark.net/lib/json"
+	"go.starlark.net/lib/math"
+	startime "go.starlark.net/lib/time"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// Timeout is the maximum time taken by a script to be loaded or to
+// return from a hook, after which it is cancelled.
+var Timeout = 5 * time.Second
+
+// Ext is the file name extension of scripts.
+const Ext = ".star"
+
+var (
+	ErrBadEnv   = errors.New("environment variable value must be a string")
+	ErrBadFFlag = errors.New("fflag value must be a bool, int, float or string")
+	ErrNotFunc  = errors.New("hook is not a function")
+)
+
+var predeclared = starlark.StringDict{
+	"json":   json.Module,
+	"math":   math.Module,
+	"struct": starlark.NewBuiltin("struct", starlarkstruct.Make),
+	"time":   startime.Module,
+}
+
+var fileOptions = &syntax.FileOptions{
+	Set:       true,
+	While:     true,
+	Recursion: true,
+}
+
+// Script is a loaded script, and the hooks it defines.
+type Script struct {
+	Name    string
+	globals starlark.StringDict
+}
+
+// Launch is the launch of a Binary, which may be modified by scripts.
+type Launch struct {
+	Binary  string
+	Account string
+	URI     *protocol.URI
+	Env     map[string]string
+	FFlags  map[string]any
+}
+
+// Load loads the scripts within the named directory, which are its files
+// with the [Ext] extension, sorted by name. There are no scripts if the
+// directory does not exist.
+func Load(dir string) ([]*Script, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+Ext))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	ss := make([]*Script, 0, len(paths))
+	for _, p := range paths {
+		s, err := LoadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, s)
+	}
+
+	return ss, nil
+}
+
+// LoadFile loads the named script, running its top-level statements.
+func LoadFile(path string) (*Script, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Script{Name: strings.TrimSuffix(filepath.Base(path), Ext)}
+
+	err = s.run(func(t *starlark.Thread) error {
+		g, err := starlark.ExecFileOptions(fileOptions, t, path, src, predeclared)
+		s.globals = g
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.Name, err)
+	}
+
+	// Hooks do not keep state between calls.
+	s.globals.Freeze()
+
+	return s, nil
+}
+
+// run runs fn on a new thread for the script, which is cancelled once
+// [Timeout] has passed.
+func (s *Script) run(fn func(*starlark.Thread) error) error {
+	t := &starlark.Thread{
+		Name: s.Name,
+		Print: func(_ *starlark.Thread, msg string) {
+			slog.Info("Script: "+msg, "script", s.Name)
+		},
+		Load: func(_ *starlark.Thread, module string) (starlark.StringDict, error) {
+			return nil, fmt.Errorf("cannot load %s", module)
+		},
+	}
+
+	timer := time.AfterFunc(Timeout, func() {
+		t.Cancel(fmt.Sprintf("timed out after %s", Timeout))
+	})
+	defer timer.Stop()
+
+	return fn(t)
+}
+
+// call calls the named hook of the script with the given argument, if
+// the script defines it.
+func (s *Script) call(hook string, arg starlark.Value) error {
+	v, ok := s.globals[hook]
+	if !ok {
+		return nil
+	}
+	if _, ok := v.(starlark.Callable); !ok {
+		return fmt.Errorf("%w: %s", ErrNotFunc, hook)
+	}
+
+	return s.run(func(t *starlark.Thread) error {
+		_, err := starlark.Call(t, v, starlark.Tuple{arg}, nil)
+		return err
+	})
+}
+
+// HasHook reports whether the script defines the named hook.
+func (s *Script) HasHook(hook string) bool {
+	_, ok := s.globals[hook]
+	return ok
+}
+
+// Launch calls the script's launch hook with the given launch, whose
+// environment and FFlags are replaced by those modified by the script.
+func (s *Script) Launch(l *Launch) error {
+	if !s.HasHook("launch") {
+		return nil
+	}
+
+	env := starlark.NewDict(len(l.Env))
+	for k, v := range l.Env {
+		env.SetKey(starlark.String(k), starlark.String(v))
+	}
+
+	fflags := starlark.NewDict(len(l.FFlags))
+	for k, v := range l.FFlags {
+		sv, err := toValue(v)
+		if err != nil {
+			return fmt.Errorf("fflag %s: %w", k, err)
+		}
+		fflags.SetKey(starlark.String(k), sv)
+	}
+
+	uri := starlark.Value(starlark.None)
+	if l.URI != nil {
+		uri = uriValue(l.URI)
+	}
+
+	ctx := starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"binary":  starlark.String(l.Binary),
+		"account": starlark.String(l.Account),
+		"uri":     uri,
+		"env":     env,
+		"fflags":  fflags,
+	})
+
+	if err := s.call("launch", ctx); err != nil {
+		return fmt.Errorf("%s: launch: %w", s.Name, err)
+	}
+
+	l.Env = make(map[string]string, env.Len())
+	for _, kv := range env.Items() {
+		k, _ := starlark.AsString(kv[0])
+		v, ok := starlark.AsString(kv[1])
+		if !ok {
+			return fmt.Errorf("%s: %w: %s", s.Name, ErrBadEnv, kv[0])
+		}
+		l.Env[k] = v
+	}
+
+	l.FFlags = make(map[string]any, fflags.Len())
+	for _, kv := range fflags.Items() {
+		k, _ := starlark.AsString(kv[0])
+		v, err := fromValue(kv[1])
+		if err != nil {
+			return fmt.Errorf("%s: %w: %s", s.Name, err, kv[0])
+		}
+		l.FFlags[k] = v
+	}
+
+	return nil
+}
+
+// Event calls the script's event hook with the given session event.
+func (s *Script) Event(e events.Event) error {
+	if !s.HasHook("event") {
+		return nil
+	}
+
+	game := starlark.Value(starlark.None)
+	if e.Game.PlaceID != "" {
+		game = starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+			"place_id":    starlark.String(e.Game.PlaceID),
+			"universe_id": starlark.String(e.Game.UniverseID),
+			"job_id":      starlark.String(e.Game.JobID),
+			"server":      starlark.String(e.Game.Server.String()),
+			"address":     starlark.String(e.Game.Address),
+		})
+	}
+
+	v := starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"event": starlark.String(e.Type.String()),
+		"time":  startime.Time(e.Time),
+		"game":  game,
+		"data":  starlark.String(e.Data),
+	})
+
+	if err := s.call("event", v); err != nil {
+		return fmt.Errorf("%s: event: %w", s.Name, err)
+	}
+
+	return nil
+}
+
+func uriValue(u *protocol.URI) starlark.Value {
+	fields := starlark.NewDict(len(u.Fields))
+	for _, f := range u.Fields {
+		fields.SetKey(starlark.String(f.Key), starlark.String(f.Value))
+	}
+	fields.Freeze()
+
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"scheme":      starlark.String(u.Scheme),
+		"launch_mode": starlark.String(u.LaunchMode),
+		"place_id":    starlark.String(u.PlaceID),
+		"universe_id": starlark.String(u.UniverseID),
+		"user_id":     starlark.String(u.UserID),
+		"job_id":      starlark.String(u.JobID),
+		"channel":     starlark.String(u.Channel),
+		"task":        starlark.String(u.Task),
+		"fields":      fields,
+	})
+}
+
+// toValue returns the Starlark value of the given FFlag value, as decoded
+// from the configuration.
+func toValue(v any) (starlark.Value, error) {
+	switch v := v.(type) {
+	case bool:
+		return starlark.Bool(v), nil
+	case int:
+		return starlark.MakeInt(v), nil
+	case int64:
+		return starlark.MakeInt64(v), nil
+	case float64:
+		return starlark.Float(v), nil
+	case string:
+		return starlark.String(v), nil
+	}
+
+	return nil, ErrBadFFlag
+}
+
+func fromValue(v starlark.Value) (any, error) {
+	switch v := v.(type) {
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.Int:
+		i, ok := v.Int64()
+		if !ok {
+			return nil, ErrBadFFlag
+		}
+		return i, nil
+	case starlark.Float:
+		return float64(v), nil
+	case starlark.String:
+		return string(v), nil
+	}
+
+	return nil, ErrBadFFlag
+}
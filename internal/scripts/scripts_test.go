@@ -0,0 +1,143 @@
+package scripts
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/events"
+	"github.com/vinegarhq/vinegar/roblox/protocol"
+)
+
+func writeScript(t *testing.T, dir, name, src string) string {
+	t.Helper()
+
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func TestLaunch(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "a.star", `
+def launch(ctx):
+    if ctx.binary != "Player" or ctx.uri.place_id != "1818":
+        fail("bad launch", ctx)
+    ctx.env["DXVK_HUD"] = "fps"
+    ctx.env.pop("MEOW")
+    ctx.fflags["DFIntTaskSchedulerTargetFps"] = 144
+`)
+	writeScript(t, dir, "b.star", `
+def launch(ctx):
+    ctx.fflags["FFlagDebugGraphicsPreferVulkan"] = ctx.env["DXVK_HUD"] == "fps"
+`)
+	writeScript(t, dir, "readme.txt", "not a script")
+
+	ss, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ss) != 2 || ss[0].Name != "a" || ss[1].Name != "b" {
+		t.Fatalf("loaded %v, want a and b", ss)
+	}
+
+	l := Launch{
+		Binary: "Player",
+		URI:    &protocol.URI{PlaceID: "1818"},
+		Env:    map[string]string{"MEOW": "1", "KEEP": "1"},
+		FFlags: map[string]any{"FIntDebugForceMSAASamples": int64(4)},
+	}
+	for _, s := range ss {
+		if err := s.Launch(&l); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(l.Env) != 2 || l.Env["DXVK_HUD"] != "fps" || l.Env["KEEP"] != "1" {
+		t.Errorf("env = %v", l.Env)
+	}
+	if len(l.FFlags) != 3 || l.FFlags["DFIntTaskSchedulerTargetFps"] != int64(144) ||
+		l.FFlags["FIntDebugForceMSAASamples"] != int64(4) ||
+		l.FFlags["FFlagDebugGraphicsPreferVulkan"] != true {
+		t.Errorf("fflags = %v", l.FFlags)
+	}
+}
+
+func TestLaunchBadValue(t *testing.T) {
+	s, err := LoadFile(writeScript(t, t.TempDir(), "bad.star", `
+def launch(ctx):
+    ctx.fflags["FFlagMeow"] = [1]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Launch(&Launch{}); !errors.Is(err, ErrBadFFlag) {
+		t.Errorf("launch = %v, want %v", err, ErrBadFFlag)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	s, err := LoadFile(writeScript(t, t.TempDir(), "event.star", `
+def event(e):
+    if e.event == "joined" and e.game.server != "private":
+        fail("bad game", e.game)
+    if e.event == "left" and e.game != None:
+        fail("bad game", e.game)
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range []events.Event{
+		{Type: events.Joined, Time: time.Now(), Game: events.Game{PlaceID: "1818", Server: events.Private}},
+		{Type: events.Left},
+	} {
+		if err := s.Event(e); err != nil {
+			t.Errorf("event %s: %s", e.Type, err)
+		}
+	}
+
+	if err := s.Event(events.Event{Type: events.Joined, Game: events.Game{PlaceID: "1818"}}); err == nil {
+		t.Error("expected error from failing hook")
+	}
+}
+
+func TestSandbox(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := LoadFile(writeScript(t, dir, "load.star", `load("other.star", "x")`)); err == nil {
+		t.Error("expected load to fail")
+	}
+
+	s, err := LoadFile(writeScript(t, dir, "frozen.star", `
+n = {}
+def event(e):
+    n["count"] = 1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Event(events.Event{Type: events.Left}); err == nil {
+		t.Error("expected state kept between calls to fail")
+	}
+
+	defer func(d time.Duration) { Timeout = d }(Timeout)
+	Timeout = 10 * time.Millisecond
+
+	_, err = LoadFile(writeScript(t, dir, "loop.star", `
+def spin():
+    while True:
+        pass
+spin()
+`))
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("loop = %v, want timeout", err)
+	}
+}
@@ -0,0 +1,144 @@
+// Package session implements a socket used to hand over the arguments
+// of a Binary launch to an already running Vinegar Binary session.
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+)
+
+// DialTimeout is the time to wait for a running session to accept
+// a handed over launch.
+const DialTimeout = 2 * time.Second
+
+var ErrRunning = errors.New("session is already running")
+
+// HandlerFunc is the callback type for a handed over launch's arguments.
+type HandlerFunc func(args []string) error
+
+type request struct {
+	Args []string `json:"args"`
+}
+
+type response struct {
+	Error string `json:"error,omitempty"`
+}
+
+// Listener is a listening session socket.
+type Listener struct {
+	net.Listener
+	path string
+}
+
+// SocketPath returns the path to the named session's socket.
+func SocketPath(name string) string {
+	return filepath.Join(dirs.Runtime, strings.ToLower(name)+".sock")
+}
+
+// Listen creates the named session's socket. If a socket is present but
+// no session is accepting on it, it is assumed to be stale and replaced.
+func Listen(name string) (*Listener, error) {
+	if err := dirs.Mkdirs(dirs.Runtime); err != nil {
+		return nil, err
+	}
+
+	p := SocketPath(name)
+
+	if c, err := net.DialTimeout("unix", p, DialTimeout); err == nil {
+		c.Close()
+		return nil, ErrRunning
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	l, err := net.Listen("unix", p)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Listening for handed over launches", "path", p)
+
+	return &Listener{
+		Listener: l,
+		path:     p,
+	}, nil
+}
+
+// Serve accepts handed over launches on the Listener, calling fn for each of
+// them, until the Listener is closed.
+func (l *Listener) Serve(fn HandlerFunc) {
+	for {
+		c, err := l.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to accept handed over launch", "error", err)
+			continue
+		}
+
+		go handle(c, fn)
+	}
+}
+
+func handle(c net.Conn, fn HandlerFunc) {
+	defer c.Close()
+
+	var req request
+	var resp response
+
+	if err := json.NewDecoder(c).Decode(&req); err != nil {
+		resp.Error = err.Error()
+	} else if err := fn(req.Args); err != nil {
+		resp.Error = err.Error()
+	}
+
+	if err := json.NewEncoder(c).Encode(&resp); err != nil {
+		slog.Error("Failed to respond to handed over launch", "error", err)
+	}
+}
+
+// Close closes the Listener and removes its socket.
+func (l *Listener) Close() error {
+	defer os.Remove(l.path)
+	return l.Listener.Close()
+}
+
+// Send hands over the given arguments to the named running session.
+func Send(name string, args []string) error {
+	c, err := net.DialTimeout("unix", SocketPath(name), DialTimeout)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err := c.SetDeadline(time.Now().Add(DialTimeout)); err != nil {
+		return err
+	}
+
+	if err := json.NewEncoder(c).Encode(&request{Args: args}); err != nil {
+		return err
+	}
+
+	var resp response
+	if err := json.NewDecoder(c).Decode(&resp); err != nil {
+		return err
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("session: %s", resp.Error)
+	}
+
+	return nil
+}
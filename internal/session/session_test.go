@@ -0,0 +1,49 @@
+package session
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+)
+
+func TestSession(t *testing.T) {
+	dirs.Runtime = t.TempDir()
+
+	if err := Send("meow", nil); err == nil {
+		t.Fatal("want error on no running session")
+	}
+
+	l, err := Listen("meow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if _, err := Listen("meow"); !errors.Is(err, ErrRunning) {
+		t.Fatal("want session already running")
+	}
+
+	got := make(chan []string, 1)
+	go l.Serve(func(args []string) error {
+		if args[0] == "hiss" {
+			return errors.New("no hissing")
+		}
+
+		got <- args
+		return nil
+	})
+
+	if err := Send("meow", []string{"purr"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if args := <-got; !reflect.DeepEqual(args, []string{"purr"}) {
+		t.Fatalf("got %v, want handed over args", args)
+	}
+
+	if err := Send("meow", []string{"hiss"}); err == nil {
+		t.Fatal("want handler error")
+	}
+}
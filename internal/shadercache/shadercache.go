@@ -0,0 +1,110 @@
+// Package shadercache implements routines to relocate the shader caches of
+// DXVK, VKD3D-Proton and the graphics drivers to a directory for each
+// wineprefix, and to seed them from a pre-built shader cache.
+package shadercache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/internal/dirs"
+)
+
+// Caches maps each shader cache kept in a shader cache directory to the
+// environment variable which relocates it.
+var Caches = map[string]string{
+	"dxvk":   "DXVK_STATE_CACHE_PATH",
+	"vkd3d":  "VKD3D_SHADER_CACHE_PATH",
+	"mesa":   "MESA_SHADER_CACHE_DIR",
+	"nvidia": "__GL_SHADER_DISK_CACHE_PATH",
+}
+
+// Dir returns the shader cache directory of the named wineprefix.
+func Dir(prefix string) string {
+	return filepath.Join(dirs.Shaders, prefix)
+}
+
+// Env returns the environment variables which relocate the shader caches
+// to the shader cache directory dir.
+func Env(dir string) map[string]string {
+	env := map[string]string{
+		"__GL_SHADER_DISK_CACHE":              "1",
+		"__GL_SHADER_DISK_CACHE_SKIP_CLEANUP": "1",
+	}
+
+	for name, key := range Caches {
+		env[key] = filepath.Join(dir, name)
+	}
+
+	return env
+}
+
+// Seed extracts the named gzip-compressed tarball of shader caches into
+// the shader cache directory dir, with each cache kept as a top-level
+// directory named after the cache, as in [Caches].
+func Seed(name, dir string) error {
+	slog.Info("Seeding shader cache", "file", name, "dir", dir)
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	tr := tar.NewReader(zr)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		rel := filepath.Clean(filepath.FromSlash(hdr.Name))
+		cache, _, _ := strings.Cut(rel, string(filepath.Separator))
+		if _, ok := Caches[cache]; !ok || !filepath.IsLocal(rel) {
+			slog.Warn("Skipping unknown shader cache file", "file", hdr.Name)
+			continue
+		}
+
+		if err := extractFile(tr, filepath.Join(dir, rel)); err != nil {
+			return fmt.Errorf("%s: %w", hdr.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func extractFile(r io.Reader, name string) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
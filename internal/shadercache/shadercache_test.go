@@ -0,0 +1,51 @@
+package shadercache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSeed(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(t.TempDir(), "shaders.tar.gz")
+
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zw := gzip.NewWriter(f)
+	tw := tar.NewWriter(zw)
+	for _, n := range []string{"dxvk/RobloxPlayerBeta.dxvk-cache", "meow/purr.bin", "../hiss.bin"} {
+		if err := tw.WriteHeader(&tar.Header{Name: n, Mode: 0o644, Size: 4}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte("meow")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, c := range []interface{ Close() error }{tw, zw, f} {
+		if err := c.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := Seed(name, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "dxvk", "RobloxPlayerBeta.dxvk-cache")); err != nil {
+		t.Error("expected seeded dxvk cache")
+	}
+
+	es, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 1 {
+		t.Errorf("seeded %d caches, want unknown caches skipped", len(es))
+	}
+}
@@ -1,49 +1,170 @@
 package state
 
 import (
+	"errors"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"slices"
+	"sort"
 
 	"github.com/vinegarhq/vinegar/internal/dirs"
 )
 
-// CleanPackages removes all cached package downloads in dirs.Downloads
-// that aren't held in the state's Binary packages.
-func (s *State) CleanPackages() error {
-	return walkDirExcluded(dirs.Downloads, s.Packages(), func(path string) error {
-		slog.Info("Cleaning up unused cached package", "path", path)
-		return os.Remove(path)
-	})
+// Policy is the retention policy of the cached package downloads and
+// the version directories, used by Clean.
+type Policy struct {
+	// KeepVersions is the amount of versions kept for each Binary,
+	// including its installed version.
+	KeepVersions int
+
+	// MaxCacheSize is the maximum size in bytes of the cached package
+	// downloads, past which the oldest packages are removed. If zero,
+	// the cached package downloads are not limited by size.
+	MaxCacheSize int64
 }
 
-// CleanPackages removes all Binary versions that aren't
-// held in the state's Binary packages.
-func (s *State) CleanVersions() error {
-	return walkDirExcluded(dirs.Versions, s.Versions(), func(path string) error {
-		slog.Info("Cleaning up unused version directory", "path", path)
-		return os.RemoveAll(path)
-	})
+// Removal is a cached package download or version directory
+// removed by Clean, with its size in bytes.
+type Removal struct {
+	Path string
+	Size int64
 }
 
-// walkDirExcluded will walk the file tree located at dir, calling
-// onExcluded for every file or directory that does not have a name in included.
-func walkDirExcluded(dir string, included []string, onExcluded func(string) error) error {
-	files, err := os.ReadDir(dir)
+type entry struct {
+	Removal
+	mod  fs.FileInfo
+	kept bool
+}
+
+// Clean removes the version directories in dirs.Versions and the cached
+// package downloads in dirs.Downloads that aren't held in the state,
+// following the retention policy, and returns what was removed. When
+// dryRun is set, nothing is removed.
+//
+// Previously installed versions of each Binary are kept up to the
+// policy's KeepVersions, after which they are forgotten by the state.
+func (s *State) Clean(p Policy, dryRun bool) ([]Removal, error) {
+	var rs []Removal
+
+	vers := s.Versions()
+	for _, bs := range []*Binary{&s.Player, &s.Studio} {
+		keep := min(max(p.KeepVersions-1, 0), len(bs.History))
+		vers = append(vers, bs.History[:keep]...)
+
+		if !dryRun {
+			bs.History = bs.History[:keep]
+		}
+	}
+
+	vs, err := entries(dirs.Versions, vers)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, file := range files {
-		if slices.Contains(included, file.Name()) {
+	ps, err := entries(dirs.Downloads, s.Packages())
+	if err != nil {
+		return nil, err
+	}
+
+	if p.MaxCacheSize > 0 {
+		var size int64
+		for _, e := range ps {
+			if e.kept {
+				size += e.Size
+			}
+		}
+
+		// Remove the oldest packages first, packages downloaded ahead
+		// of an update are required to install the update.
+		sort.Slice(ps, func(i, j int) bool {
+			return ps[i].mod.ModTime().Before(ps[j].mod.ModTime())
+		})
+
+		prefetched := append(slices.Clone(s.Player.Prefetched), s.Studio.Prefetched...)
+		for i := range ps {
+			if size <= p.MaxCacheSize {
+				break
+			}
+
+			if ps[i].kept && !slices.Contains(prefetched, ps[i].mod.Name()) {
+				ps[i].kept = false
+				size -= ps[i].Size
+			}
+		}
+	}
+
+	for _, e := range append(vs, ps...) {
+		if e.kept {
 			continue
 		}
+		rs = append(rs, e.Removal)
 
-		if err := onExcluded(filepath.Join(dir, file.Name())); err != nil {
-			return err
+		if dryRun {
+			continue
+		}
+
+		slog.Info("Cleaning up unused file", "path", e.Path, "size", e.Size)
+
+		if err := os.RemoveAll(e.Path); err != nil {
+			return rs, err
 		}
 	}
 
-	return nil
+	return rs, nil
+}
+
+// entries returns the files and directories within dir, which are kept
+// if they are named in included.
+func entries(dir string, included []string) ([]entry, error) {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	es := make([]entry, 0, len(files))
+	for _, file := range files {
+		i, err := file.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		path := filepath.Join(dir, file.Name())
+		size, err := diskSize(path)
+		if err != nil {
+			return nil, err
+		}
+
+		es = append(es, entry{
+			Removal: Removal{Path: path, Size: size},
+			mod:     i,
+			kept:    slices.Contains(included, file.Name()),
+		})
+	}
+
+	return es, nil
+}
+
+// diskSize returns the total size of the named file or directory.
+func diskSize(path string) (int64, error) {
+	var size int64
+
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		i, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		size += i.Size()
+		return nil
+	})
+
+	return size, err
 }
@@ -0,0 +1,40 @@
+package state
+
+import (
+	"time"
+)
+
+// PlaytimeDays is the amount of days of which a Binary's playtime
+// is kept in the state.
+const PlaytimeDays = 7
+
+// Played returns the time the Binary was played on the day of t.
+func (bs *Binary) Played(t time.Time) time.Duration {
+	return bs.Playtime[t.Format(time.DateOnly)]
+}
+
+// AddPlaytime adds the time played from start until end to the days it
+// was played on, removing the days older than PlaytimeDays before end.
+func (bs *Binary) AddPlaytime(start, end time.Time) {
+	if bs.Playtime == nil {
+		bs.Playtime = make(map[string]time.Duration)
+	}
+
+	for start.Before(end) {
+		y, m, d := start.Date()
+		next := time.Date(y, m, d+1, 0, 0, 0, 0, start.Location())
+		if next.After(end) {
+			next = end
+		}
+
+		bs.Playtime[start.Format(time.DateOnly)] += next.Sub(start)
+		start = next
+	}
+
+	oldest := end.AddDate(0, 0, -PlaytimeDays+1).Format(time.DateOnly)
+	for day := range bs.Playtime {
+		if day < oldest {
+			delete(bs.Playtime, day)
+		}
+	}
+}
@@ -5,24 +5,93 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/mods"
 	"github.com/vinegarhq/vinegar/roblox/bootstrapper"
+	"github.com/vinegarhq/vinegar/sysinfo"
 )
 
 var path = filepath.Join(dirs.Data, "state.json")
 
-// BinaryState is used track a Binary's deployment and wineprefix.
-type Binary struct {
+// Prefix is used to track a Binary's wineprefix.
+//
+// Registry holds the registry values set by Vinegar, keyed by their
+// key and value name, to only set them when changed. Passthrough holds the
+// links to host directories made within the wineprefix, relative to it, to
+// remove them once no longer passed through.
+type Prefix struct {
 	DxvkVersion string
-	Version     string
-	Packages    []string
+	Registry    map[string]string `json:",omitempty"`
+	Passthrough []string          `json:",omitempty"`
+}
+
+// Deployment is an installed deployment of a channel, which is kept
+// when switching away from its channel.
+type Deployment struct {
+	Version  string
+	Packages []string
+	Mods     mods.Applied `json:",omitempty"`
+	Verified time.Time
+}
+
+// BinaryState is used track a Binary's deployment, its applied mods
+// and wineprefix.
+//
+// Additional accounts each have their own wineprefix, tracked in Accounts.
+type Binary struct {
+	Prefix
+	Version  string
+	Channel  string `json:",omitempty"`
+	Packages []string
+	Mods     mods.Applied       `json:",omitempty"`
+	Accounts map[string]*Prefix `json:",omitempty"`
+
+	// Deployments holds the installed deployments of the channels
+	// other than the installed version's Channel, keyed by their channel,
+	// to be reused when switching back to them.
+	Deployments map[string]Deployment `json:",omitempty"`
+
+	// ModsDisabled disables applying mods, including the overlay.
+	ModsDisabled bool `json:",omitempty"`
+
+	// Packages downloaded ahead of an update, and the version they
+	// were extracted to, which are kept from being cleaned up until
+	// the update is installed.
+	Prefetched        []string `json:",omitempty"`
+	PrefetchedVersion string   `json:",omitempty"`
+
+	// Previously installed versions, most recent first.
+	History []string `json:",omitempty"`
+
+	// When the installed version was last verified to be the latest
+	// version of the channel it was verified with.
+	Verified        time.Time
+	VerifiedChannel string `json:",omitempty"`
+
+	SetupTimings []SetupTiming `json:",omitempty"`
+
+	// Playtime holds the time played on each of the last
+	// PlaytimeDays days, keyed by their date.
+	Playtime map[string]time.Duration `json:",omitempty"`
+
+	// Channels holds whether each channel requested by Roblox
+	// was accepted to be switched to.
+	Channels map[string]bool `json:",omitempty"`
+
+	// Runner is the installed runner used when no wine root is
+	// configured, with the system's Wine being used if empty.
+	Runner string `json:",omitempty"`
 }
 
 // State holds various details about Vinegar's current state.
 type State struct {
 	Player Binary
 	Studio Binary
+
+	// Hardware caches the detected hardware information of the host.
+	Hardware *sysinfo.Hardware `json:",omitempty"`
 }
 
 // Load returns the state file's contents in State form.
@@ -44,6 +113,14 @@ func Load() (State, error) {
 		return State{}, err
 	}
 
+	// States from before the installed version's channel was kept only
+	// have the channel it was last verified with.
+	for _, bs := range []*Binary{&state.Player, &state.Studio} {
+		if bs.Channel == "" && bs.Version != "" {
+			bs.Channel = bs.VerifiedChannel
+		}
+	}
+
 	return state, nil
 }
 
@@ -71,18 +148,90 @@ func (s *State) Save() error {
 	return nil
 }
 
-// Add formats the given package manifest into a Binary form.
+// Add formats the given package manifest into a Binary form, keeping
+// the previously installed version in History.
+//
+// As the deployment is installed to a new version directory, the applied
+// mods are reset.
 func (bs *Binary) Add(pm *bootstrapper.PackageManifest) {
+	if bs.Version != "" && bs.Version != pm.Deployment.GUID {
+		bs.History = append([]string{bs.Version}, bs.History...)
+	}
+
 	bs.Version = pm.Deployment.GUID
+	bs.Channel = pm.Deployment.Channel
+	bs.Mods = nil
+	bs.Prefetched = nil
+	bs.PrefetchedVersion = ""
 	for _, pkg := range pm.Packages {
 		bs.Packages = append(bs.Packages, pkg.Checksum)
 	}
 }
 
+// SwitchChannel keeps the installed deployment in Deployments if the named
+// channel isn't its channel, and replaces it with the channel's kept
+// deployment, reporting whether there was one. Without one, there is
+// no installed deployment until one is added.
+//
+// Version directories are named after their deployment's GUID, which
+// is unique across channels, and are left as-is.
+func (bs *Binary) SwitchChannel(channel string) bool {
+	if bs.Channel == channel {
+		return false
+	}
+
+	if bs.Version != "" {
+		if bs.Deployments == nil {
+			bs.Deployments = make(map[string]Deployment)
+		}
+		bs.Deployments[bs.Channel] = Deployment{
+			Version:  bs.Version,
+			Packages: bs.Packages,
+			Mods:     bs.Mods,
+			Verified: bs.Verified,
+		}
+	}
+
+	d, ok := bs.Deployments[channel]
+	delete(bs.Deployments, channel)
+
+	bs.Channel = channel
+	bs.Version = d.Version
+	bs.Packages = d.Packages
+	bs.Mods = d.Mods
+	bs.Verified = d.Verified
+	bs.VerifiedChannel = channel
+
+	return ok
+}
+
+// AccountPrefix returns the wineprefix state of the named account, the
+// Binary's own wineprefix state is used for the default account, which
+// has no name.
+func (bs *Binary) AccountPrefix(name string) *Prefix {
+	if name == "" {
+		return &bs.Prefix
+	}
+
+	if bs.Accounts == nil {
+		bs.Accounts = make(map[string]*Prefix)
+	}
+
+	if _, ok := bs.Accounts[name]; !ok {
+		bs.Accounts[name] = new(Prefix)
+	}
+
+	return bs.Accounts[name]
+}
+
 // Packages returns all the available Binary packages from the state.
 func (s *State) Packages() (pkgs []string) {
 	for _, bs := range []Binary{s.Player, s.Studio} {
 		pkgs = append(pkgs, bs.Packages...)
+		pkgs = append(pkgs, bs.Prefetched...)
+		for _, d := range bs.Deployments {
+			pkgs = append(pkgs, d.Packages...)
+		}
 	}
 
 	return
@@ -92,6 +241,12 @@ func (s *State) Packages() (pkgs []string) {
 func (s *State) Versions() (vers []string) {
 	for _, bs := range []Binary{s.Player, s.Studio} {
 		vers = append(vers, bs.Version)
+		if bs.PrefetchedVersion != "" {
+			vers = append(vers, bs.PrefetchedVersion)
+		}
+		for _, d := range bs.Deployments {
+			vers = append(vers, d.Version)
+		}
 	}
 
 	return
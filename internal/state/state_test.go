@@ -2,9 +2,12 @@ package state
 
 import (
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/vinegarhq/vinegar/internal/dirs"
 	"github.com/vinegarhq/vinegar/roblox"
 	"github.com/vinegarhq/vinegar/roblox/bootstrapper"
 )
@@ -35,6 +38,8 @@ func TestState(t *testing.T) {
 		}},
 	})
 
+	s.Player.AccountPrefix("alt").DxvkVersion = "2.3"
+
 	if err := s.Save(); err != nil {
 		t.Fatal(err)
 	}
@@ -51,4 +56,146 @@ func TestState(t *testing.T) {
 	if !reflect.DeepEqual(sExp.Packages(), []string{"meow"}) {
 		t.Fatal("want meow packages")
 	}
+
+	if sExp.Player.AccountPrefix("alt").DxvkVersion != "2.3" {
+		t.Fatal("want account prefix stored state")
+	}
+
+	if sExp.Player.AccountPrefix("") != &sExp.Player.Prefix {
+		t.Fatal("want default account binary prefix state")
+	}
+}
+
+func TestSetupTimings(t *testing.T) {
+	var bs Binary
+
+	for i := 0; i < MaxSetupTimings+5; i++ {
+		bs.AddSetupTiming(SetupTiming{
+			Version: "version-meow",
+			Phases: []Phase{
+				{"fetch", time.Second},
+				{"dxvk", time.Duration(i)},
+			},
+		})
+	}
+
+	if len(bs.SetupTimings) != MaxSetupTimings {
+		t.Fatalf("got %d setup timings, want %d", len(bs.SetupTimings), MaxSetupTimings)
+	}
+
+	st := bs.SetupTimings[0]
+	if d, _ := st.Phase("dxvk"); d != 5 {
+		t.Error("expected oldest setup timings to be removed")
+	}
+
+	if _, ok := st.Phase("download"); ok {
+		t.Error("expected download phase to be absent")
+	}
+
+	if st.Total() != time.Second+5 {
+		t.Error("expected total of phases")
+	}
+}
+
+func TestClean(t *testing.T) {
+	dirs.Versions = t.TempDir()
+	dirs.Downloads = t.TempDir()
+
+	for _, v := range []string{"version-new", "version-old", "version-older"} {
+		if err := os.Mkdir(filepath.Join(dirs.Versions, v), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, pkg := range []string{"meow", "purr", "hiss"} {
+		path := filepath.Join(dirs.Downloads, pkg)
+		if err := os.WriteFile(path, make([]byte, 10), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		mod := time.Now().Add(time.Duration(i) * time.Hour)
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := State{Player: Binary{
+		Version:  "version-new",
+		Packages: []string{"meow", "purr"},
+		History:  []string{"version-old", "version-older"},
+	}}
+	p := Policy{KeepVersions: 2, MaxCacheSize: 10}
+
+	rs, err := s.Clean(p, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, r := range rs {
+		got = append(got, filepath.Base(r.Path))
+	}
+	if want := []string{"version-older", "meow", "hiss"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("dry run removals %v, want %v", got, want)
+	}
+
+	if _, err := s.Clean(p, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(s.Player.History, []string{"version-old"}) {
+		t.Error("expected forgotten versions to be removed from history")
+	}
+
+	if _, err := os.Stat(filepath.Join(dirs.Versions, "version-older")); err == nil {
+		t.Error("expected older version to be removed")
+	}
+}
+
+func TestSwitchChannel(t *testing.T) {
+	bs := Binary{Version: "version-meow", Packages: []string{"meow"}}
+
+	if bs.SwitchChannel("") {
+		t.Error("want no switch to the installed channel")
+	}
+
+	if bs.SwitchChannel("zflag") {
+		t.Error("want no kept deployment of zflag")
+	}
+	if bs.Version != "" || bs.Channel != "zflag" {
+		t.Fatalf("installed %s %q, want nothing installed on zflag", bs.Version, bs.Channel)
+	}
+
+	bs.Version = "version-purr"
+	if !bs.SwitchChannel("") {
+		t.Fatal("want kept deployment of the default channel")
+	}
+	if bs.Version != "version-meow" || !reflect.DeepEqual(bs.Packages, []string{"meow"}) {
+		t.Errorf("installed %s %v, want kept deployment", bs.Version, bs.Packages)
+	}
+
+	if d, ok := bs.Deployments["zflag"]; !ok || d.Version != "version-purr" {
+		t.Errorf("want zflag deployment kept, got %v", bs.Deployments)
+	}
+	if _, ok := bs.Deployments[""]; ok {
+		t.Error("want installed deployment removed from the kept deployments")
+	}
+}
+
+func TestAddPlaytime(t *testing.T) {
+	var bs Binary
+	start := time.Date(2024, 1, 1, 23, 30, 0, 0, time.UTC)
+
+	bs.AddPlaytime(start.AddDate(0, 0, -PlaytimeDays), start.AddDate(0, 0, -PlaytimeDays).Add(time.Minute))
+	bs.AddPlaytime(start, start.Add(time.Hour))
+
+	if got := bs.Played(start); got != 30*time.Minute {
+		t.Errorf("played %s on the first day, want 30m", got)
+	}
+	if got := bs.Played(start.Add(time.Hour)); got != 30*time.Minute {
+		t.Errorf("played %s on the next day, want 30m", got)
+	}
+	if len(bs.Playtime) != 2 {
+		t.Errorf("playtime %v, want the oldest day removed", bs.Playtime)
+	}
 }
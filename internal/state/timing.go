@@ -0,0 +1,55 @@
+package state
+
+import (
+	"time"
+)
+
+// MaxSetupTimings is the amount of a Binary's most recent setup
+// timings kept in the state.
+const MaxSetupTimings = 50
+
+// Phase is the time taken by a phase of a Binary's setup.
+type Phase struct {
+	Name     string
+	Duration time.Duration
+}
+
+// SetupTiming is a record of the time taken by each phase of a Binary's
+// setup, measured locally and never sent anywhere.
+type SetupTiming struct {
+	Time    time.Time
+	Version string
+	Updated bool
+	Phases  []Phase
+}
+
+// Total returns the total time taken by the setup's phases.
+func (st *SetupTiming) Total() (d time.Duration) {
+	for _, p := range st.Phases {
+		d += p.Duration
+	}
+
+	return
+}
+
+// Phase returns the time taken by the named phase, and whether the setup
+// had performed the phase.
+func (st *SetupTiming) Phase(name string) (time.Duration, bool) {
+	for _, p := range st.Phases {
+		if p.Name == name {
+			return p.Duration, true
+		}
+	}
+
+	return 0, false
+}
+
+// AddSetupTiming adds the given setup timing to the Binary's setup timings,
+// removing the oldest timings past MaxSetupTimings.
+func (bs *Binary) AddSetupTiming(st SetupTiming) {
+	bs.SetupTimings = append(bs.SetupTimings, st)
+
+	if n := len(bs.SetupTimings) - MaxSetupTimings; n > 0 {
+		bs.SetupTimings = bs.SetupTimings[n:]
+	}
+}
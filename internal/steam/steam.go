@@ -0,0 +1,184 @@
+// Package steam implements routines to add non-Steam game shortcuts,
+// along with their artwork, to the local Steam users.
+package steam
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/vinegarhq/vinegar/internal/dirs"
+	"github.com/vinegarhq/vinegar/internal/netutil"
+)
+
+var ErrNoUsers = errors.New("no steam users found")
+
+// Roots are the directories a Steam installation may be located in.
+var Roots = []string{
+	filepath.Join(xdg.Home, ".steam", "steam"),
+	filepath.Join(xdg.DataHome, "Steam"),
+	filepath.Join(xdg.Home, ".var", "app", "com.valvesoftware.Steam", ".local", "share", "Steam"),
+}
+
+// Artwork types of a shortcut, as suffixes of the artwork's
+// file name in a Steam user's grid directory.
+const (
+	ArtworkWide     = ""
+	ArtworkPortrait = "p"
+	ArtworkHero     = "_hero"
+	ArtworkLogo     = "_logo"
+	ArtworkIcon     = "_icon"
+)
+
+// Shortcut is a representation of a non-Steam game shortcut.
+type Shortcut struct {
+	AppName       string
+	Exe           string
+	StartDir      string
+	Icon          string
+	LaunchOptions string
+	Tags          []string
+
+	// Artwork maps artwork types to the URL of their image.
+	Artwork map[string]string
+}
+
+// AppID returns the Shortcut's app ID, which Steam derives from
+// the Shortcut's executable and name.
+func (s *Shortcut) AppID() uint32 {
+	return crc32.ChecksumIEEE([]byte(strconv.Quote(s.Exe)+s.AppName)) | 0x80000000
+}
+
+func (s *Shortcut) nodes(prev Nodes) Nodes {
+	ns := append(Nodes{}, prev...)
+	tags := Nodes{}
+	for i, t := range s.Tags {
+		tags = append(tags, Node{strconv.Itoa(i), t})
+	}
+
+	ns.Set("appid", s.AppID())
+	ns.Set("AppName", s.AppName)
+	ns.Set("Exe", strconv.Quote(s.Exe))
+	ns.Set("StartDir", strconv.Quote(s.StartDir))
+	ns.Set("icon", s.Icon)
+	ns.Set("LaunchOptions", s.LaunchOptions)
+	ns.Set("AllowOverlay", uint32(1))
+	ns.Set("AllowDesktopConfig", uint32(1))
+	ns.Set("tags", tags)
+
+	return ns
+}
+
+// Users returns the config directories of the local Steam users.
+func Users() ([]string, error) {
+	var users []string
+
+	for _, r := range Roots {
+		m, err := filepath.Glob(filepath.Join(r, "userdata", "*", "config"))
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, m...)
+	}
+
+	if len(users) == 0 {
+		return nil, ErrNoUsers
+	}
+
+	return users, nil
+}
+
+// AddShortcut adds the given shortcut to the shortcuts of the Steam user with
+// the given config directory, replacing the shortcut with the same app ID if
+// present, and downloads the shortcut's artwork to the user's grid directory.
+//
+// Steam must not be running, as it overwrites the shortcuts on exit.
+func AddShortcut(user string, s *Shortcut) error {
+	path := filepath.Join(user, "shortcuts.vdf")
+
+	root, err := readShortcuts(path)
+	if err != nil {
+		return fmt.Errorf("read shortcuts: %w", err)
+	}
+
+	v, _ := root.Get("shortcuts")
+	scs, _ := v.(Nodes)
+
+	found := false
+	for i, sc := range scs {
+		ns, _ := sc.Value.(Nodes)
+		if id, _ := ns.Get("appid"); id == s.AppID() {
+			scs[i].Value = s.nodes(ns)
+			found = true
+		}
+	}
+
+	if !found {
+		scs = append(scs, Node{strconv.Itoa(len(scs)), s.nodes(nil)})
+	}
+
+	root.Set("shortcuts", scs)
+
+	if err := writeShortcuts(path, root); err != nil {
+		return fmt.Errorf("write shortcuts: %w", err)
+	}
+
+	for t, url := range s.Artwork {
+		name := strconv.FormatUint(uint64(s.AppID()), 10) + t + ".png"
+
+		if err := dirs.Mkdirs(filepath.Join(user, "grid")); err != nil {
+			return err
+		}
+
+		if err := netutil.Download(url, filepath.Join(user, "grid", name)); err != nil {
+			return fmt.Errorf("artwork %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func readShortcuts(path string) (Nodes, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Nodes{{"shortcuts", Nodes{}}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadVDF(f)
+}
+
+func writeShortcuts(path string, root Nodes) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return WriteVDF(f, root)
+}
+
+// Running determines if Steam is running, according to its PID file.
+func Running() bool {
+	b, err := os.ReadFile(filepath.Join(xdg.Home, ".steam", "steam.pid"))
+	if err != nil {
+		return false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return false
+	}
+
+	_, err = os.Stat(filepath.Join("/proc", strconv.Itoa(pid)))
+	return err == nil
+}
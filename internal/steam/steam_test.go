@@ -0,0 +1,65 @@
+package steam
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestVDF(t *testing.T) {
+	ns := Nodes{{"shortcuts", Nodes{
+		{"0", Nodes{
+			{"appid", uint32(0x8badf00d)},
+			{"AppName", "meow"},
+			{"tags", Nodes{}},
+		}},
+	}}}
+
+	var buf bytes.Buffer
+	if err := WriteVDF(&buf, ns); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadVDF(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, ns) {
+		t.Fatalf("got %v, want %v", got, ns)
+	}
+}
+
+func TestAddShortcut(t *testing.T) {
+	user := t.TempDir()
+	sc := Shortcut{AppName: "Roblox Player", Exe: "/usr/bin/vinegar", LaunchOptions: "player run"}
+
+	for i := 0; i < 2; i++ {
+		if err := AddShortcut(user, &sc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(filepath.Join(user, "shortcuts.vdf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	root, err := ReadVDF(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, _ := root.Get("shortcuts")
+	scs := v.(Nodes)
+	if len(scs) != 1 {
+		t.Fatalf("got %d shortcuts, want replaced shortcut", len(scs))
+	}
+
+	if id, _ := scs[0].Value.(Nodes).Get("appid"); id != sc.AppID() {
+		t.Error("expected shortcut app id")
+	}
+}
@@ -0,0 +1,162 @@
+package steam
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Binary VDF value types
+const (
+	typeMap    byte = 0x00
+	typeString byte = 0x01
+	typeInt    byte = 0x02
+	typeEnd    byte = 0x08
+)
+
+var ErrBadVDF = errors.New("malformed binary vdf")
+
+// Node is a key-value pair of a binary VDF document, as used by Steam's
+// shortcuts.vdf. The value is either a string, an uint32, or Nodes.
+type Node struct {
+	Key   string
+	Value any
+}
+
+// Nodes are the ordered key-value pairs of a binary VDF map.
+type Nodes []Node
+
+// Get returns the value of the named key within the Nodes.
+func (ns Nodes) Get(key string) (any, bool) {
+	for _, n := range ns {
+		if n.Key == key {
+			return n.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Set sets the named key's value in the Nodes, adding it if it
+// is not present.
+func (ns *Nodes) Set(key string, value any) {
+	for i, n := range *ns {
+		if n.Key == key {
+			(*ns)[i].Value = value
+			return
+		}
+	}
+
+	*ns = append(*ns, Node{key, value})
+}
+
+// ReadVDF reads a binary VDF document from r.
+func ReadVDF(r io.Reader) (Nodes, error) {
+	return readNodes(bufio.NewReader(r))
+}
+
+func readNodes(r *bufio.Reader) (Nodes, error) {
+	ns := Nodes{}
+
+	for {
+		t, err := r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return ns, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if t == typeEnd {
+			return ns, nil
+		}
+
+		key, err := readString(r)
+		if err != nil {
+			return nil, err
+		}
+
+		n := Node{Key: key}
+
+		switch t {
+		case typeMap:
+			n.Value, err = readNodes(r)
+		case typeString:
+			n.Value, err = readString(r)
+		case typeInt:
+			var v uint32
+			err = binary.Read(r, binary.LittleEndian, &v)
+			n.Value = v
+		default:
+			return nil, fmt.Errorf("%w: unknown type %#x", ErrBadVDF, t)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ns = append(ns, n)
+	}
+}
+
+func readString(r *bufio.Reader) (string, error) {
+	s, err := r.ReadString(0)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrBadVDF, err)
+	}
+
+	return s[:len(s)-1], nil
+}
+
+// WriteVDF writes the Nodes as a binary VDF document to w.
+func WriteVDF(w io.Writer, ns Nodes) error {
+	bw := bufio.NewWriter(w)
+
+	if err := writeNodes(bw, ns); err != nil {
+		return err
+	}
+
+	// Document end
+	if err := bw.WriteByte(typeEnd); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+func writeNodes(w *bufio.Writer, ns Nodes) error {
+	for _, n := range ns {
+		var t byte
+
+		switch n.Value.(type) {
+		case Nodes:
+			t = typeMap
+		case string:
+			t = typeString
+		case uint32:
+			t = typeInt
+		default:
+			return fmt.Errorf("vdf %s: unsupported type %T", n.Key, n.Value)
+		}
+
+		w.WriteByte(t)
+		w.WriteString(n.Key)
+		w.WriteByte(0)
+
+		switch v := n.Value.(type) {
+		case Nodes:
+			if err := writeNodes(w, v); err != nil {
+				return err
+			}
+			w.WriteByte(typeEnd)
+		case string:
+			w.WriteString(v)
+			w.WriteByte(0)
+		case uint32:
+			binary.Write(w, binary.LittleEndian, v)
+		}
+	}
+
+	return nil
+}
@@ -2,16 +2,20 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 const APIURL = "https://%s.roblox.com/%s"
 
 var httpClient = &http.Client{}
 
+var serviceURLs = make(map[string]string)
+
 var (
 	ErrBadStatus = errors.New("bad status")
 	ErrNoData    = errors.New("no data")
@@ -22,12 +26,36 @@ func SetClient(client *http.Client) {
 	httpClient = client
 }
 
+// SetServiceURL sets the base URL used to make API requests to the named
+// service instead of the service's Roblox URL, such as to use a proxy or
+// a mirror of the service. An empty URL restores the service's Roblox URL.
+func SetServiceURL(service, base string) {
+	if base == "" {
+		delete(serviceURLs, service)
+		return
+	}
+
+	serviceURLs[service] = strings.TrimSuffix(base, "/")
+}
+
+func serviceURL(service, endpoint string) string {
+	if base, ok := serviceURLs[service]; ok {
+		return base + "/" + endpoint
+	}
+
+	return fmt.Sprintf(APIURL, service, endpoint)
+}
+
 // Request makes a API request given method, service, endpoint, and data
 // to send to the endpoint with the given method.
 func Request(method, service, endpoint string, v interface{}) error {
-	url := fmt.Sprintf(APIURL, service, endpoint)
+	return RequestContext(context.Background(), method, service, endpoint, v)
+}
 
-	req, err := http.NewRequest(method, url, nil)
+// RequestContext is like Request, with the request being cancelled
+// once ctx is done.
+func RequestContext(ctx context.Context, method, service, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, serviceURL(service, endpoint), nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const csrfHeader = "X-Csrf-Token"
+
+var ErrNoTicket = errors.New("no authentication ticket")
+
+// AuthRequest makes an API request authenticated with the given .ROBLOSECURITY
+// cookie, given method, service, endpoint, and data to send to the endpoint;
+// body is encoded as the request's JSON body if non-nil, and the response is
+// decoded into v if non-nil. The CSRF token required by Roblox for
+// authenticated requests is retrieved as needed.
+//
+// The returned header is the header of the response.
+func AuthRequest(method, service, endpoint, cookie string, body, v interface{}) (http.Header, error) {
+	var data []byte
+
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+
+	url := serviceURL(service, endpoint)
+
+	resp, err := authDo(method, url, cookie, "", data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Roblox rejects the first request with the CSRF token to use
+	if token := resp.Header.Get(csrfHeader); resp.StatusCode == http.StatusForbidden && token != "" {
+		resp.Body.Close()
+
+		resp, err = authDo(method, url, cookie, token, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errsResp := new(errorsResponse)
+		if err := json.NewDecoder(resp.Body).Decode(errsResp); err == nil {
+			return nil, errsResp
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrBadStatus, resp.Status)
+	}
+
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp.Header, nil
+}
+
+func authDo(method, url, cookie, token string, data []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Referer", "https://www.roblox.com/")
+	req.AddCookie(&http.Cookie{Name: ".ROBLOSECURITY", Value: cookie})
+
+	if token != "" {
+		req.Header.Set(csrfHeader, token)
+	}
+
+	return httpClient.Do(req)
+}
+
+// GetAuthTicket gets an authentication ticket for the given .ROBLOSECURITY
+// cookie, which authenticates a Player launch.
+func GetAuthTicket(cookie string) (string, error) {
+	h, err := AuthRequest("POST", "auth", "v1/authentication-ticket", cookie, nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	ticket := h.Get("Rbx-Authentication-Ticket")
+	if ticket == "" {
+		return "", ErrNoTicket
+	}
+
+	return ticket, nil
+}
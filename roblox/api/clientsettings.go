@@ -1,6 +1,11 @@
 package api
 
+import "context"
+
 // ClientVersion is a representation of the Roblox ClientVersionResponse model.
+//
+// The next client version is present when a new version is being rolled
+// out to the deployment channel.
 type ClientVersion struct {
 	Version                 string `json:"version"`
 	ClientVersionUpload     string `json:"clientVersionUpload"`
@@ -11,6 +16,12 @@ type ClientVersion struct {
 
 // GetClientVersion gets the ClientVersion for the named binaryType and deployment channel.
 func GetClientVersion(binaryType string, channel string) (ClientVersion, error) {
+	return GetClientVersionContext(context.Background(), binaryType, channel)
+}
+
+// GetClientVersionContext is like GetClientVersion, with the request
+// being cancelled once ctx is done.
+func GetClientVersionContext(ctx context.Context, binaryType string, channel string) (ClientVersion, error) {
 	var cv ClientVersion
 
 	ep := "v2/client-version/" + binaryType
@@ -18,10 +29,28 @@ func GetClientVersion(binaryType string, channel string) (ClientVersion, error)
 		ep += "/channel/" + channel
 	}
 
-	err := Request("GET", "clientsettings", ep, &cv)
+	err := RequestContext(ctx, "GET", "clientsettings", ep, &cv)
 	if err != nil {
 		return ClientVersion{}, err
 	}
 
 	return cv, nil
 }
+
+// UserChannel is a representation of the Roblox UserChannelResponse model.
+type UserChannel struct {
+	ChannelName string  `json:"channelName"`
+	Token       *string `json:"token"`
+}
+
+// GetUserChannel gets the deployment channel assigned for the named binaryType.
+func GetUserChannel(binaryType string) (UserChannel, error) {
+	var uc UserChannel
+
+	err := Request("GET", "clientsettings", "v2/user-channel?binaryType="+binaryType, &uc)
+	if err != nil {
+		return UserChannel{}, err
+	}
+
+	return uc, nil
+}
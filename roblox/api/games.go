@@ -1,6 +1,9 @@
 package api
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+)
 
 // Creator is a representation of the Roblox GameCreator model.
 type Creator struct {
@@ -60,3 +63,23 @@ func GetGameDetails(universeID string) (GameDetail, error) {
 
 	return gdr.Data[0], nil
 }
+
+type universeIDResponse struct {
+	UniverseID int64 `json:"universeId"`
+}
+
+// GetUniverseID gets the universe ID of the named placeID.
+func GetUniverseID(placeID string) (string, error) {
+	var uidr universeIDResponse
+
+	err := Request("GET", "apis", "universes/v1/places/"+placeID+"/universe", &uidr)
+	if err != nil {
+		return "", err
+	}
+
+	if uidr.UniverseID == 0 {
+		return "", fmt.Errorf("universe: %w", ErrNoData)
+	}
+
+	return strconv.FormatInt(uidr.UniverseID, 10), nil
+}
@@ -38,3 +38,32 @@ func GetGameIcon(universeID, returnPolicy, size, format string, isCircular bool)
 
 	return tnr.Data[0], nil
 }
+
+type gameThumbnailsResponse struct {
+	Data []struct {
+		UniverseID int64       `json:"universeId"`
+		Thumbnails []Thumbnail `json:"thumbnails"`
+	} `json:"data"`
+}
+
+// GetGameThumbnail gets the first thumbnail URL for the given universeID,
+// refer to the [Thumbnails API documentation] for more information.
+//
+// [[Thumbnails API documentation]: https://thumbnails.roblox.com/docs/index.html
+func GetGameThumbnail(universeID, size, format string) (Thumbnail, error) {
+	var gtr gameThumbnailsResponse
+
+	err := Request("GET", "thumbnails",
+		fmt.Sprintf("v1/games/multiget/thumbnails?universeIds=%s&countPerUniverse=1&size=%s&format=%s",
+			universeID, size, format), &gtr,
+	)
+	if err != nil {
+		return Thumbnail{}, err
+	}
+
+	if len(gtr.Data) == 0 || len(gtr.Data[0].Thumbnails) == 0 {
+		return Thumbnail{}, fmt.Errorf("thumbnails: %w", ErrNoData)
+	}
+
+	return gtr.Data[0].Thumbnails[0], nil
+}
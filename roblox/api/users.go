@@ -0,0 +1,83 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// User is a representation of the Roblox MultiGetUserByNameResponse model.
+type User struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type usersResponse struct {
+	Data []User `json:"data"`
+}
+
+// GetUserByName gets the User with the named username, authenticated with
+// the given .ROBLOSECURITY cookie.
+func GetUserByName(cookie, username string) (User, error) {
+	var ur usersResponse
+
+	_, err := AuthRequest("POST", "users", "v1/usernames/users", cookie, map[string]any{
+		"usernames":          []string{username},
+		"excludeBannedUsers": true,
+	}, &ur)
+	if err != nil {
+		return User{}, err
+	}
+
+	if len(ur.Data) == 0 {
+		return User{}, fmt.Errorf("user %s: %w", username, ErrNoData)
+	}
+
+	return ur.Data[0], nil
+}
+
+// UserPresenceType is the type of a user's UserPresence.
+type UserPresenceType int
+
+const (
+	Offline UserPresenceType = iota
+	Online
+	InGame
+	InStudio
+	Invisible
+)
+
+// UserPresence is a representation of the Roblox UserPresence model.
+type UserPresence struct {
+	UserPresenceType UserPresenceType `json:"userPresenceType"`
+	LastLocation     string           `json:"lastLocation"`
+	PlaceID          *int64           `json:"placeId"`
+	RootPlaceID      *int64           `json:"rootPlaceId"`
+	GameID           *string          `json:"gameId"`
+	UniverseID       *int64           `json:"universeId"`
+	UserID           int64            `json:"userId"`
+}
+
+type userPresencesResponse struct {
+	UserPresences []UserPresence `json:"userPresences"`
+}
+
+// GetUserPresence gets the given userID's UserPresence, authenticated with
+// the given .ROBLOSECURITY cookie. The presence's game is only present if
+// the user's privacy settings allow the authenticated user to join them.
+func GetUserPresence(cookie string, userID int64) (UserPresence, error) {
+	var upr userPresencesResponse
+
+	_, err := AuthRequest("POST", "presence", "v1/presence/users", cookie, map[string]any{
+		"userIds": []int64{userID},
+	}, &upr)
+	if err != nil {
+		return UserPresence{}, err
+	}
+
+	if len(upr.UserPresences) == 0 {
+		return UserPresence{}, fmt.Errorf("presence %s: %w", strconv.FormatInt(userID, 10), ErrNoData)
+	}
+
+	return upr.UserPresences[0], nil
+}
@@ -7,31 +7,42 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
+// extractBufferSize is the size of the buffer each file is extracted with,
+// larger than io.Copy's to make fewer writes to the disk.
+const extractBufferSize = 1 << 20
+
+var extractBuffers = sync.Pool{
+	New: func() any {
+		b := make([]byte, extractBufferSize)
+		return &b
+	},
+}
+
 func extract(src string, dir string) error {
-	r, err := zip.OpenReader(src)
+	r, closeZip, err := openZip(src)
 	if err != nil {
 		return err
 	}
-	defer r.Close()
+	defer closeZip()
 
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
 
 	for _, f := range r.File {
-		dest := filepath.Join(dir, strings.ReplaceAll(f.Name, `\`, "/"))
+		dest, err := extractPath(dir, f)
+		if err != nil {
+			return err
+		}
 
 		// ignore the destination directory, it was already created above
 		if dir == dest {
 			continue
 		}
 
-		if !strings.HasPrefix(dest, filepath.Clean(dir)+string(os.PathSeparator)) {
-			return fmt.Errorf("illegal file path: %s", dest)
-		}
-
 		if f.FileInfo().IsDir() {
 			if err := os.MkdirAll(dest, f.Mode()); err != nil {
 				return err
@@ -48,20 +59,46 @@ func extract(src string, dir string) error {
 	return nil
 }
 
+// extractPath returns the path the given file is extracted to within dir.
+func extractPath(dir string, f *zip.File) (string, error) {
+	dest := filepath.Join(dir, strings.ReplaceAll(f.Name, `\`, "/"))
+
+	if dest != filepath.Clean(dir) && !strings.HasPrefix(dest, filepath.Clean(dir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("illegal file path: %s", dest)
+	}
+
+	return dest, nil
+}
+
 func extractFile(src *zip.File, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return err
+	}
+
 	f, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, src.Mode())
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
+	// Allocating the file upfront avoids fragmenting it
+	// as it is extended by each write.
+	if err := preallocate(f, int64(src.UncompressedSize64)); err != nil {
+		return err
+	}
+
 	z, err := src.Open()
 	if err != nil {
 		return err
 	}
 	defer z.Close()
 
-	if _, err := io.Copy(f, z); err != nil {
+	buf := extractBuffers.Get().(*[]byte)
+	defer extractBuffers.Put(buf)
+
+	// *os.File's ReadFrom would otherwise be used, which ignores the buffer
+	// when the reader is not a file.
+	if _, err := io.CopyBuffer(struct{ io.Writer }{f}, z, *buf); err != nil {
 		return err
 	}
 
@@ -0,0 +1,59 @@
+//go:build linux
+
+package bootstrapper
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"syscall"
+)
+
+// openZip opens the named zip file by memory-mapping it, which avoids
+// copying the compressed data through small reads for each file.
+func openZip(name string) (*zip.Reader, func() error, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if fi.Size() == 0 {
+		return nil, nil, zip.ErrFormat
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: name, Err: err}
+	}
+
+	// The files are mostly read in the order they are stored.
+	_ = syscall.Madvise(data, syscall.MADV_SEQUENTIAL)
+
+	r, err := zip.NewReader(bytes.NewReader(data), fi.Size())
+	if err != nil {
+		syscall.Munmap(data)
+		return nil, nil, err
+	}
+
+	return r, func() error { return syscall.Munmap(data) }, nil
+}
+
+func preallocate(f *os.File, size int64) error {
+	if size == 0 {
+		return nil
+	}
+
+	err := syscall.Fallocate(int(f.Fd()), 0, 0, size)
+	if errors.Is(err, syscall.EOPNOTSUPP) || errors.Is(err, syscall.ENOSYS) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+
+package bootstrapper
+
+import (
+	"archive/zip"
+	"os"
+)
+
+func openZip(name string) (*zip.Reader, func() error, error) {
+	r, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &r.Reader, r.Close, nil
+}
+
+func preallocate(*os.File, int64) error {
+	return nil
+}
@@ -0,0 +1,203 @@
+package bootstrapper
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeZip(t testing.TB, name string, files map[string][]byte) {
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for n, data := range files {
+		w, err := zw.Create(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "content-meows.zip")
+	files := map[string][]byte{
+		`sounds\meow.ogg`:        bytes.Repeat([]byte("meow"), extractBufferSize),
+		"textures/purr/hiss.png": []byte("hiss"),
+		"empty.txt":              nil,
+	}
+	writeZip(t, src, files)
+
+	dest := filepath.Join(dir, "content")
+	if err := extract(src, dest); err != nil {
+		t.Fatal(err)
+	}
+
+	for n, want := range map[string][]byte{
+		"sounds/meow.ogg":        files[`sounds\meow.ogg`],
+		"textures/purr/hiss.png": files["textures/purr/hiss.png"],
+		"empty.txt":              nil,
+	} {
+		got, err := os.ReadFile(filepath.Join(dest, n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("extracted %s has %d bytes, want %d", n, len(got), len(want))
+		}
+	}
+
+	evil := filepath.Join(dir, "evil.zip")
+	writeZip(t, evil, map[string][]byte{"../hiss": nil})
+	if err := extract(evil, dest); err == nil {
+		t.Error("want illegal file path error")
+	}
+}
+
+func TestPackageRepair(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "content-meows.zip")
+	writeZip(t, src, map[string][]byte{
+		`sounds\meow.ogg`: []byte("meow"),
+		"sounds/purr.ogg": []byte("purr"),
+		"sounds/hiss.ogg": []byte("hiss"),
+		"sounds/mrrp.ogg": []byte("mrrp"),
+	})
+
+	dest := filepath.Join(dir, "content")
+	if err := extract(src, dest); err != nil {
+		t.Fatal(err)
+	}
+
+	meow := filepath.Join(dest, "sounds", "meow.ogg")
+	purr := filepath.Join(dest, "sounds", "purr.ogg")
+	mrrp := filepath.Join(dest, "sounds", "mrrp.ogg")
+	if err := os.WriteFile(meow, []byte("woof"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mrrp, []byte("modded"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(purr); err != nil {
+		t.Fatal(err)
+	}
+
+	p := Package{Name: "content-meows.zip"}
+	repaired, err := p.Repair(src, dest, 2, func(path string) bool {
+		return path == mrrp
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repaired) != 2 {
+		t.Errorf("repaired %v, want only the changed and missing files", repaired)
+	}
+
+	for n, want := range map[string]string{meow: "meow", purr: "purr", mrrp: "modded"} {
+		if got, err := os.ReadFile(n); err != nil || string(got) != want {
+			t.Errorf("%s is %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestCheckFiles(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "content-meows.zip")
+	writeZip(t, src, map[string][]byte{
+		`sounds\meow.ogg`: []byte("meow"),
+		"sounds/purr.ogg": []byte("purr"),
+		"sounds/hiss.ogg": []byte("hiss"),
+		"sounds/mrrp.ogg": []byte("mrrp"),
+	})
+
+	dest := filepath.Join(dir, "content")
+	if err := extract(src, dest); err != nil {
+		t.Fatal(err)
+	}
+
+	p := Package{Name: "content-meows.zip"}
+	sums, err := p.Checksums(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sums) != 4 {
+		t.Fatalf("checksums %v, want each file", sums)
+	}
+
+	meow := filepath.Join("sounds", "meow.ogg")
+	purr := filepath.Join("sounds", "purr.ogg")
+	mrrp := filepath.Join("sounds", "mrrp.ogg")
+	if err := os.WriteFile(filepath.Join(dest, meow), []byte("woof"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dest, mrrp), []byte("modded"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dest, purr)); err != nil {
+		t.Fatal(err)
+	}
+
+	missing, corrupted, err := CheckFiles(dest, sums, 2, func(rel string) bool {
+		return rel == mrrp
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(missing) != 1 || missing[0] != purr {
+		t.Errorf("missing %v, want %s", missing, purr)
+	}
+	if len(corrupted) != 1 || corrupted[0] != meow {
+		t.Errorf("corrupted %v, want %s", corrupted, meow)
+	}
+}
+
+func BenchmarkExtract(b *testing.B) {
+	dir := b.TempDir()
+	src := filepath.Join(dir, "content-meows.zip")
+
+	files := make(map[string][]byte)
+	for _, n := range []string{"a", "b", "c", "d"} {
+		files["textures/"+n+".png"] = bytes.Repeat([]byte(n), 8<<20)
+	}
+	writeZip(b, src, files)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := extract(src, filepath.Join(dir, "content")); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestPackageFiles(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "content-meows.zip")
+	writeZip(t, src, map[string][]byte{
+		`sounds\meow.ogg`: []byte("meow"),
+		"textures/":       nil,
+	})
+
+	p := Package{Name: "content-meows.zip"}
+	files, err := p.Files(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 || files[0] != "sounds/meow.ogg" {
+		t.Errorf("files %v, want only sounds/meow.ogg", files)
+	}
+}
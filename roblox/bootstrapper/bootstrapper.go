@@ -1,3 +1,31 @@
 // Package bootstrapper implements various routines and types
 // to install or bootstrap a Roblox Binary.
+//
+// A deployment is installed by resolving its package manifest, with
+// [Resolve], and installing its packages to a version directory with
+// [PackageManifest.Install]:
+//
+//	pm, err := bootstrapper.Resolve(ctx, roblox.Player, "", "")
+//	if err != nil {
+//		return err
+//	}
+//
+//	err = pm.Install(ctx, downloadDir, filepath.Join(versionsDir, pm.GUID),
+//		bootstrapper.InstallOptions{
+//			Concurrency: 4,
+//			Progress: func(p bootstrapper.Progress) {
+//				fmt.Println(p.Package.Name, p.Current, p.Total)
+//			},
+//		})
+//
+// Each step is also available on its own: [FetchDeployment] and
+// [FetchPackageManifest] resolve a deployment, [Package.Download] and
+// [Package.Verify] download a package and verify its checksum, and
+// [Package.Extract] extracts it. An installed version directory is
+// verified against the checksums of its packages' files, returned by
+// [Package.Checksums], with [CheckFiles].
+//
+// Network requests are made to the fastest accessible deploy mirror, as
+// returned by [Mirror], failing over to the others, and are stopped once
+// the given context is done.
 package bootstrapper
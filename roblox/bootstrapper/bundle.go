@@ -0,0 +1,305 @@
+package bootstrapper
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/roblox"
+)
+
+// BundleManifestName is the name of a bundle's manifest, alongside its
+// packages which are named after their checksums.
+const BundleManifestName = "manifest.json"
+
+var (
+	ErrNoBundleManifest = errors.New("bundle has no manifest")
+	ErrBundleBinary     = errors.New("bundle is of another binary")
+)
+
+// bundleManifest is the representation of a bundle's manifest.
+type bundleManifest struct {
+	Binary   string
+	Channel  string
+	GUID     string
+	Packages Packages
+}
+
+// WriteBundle writes a bundle of the given package manifest's packages,
+// read from the named directory in which they are named after their
+// checksums, to dest: a tar archive if dest ends with ".tar", or a
+// directory otherwise.
+func WriteBundle(dest string, pm *PackageManifest, dir string) error {
+	manif, err := json.MarshalIndent(bundleManifest{
+		Binary:   pm.Deployment.Type.BinaryName(),
+		Channel:  pm.Deployment.Channel,
+		GUID:     pm.Deployment.GUID,
+		Packages: pm.Packages,
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasSuffix(dest, ".tar") {
+		if err := os.MkdirAll(dest, 0o755); err != nil {
+			return err
+		}
+
+		for _, p := range pm.Packages {
+			if err := copyPackage(filepath.Join(dir, p.Checksum), filepath.Join(dest, p.Checksum)); err != nil {
+				return fmt.Errorf("copy package %s: %w", p.Name, err)
+			}
+		}
+
+		return os.WriteFile(filepath.Join(dest, BundleManifestName), manif, 0o644)
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+
+	// The manifest is written first, for it to be read before the packages.
+	if err := tw.WriteHeader(&tar.Header{
+		Name: BundleManifestName,
+		Mode: 0o644,
+		Size: int64(len(manif)),
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(manif); err != nil {
+		return err
+	}
+
+	for _, p := range pm.Packages {
+		if err := writeTarPackage(tw, filepath.Join(dir, p.Checksum), p.Checksum); err != nil {
+			return fmt.Errorf("write package %s: %w", p.Name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+func writeTarPackage(tw *tar.Writer, src, name string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime(),
+	}); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// ReadBundle reads the bundle of the given Binary type at src, being either
+// a directory or a tar archive written by [WriteBundle], to the named
+// directory, in which its packages are named after their checksums.
+//
+// Each package is verified against its checksum within the bundle's
+// manifest. The returned package manifest has no DeployURL, with its
+// packages only being available within the named directory.
+func ReadBundle(src string, bt roblox.BinaryType, dir string) (PackageManifest, error) {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return PackageManifest{}, err
+	}
+
+	var bm bundleManifest
+	if fi.IsDir() {
+		bm, err = readBundleDir(src, dir)
+	} else {
+		bm, err = readBundleTar(src, dir)
+	}
+	if err != nil {
+		return PackageManifest{}, err
+	}
+
+	if bm.Binary != bt.BinaryName() {
+		return PackageManifest{}, fmt.Errorf("%w: %s", ErrBundleBinary, bm.Binary)
+	}
+
+	for _, p := range bm.Packages {
+		if err := p.Verify(filepath.Join(dir, p.Checksum)); err != nil {
+			return PackageManifest{}, fmt.Errorf("bundle package %s: %w", p.Name, err)
+		}
+	}
+
+	d := NewDeployment(bt, bm.Channel, bm.GUID)
+	slog.Info("Read bundle", "path", src, "guid", d.GUID, "channel", d.Channel, "packages", len(bm.Packages))
+
+	return PackageManifest{
+		Deployment: &d,
+		Packages:   bm.Packages,
+	}, nil
+}
+
+func readBundleDir(src, dir string) (bundleManifest, error) {
+	var bm bundleManifest
+
+	data, err := os.ReadFile(filepath.Join(src, BundleManifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return bm, ErrNoBundleManifest
+	} else if err != nil {
+		return bm, err
+	}
+
+	if err := json.Unmarshal(data, &bm); err != nil {
+		return bm, fmt.Errorf("manifest: %w", err)
+	}
+
+	for _, p := range bm.Packages {
+		if !validChecksum(p.Checksum) {
+			return bm, fmt.Errorf("manifest: bad package checksum: %s", p.Checksum)
+		}
+
+		if err := copyPackage(filepath.Join(src, p.Checksum), filepath.Join(dir, p.Checksum)); err != nil {
+			return bm, fmt.Errorf("copy package %s: %w", p.Name, err)
+		}
+	}
+
+	return bm, nil
+}
+
+func readBundleTar(src, dir string) (bundleManifest, error) {
+	var bm bundleManifest
+	found := false
+
+	f, err := os.Open(src)
+	if err != nil {
+		return bm, err
+	}
+	defer f.Close()
+
+	tr := tar.NewReader(f)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return bm, err
+		}
+
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := filepath.Clean(h.Name)
+		if name == BundleManifestName {
+			if err := json.NewDecoder(tr).Decode(&bm); err != nil {
+				return bm, fmt.Errorf("manifest: %w", err)
+			}
+			found = true
+			continue
+		}
+
+		// Packages are verified against the manifest once all are read,
+		// as they may come before it.
+		if !validChecksum(name) {
+			slog.Warn("Skipping unknown bundle file", "name", h.Name)
+			continue
+		}
+
+		if err := writePackage(tr, filepath.Join(dir, name)); err != nil {
+			return bm, fmt.Errorf("read package %s: %w", name, err)
+		}
+	}
+
+	if !found {
+		return bm, ErrNoBundleManifest
+	}
+
+	return bm, nil
+}
+
+// validChecksum determines if the given checksum is a hexadecimal MD5
+// checksum, for it to be safely used as a file name.
+func validChecksum(sum string) bool {
+	if len(sum) != 32 {
+		return false
+	}
+
+	for _, c := range sum {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// copyPackage copies the named package file to dst, hard-linking it
+// if possible.
+func copyPackage(src, dst string) error {
+	if err := os.Link(src, dst); err == nil || errors.Is(err, os.ErrExist) && samePackage(src, dst) {
+		return nil
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return writePackage(f, dst)
+}
+
+// samePackage determines if the named package files are the same file.
+func samePackage(a, b string) bool {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+
+	bi, err := os.Stat(b)
+	return err == nil && os.SameFile(ai, bi)
+}
+
+// writePackage writes the package read from r to dst, through a temporary
+// file for dst to never be incomplete.
+func writePackage(r io.Reader, dst string) error {
+	tmp := dst + ".bundle"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, dst)
+}
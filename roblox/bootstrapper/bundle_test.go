@@ -0,0 +1,60 @@
+package bootstrapper
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vinegarhq/vinegar/roblox"
+)
+
+func TestBundle(t *testing.T) {
+	dir := t.TempDir()
+	downloads := filepath.Join(dir, "downloads")
+	if err := os.Mkdir(downloads, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	sum := md5.Sum([]byte("meow"))
+	p := Package{Name: "content-meows.zip", Checksum: hex.EncodeToString(sum[:])}
+	if err := os.WriteFile(filepath.Join(downloads, p.Checksum), []byte("meow"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDeployment(roblox.Player, "purr", "version-meow")
+	pm := PackageManifest{Deployment: &d, Packages: Packages{p}}
+
+	for _, name := range []string{"bundle", "bundle.tar"} {
+		src := filepath.Join(dir, name)
+		if err := WriteBundle(src, &pm, downloads); err != nil {
+			t.Fatal(err)
+		}
+
+		dest := t.TempDir()
+		got, err := ReadBundle(src, roblox.Player, dest)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if *got.Deployment != d || len(got.Packages) != 1 || got.Packages[0] != p {
+			t.Errorf("%s has %v %v, want %v %v", name, *got.Deployment, got.Packages, d, pm.Packages)
+		}
+		if data, err := os.ReadFile(filepath.Join(dest, p.Checksum)); err != nil || string(data) != "meow" {
+			t.Errorf("%s package is %q, want meow", name, data)
+		}
+
+		if _, err := ReadBundle(src, roblox.Studio, dest); !errors.Is(err, ErrBundleBinary) {
+			t.Errorf("%s read as studio: %v, want %v", name, err, ErrBundleBinary)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "bundle", p.Checksum), []byte("hiss"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadBundle(filepath.Join(dir, "bundle"), roblox.Player, t.TempDir()); err == nil {
+		t.Error("want corrupted package error")
+	}
+}
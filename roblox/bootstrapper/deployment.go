@@ -1,8 +1,11 @@
 package bootstrapper
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 
+	"github.com/vinegarhq/vinegar/internal/retry"
 	"github.com/vinegarhq/vinegar/roblox"
 	"github.com/vinegarhq/vinegar/roblox/api"
 )
@@ -33,10 +36,22 @@ func NewDeployment(bt roblox.BinaryType, channel string, GUID string) Deployment
 
 // FetchDeployment returns the latest Version for the given roblox Binary type
 // with the given deployment channel through [api.GetClientVersion].
-func FetchDeployment(bt roblox.BinaryType, channel string) (Deployment, error) {
+//
+// Failures are retried with [retry.Network] until ctx is done, unless Roblox
+// had responded with an API error, such as for an invalid channel.
+func FetchDeployment(ctx context.Context, bt roblox.BinaryType, channel string) (Deployment, error) {
 	slog.Info("Fetching Binary Deployment", "name", bt.BinaryName(), "channel", channel)
 
-	cv, err := api.GetClientVersion(bt.BinaryName(), channel)
+	p := retry.Network
+	p.Retryable = func(err error) bool {
+		return !errors.As(err, new(api.ErrorResponse))
+	}
+
+	var cv api.ClientVersion
+	err := retry.DoContext(ctx, "fetch deployment", p, func() (err error) {
+		cv, err = api.GetClientVersionContext(ctx, bt.BinaryName(), channel)
+		return
+	})
 	if err != nil {
 		return Deployment{}, err
 	}
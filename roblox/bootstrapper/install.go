@@ -0,0 +1,112 @@
+package bootstrapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vinegarhq/vinegar/roblox"
+	"golang.org/x/sync/errgroup"
+)
+
+var ErrUnhandledPackage = errors.New("unhandled package")
+
+// Resolve returns the package manifest of the deployment of the given
+// Binary type and channel with the given GUID, or of the channel's latest
+// deployment if the GUID is empty.
+func Resolve(ctx context.Context, bt roblox.BinaryType, channel, guid string) (PackageManifest, error) {
+	d := NewDeployment(bt, channel, guid)
+	if guid == "" {
+		var err error
+		d, err = FetchDeployment(ctx, bt, channel)
+		if err != nil {
+			return PackageManifest{}, fmt.Errorf("fetch deployment: %w", err)
+		}
+	}
+
+	pm, err := FetchPackageManifest(ctx, &d)
+	if err != nil {
+		return PackageManifest{}, fmt.Errorf("fetch package manifest: %w", err)
+	}
+
+	return pm, nil
+}
+
+// InstallOptions are the options of installing a package manifest's
+// packages with Install.
+type InstallOptions struct {
+	// Concurrency is the maximum amount of packages downloaded at
+	// once, which is one if unset.
+	Concurrency int
+
+	// Directories are the directories the packages are extracted to
+	// within the version directory, which are the Binary type's
+	// [BinaryDirectories] if nil.
+	Directories PackageDirectories
+
+	// Progress is called with the download progress of each package,
+	// if non-nil. It is called from multiple goroutines at once.
+	Progress ProgressFunc
+
+	// Extracted is called once each package was extracted, if non-nil.
+	// It is called from multiple goroutines at once.
+	Extracted func(*Package)
+}
+
+// Install downloads the package manifest's packages to the named download
+// directory, named after their checksum, and extracts each package to the
+// named version directory once it was downloaded, with its AppSettings
+// written. Packages already downloaded with the correct checksum are
+// not downloaded again.
+//
+// Installing is stopped at the first failure, or once ctx is done.
+func (pm *PackageManifest) Install(ctx context.Context, downloadDir, dir string, opts InstallOptions) error {
+	pkgDirs := opts.Directories
+	if pkgDirs == nil {
+		pkgDirs = BinaryDirectories(pm.Type)
+	}
+
+	for _, p := range pm.Packages {
+		if _, ok := pkgDirs[p.Name]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnhandledPackage, p.Name)
+		}
+	}
+
+	if err := os.MkdirAll(downloadDir, 0o755); err != nil {
+		return err
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(max(opts.Concurrency, 1))
+
+	for _, p := range pm.Packages {
+		p := p
+		eg.Go(func() error {
+			src := filepath.Join(downloadDir, p.Checksum)
+			if err := p.Download(ctx, src, pm.DeployURL, opts.Progress); err != nil {
+				return err
+			}
+
+			if err := p.Extract(src, filepath.Join(dir, pkgDirs[p.Name])); err != nil {
+				return err
+			}
+
+			if opts.Extracted != nil {
+				opts.Extracted(&p)
+			}
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if err := WriteAppSettings(dir); err != nil {
+		return fmt.Errorf("appsettings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package bootstrapper
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/vinegarhq/vinegar/roblox"
+)
+
+func TestInstall(t *testing.T) {
+	tmp := t.TempDir()
+	zips := make(map[string][]byte)
+	pm := PackageManifest{Deployment: &Deployment{Type: roblox.Player}}
+	for name, file := range map[string]string{
+		"content-meows.zip": "sounds/meow.ogg",
+		"shaders.zip":       "purr.pack",
+	} {
+		src := filepath.Join(tmp, name)
+		writeZip(t, src, map[string][]byte{file: []byte(name)})
+
+		data, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum := md5.Sum(data)
+		zips[name] = data
+		pm.Packages = append(pm.Packages, Package{
+			Name:     name,
+			Checksum: hex.EncodeToString(sum[:]),
+			ZipSize:  int64(len(data)),
+		})
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, name, _ := strings.Cut(r.URL.Path, "-")
+		data, ok := zips[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	defer srv.Close()
+	pm.DeployURL = srv.URL + "/version"
+
+	var mu sync.Mutex
+	var extracted []string
+	dir := filepath.Join(tmp, "version")
+	err := pm.Install(context.Background(), filepath.Join(tmp, "downloads"), dir, InstallOptions{
+		Concurrency: 2,
+		Directories: PackageDirectories{
+			"content-meows.zip": "content/",
+			"shaders.zip":       "shaders/",
+		},
+		Extracted: func(p *Package) {
+			mu.Lock()
+			defer mu.Unlock()
+			extracted = append(extracted, p.Name)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(extracted) != 2 {
+		t.Errorf("got extracted packages %v, want both", extracted)
+	}
+	for _, f := range []string{"content/sounds/meow.ogg", "shaders/purr.pack", "AppSettings.xml"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Error(err)
+		}
+	}
+
+	pm.Packages = append(pm.Packages, Package{Name: "hiss.zip"})
+	if err := pm.Install(context.Background(), tmp, dir, InstallOptions{}); !errors.Is(err, ErrUnhandledPackage) {
+		t.Errorf("got %v, want unhandled package", err)
+	}
+}
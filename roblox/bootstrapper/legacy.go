@@ -0,0 +1,41 @@
+package bootstrapper
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vinegarhq/vinegar/roblox"
+)
+
+var ErrNoExecutable = errors.New("no roblox executable found in version directory")
+
+// FindExecutable returns the file name of the Binary's executable within
+// the version directory dir. Older deployments may not have the executable
+// named as [roblox.BinaryType.Executable], in which case the first Roblox
+// executable of the Binary which is not a launcher or crash handler is used.
+func FindExecutable(bt roblox.BinaryType, dir string) (string, error) {
+	exe := bt.Executable()
+	if _, err := os.Stat(filepath.Join(dir, exe)); err == nil {
+		return exe, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := strings.ToLower("Roblox" + bt.String())
+	for _, e := range entries {
+		n := strings.ToLower(e.Name())
+		if e.IsDir() || !strings.HasPrefix(n, prefix) || !strings.HasSuffix(n, ".exe") ||
+			strings.Contains(n, "launcher") || strings.Contains(n, "crash") {
+			continue
+		}
+
+		return e.Name(), nil
+	}
+
+	return "", ErrNoExecutable
+}
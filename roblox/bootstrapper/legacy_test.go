@@ -0,0 +1,36 @@
+package bootstrapper
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vinegarhq/vinegar/roblox"
+)
+
+func TestFindExecutable(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := FindExecutable(roblox.Player, dir); !errors.Is(err, ErrNoExecutable) {
+		t.Error("expected no executable")
+	}
+
+	for _, n := range []string{"RobloxPlayerLauncher.exe", "RobloxPlayerOld.exe"} {
+		if err := os.WriteFile(filepath.Join(dir, n), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if exe, err := FindExecutable(roblox.Player, dir); err != nil || exe != "RobloxPlayerOld.exe" {
+		t.Errorf("got executable %s (%v), want legacy executable", exe, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, roblox.Player.Executable()), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if exe, _ := FindExecutable(roblox.Player, dir); exe != roblox.Player.Executable() {
+		t.Errorf("got executable %s, want current executable", exe)
+	}
+}
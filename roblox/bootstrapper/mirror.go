@@ -1,11 +1,23 @@
 package bootstrapper
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/netutil"
 )
 
+// MirrorTimeout is the maximum time taken by a deploy mirror to
+// respond to a probe before it is considered inaccessible.
+const MirrorTimeout = 5 * time.Second
+
 var (
 	ErrNoMirrorFound = errors.New("no accessible deploy mirror found")
 
@@ -21,25 +33,126 @@ var (
 	}
 )
 
-// Mirror returns an available mirror URL from [Mirrors].
-func Mirror() (string, error) {
-	slog.Info("Finding an accessible deploy mirror")
+var (
+	mirrorMu sync.Mutex
+	// ranked are the accessible deploy mirrors, fastest first.
+	ranked []string
+)
 
-	for _, m := range Mirrors {
-		resp, err := http.Head(m + "/" + "version")
-		if err != nil {
-			slog.Error("Bad deploy mirror", "mirror", m, "error", err)
+// Probe is the result of probing a deploy mirror.
+type Probe struct {
+	Mirror  string
+	Latency time.Duration
+	Err     error
+}
 
-			continue
+// ProbeMirrors probes the given deploy mirrors at once, and returns their
+// probes ordered by latency, with the inaccessible mirrors last. Probes
+// still pending once ctx is done fail.
+func ProbeMirrors(ctx context.Context, mirrors []string) []Probe {
+	probes := make([]Probe, len(mirrors))
+	client := &http.Client{Timeout: MirrorTimeout}
+
+	var wg sync.WaitGroup
+	for i, m := range mirrors {
+		probes[i].Mirror = m
+
+		wg.Add(1)
+		go func(p *Probe) {
+			defer wg.Done()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodHead, p.Mirror+"/version", nil)
+			if err != nil {
+				p.Err = err
+				return
+			}
+
+			start := time.Now()
+			resp, err := client.Do(req)
+			p.Latency = time.Since(start)
+			if err != nil {
+				p.Err = err
+				return
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				p.Err = fmt.Errorf("%w: %s", netutil.ErrBadStatus, resp.Status)
+			}
+		}(&probes[i])
+	}
+	wg.Wait()
+
+	sort.SliceStable(probes, func(i, j int) bool {
+		if (probes[i].Err == nil) != (probes[j].Err == nil) {
+			return probes[i].Err == nil
+		}
+		return probes[i].Latency < probes[j].Latency
+	})
+
+	return probes
+}
+
+// SetMirror sets the deploy mirror used instead of the fastest of [Mirrors].
+func SetMirror(mirror string) {
+	mirrorMu.Lock()
+	defer mirrorMu.Unlock()
+
+	ranked = []string{strings.TrimSuffix(mirror, "/")}
+}
+
+// Mirror returns the fastest accessible deploy mirror from [Mirrors],
+// which are only probed once until none of them are accessible.
+func Mirror(ctx context.Context) (string, error) {
+	mirrorMu.Lock()
+	defer mirrorMu.Unlock()
+
+	if len(ranked) == 0 {
+		slog.Info("Probing deploy mirrors")
+
+		for _, p := range ProbeMirrors(ctx, Mirrors) {
+			if p.Err != nil {
+				slog.Error("Bad deploy mirror", "mirror", p.Mirror, "error", p.Err)
+				continue
+			}
+
+			slog.Info("Found deploy mirror", "mirror", p.Mirror, "latency", p.Latency)
+			ranked = append(ranked, p.Mirror)
 		}
-		resp.Body.Close()
+	}
 
-		if resp.StatusCode == 200 {
-			slog.Info("Found deploy mirror", "mirror", m)
+	if len(ranked) == 0 {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		return "", ErrNoMirrorFound
+	}
 
-			return m, nil
+	return ranked[0], nil
+}
+
+// failover returns the given URL of a file on a deploy mirror on the next
+// fastest accessible deploy mirror, with the URL's mirror no longer being
+// preferred, if the given error of the request to it is transient.
+func failover(url string, err error) (string, bool) {
+	if !netutil.Transient(err) {
+		return "", false
+	}
+
+	mirrorMu.Lock()
+	defer mirrorMu.Unlock()
+
+	for i, m := range ranked {
+		path, ok := strings.CutPrefix(url, m)
+		if !ok || len(ranked) < 2 {
+			continue
 		}
+
+		ranked = append(append(ranked[:i:i], ranked[i+1:]...), m)
+		slog.Warn("Deploy mirror failed, failing over", "mirror", m, "next", ranked[0], "error", err)
+
+		return ranked[0] + path, true
 	}
 
-	return "", ErrNoMirrorFound
+	return "", false
 }
@@ -0,0 +1,53 @@
+package bootstrapper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/vinegarhq/vinegar/internal/netutil"
+)
+
+func TestProbeMirrors(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer fast.Close()
+	bad := httptest.NewServer(http.NotFoundHandler())
+	defer bad.Close()
+
+	probes := ProbeMirrors(context.Background(), []string{bad.URL, slow.URL, fast.URL})
+
+	var got []string
+	for _, p := range probes {
+		got = append(got, p.Mirror)
+	}
+	if !slices.Equal(got, []string{fast.URL, slow.URL, bad.URL}) {
+		t.Errorf("probed mirrors %v, want fast, slow and bad", got)
+	}
+	if probes[2].Err == nil {
+		t.Error("want bad mirror error")
+	}
+}
+
+func TestFailover(t *testing.T) {
+	defer func(r []string) { ranked = r }(ranked)
+	ranked = []string{"https://meow", "https://purr", "https://hiss"}
+
+	if _, ok := failover("https://meow/version-meow", &netutil.StatusError{StatusCode: 404}); ok {
+		t.Error("want no failover for a missing file")
+	}
+
+	url, ok := failover("https://meow/version-meow", &netutil.StatusError{StatusCode: 503})
+	if !ok || url != "https://purr/version-meow" {
+		t.Errorf("failed over to %s, want purr", url)
+	}
+	if !slices.Equal(ranked, []string{"https://purr", "https://hiss", "https://meow"}) {
+		t.Errorf("ranked mirrors %v, want meow demoted", ranked)
+	}
+}
@@ -1,12 +1,17 @@
 package bootstrapper
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/vinegarhq/vinegar/internal/netutil"
 )
@@ -44,23 +49,132 @@ func (p *Package) Verify(src string) error {
 	return nil
 }
 
-// Download will download the package to the named dest destination
-// directory with the given deployURL deploy mirror; if the package
-// exists and has the correct checksum, it will return immediately.
-func (p *Package) Download(dest, deployURL string) error {
+// ProgressInterval is the minimum interval in which the progress of a
+// package download is reported.
+const ProgressInterval = 100 * time.Millisecond
+
+// Progress is the reported progress of a package download.
+//
+// Speed is in bytes per second, and only accounts for the bytes downloaded
+// since the download was started or resumed.
+type Progress struct {
+	Package *Package
+	Current int64
+	Total   int64
+	Speed   float64
+	ETA     time.Duration
+}
+
+// ProgressFunc is the callback type for reporting the progress
+// of a package download.
+type ProgressFunc func(Progress)
+
+type progressReporter struct {
+	pkg   *Package
+	fn    ProgressFunc
+	start time.Time
+	base  int64
+	last  time.Time
+}
+
+func (pr *progressReporter) report(written int64) {
+	now := time.Now()
+	if pr.start.IsZero() {
+		pr.start = now
+		pr.base = written
+	} else if now.Sub(pr.last) < ProgressInterval && written < pr.pkg.ZipSize {
+		return
+	}
+	pr.last = now
+
+	p := Progress{
+		Package: pr.pkg,
+		Current: written,
+		Total:   pr.pkg.ZipSize,
+	}
+
+	if elapsed := now.Sub(pr.start).Seconds(); elapsed > 0 {
+		p.Speed = float64(written-pr.base) / elapsed
+	}
+	if p.Speed > 0 && p.Total > p.Current {
+		p.ETA = time.Duration(float64(p.Total-p.Current) / p.Speed * float64(time.Second))
+	}
+
+	pr.fn(p)
+}
+
+// Download downloads the package to the named dest file from the given
+// deployURL of its deployment on a deploy mirror, reporting the progress
+// of the download to pf if non-nil; if the package exists and has the
+// correct checksum, it will return immediately.
+//
+// The package is downloaded to a partial file alongside dest, which is kept
+// if the download was interrupted or ctx is done, to be resumed by the next
+// download of the package. If a resumed download does not match the
+// package's checksum, it is downloaded again from the start.
+func (p *Package) Download(ctx context.Context, dest, deployURL string, pf ProgressFunc) error {
 	if err := p.Verify(dest); err == nil {
 		slog.Info("Package is already downloaded", "name", p.Name, "file", dest)
+		if pf != nil {
+			pf(Progress{Package: p, Current: p.ZipSize, Total: p.ZipSize})
+		}
 		return nil
 	}
 
 	url := deployURL + "-" + p.Name
-	slog.Info("Downloading package", "url", url, "path", dest)
+	part := dest + ".part"
+	_, err := os.Stat(part)
+	resumed := err == nil
+
+	// URLs already downloaded from, for their mirrors to not be
+	// failed over to again.
+	failed := map[string]bool{url: true}
 
-	if err := netutil.Download(url, dest); err != nil {
-		return fmt.Errorf("download package %s: %w", p.Name, err)
+	for {
+		if resumed {
+			slog.Info("Resuming package download", "url", url, "path", part)
+		} else {
+			slog.Info("Downloading package", "url", url, "path", dest)
+		}
+
+		var wf func(int64)
+		if pf != nil {
+			wf = (&progressReporter{pkg: p, fn: pf}).report
+		}
+
+		if err := netutil.DownloadResume(ctx, url, part, wf); err != nil {
+			next, ok := failover(url, err)
+			if !ok || failed[next] || ctx.Err() != nil {
+				return fmt.Errorf("download package %s: %w", p.Name, err)
+			}
+
+			failed[next] = true
+			url = next
+			_, serr := os.Stat(part)
+			resumed = serr == nil
+			continue
+		}
+
+		err := p.Verify(part)
+		if err == nil {
+			break
+		}
+
+		// Regardless of the file being resumed or not, it is
+		// corrupted and would only be resumed again.
+		if rerr := os.Remove(part); rerr != nil && !errors.Is(rerr, os.ErrNotExist) {
+			return rerr
+		}
+
+		if !resumed {
+			return err
+		}
+
+		slog.Warn("Resumed package download is corrupted, downloading again", "name", p.Name)
+		resumed = false
 	}
 
-	return p.Verify(dest)
+	return os.Rename(part, dest)
 }
 
 // Extract extracts the named package source file to a given destination directory
@@ -72,3 +186,28 @@ func (p *Package) Extract(src, dest string) error {
 	slog.Info("Extracted package", "name", p.Name, "path", src, "dest", dest)
 	return nil
 }
+
+// Files returns the paths of the files within the named package source file,
+// relative to the directory the package is extracted to.
+func (p *Package) Files(src string) ([]string, error) {
+	r, closeZip, err := openZip(src)
+	if err != nil {
+		return nil, fmt.Errorf("open package %s (%s): %w", p.Name, src, err)
+	}
+	defer closeZip()
+
+	files := make([]string, 0, len(r.File))
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		name := filepath.Clean(strings.ReplaceAll(f.Name, `\`, "/"))
+		if !filepath.IsLocal(name) {
+			return nil, fmt.Errorf("illegal file path: %s", f.Name)
+		}
+		files = append(files, name)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,75 @@
+package bootstrapper
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPackageDownloadResume(t *testing.T) {
+	data := bytes.Repeat([]byte("meow"), 4096)
+	sum := md5.Sum(data)
+	pkg := Package{
+		Name:     "content-meows.zip",
+		Checksum: hex.EncodeToString(sum[:]),
+		ZipSize:  int64(len(data)),
+	}
+
+	var ranges []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "-"+pkg.Name) {
+			http.NotFound(w, r)
+			return
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, pkg.Name, time.Time{}, bytes.NewReader(data))
+	}))
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), pkg.Checksum)
+	if err := os.WriteFile(dest+".part", data[:1000], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var last Progress
+	if err := pkg.Download(context.Background(), dest, srv.URL+"/version", func(p Progress) {
+		last = p
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ranges) != 1 || ranges[0] != "bytes=1000-" {
+		t.Errorf("got requested ranges %q, want resumed range", ranges)
+	}
+	if last.Current != last.Total || last.Total != pkg.ZipSize {
+		t.Errorf("got last progress %d/%d, want %d", last.Current, last.Total, pkg.ZipSize)
+	}
+	if err := pkg.Verify(dest); err != nil {
+		t.Error(err)
+	}
+
+	// A corrupted partial download is downloaded again from the start.
+	os.Remove(dest)
+	if err := os.WriteFile(dest+".part", []byte("hiss"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ranges = nil
+	if err := pkg.Download(context.Background(), dest, srv.URL+"/version", nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(ranges) != 2 || ranges[1] != "" {
+		t.Errorf("got requested ranges %q, want restarted download", ranges)
+	}
+	if _, err := os.Stat(dest + ".part"); !os.IsNotExist(err) {
+		t.Error("want partial download removed")
+	}
+}
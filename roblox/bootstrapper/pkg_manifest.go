@@ -1,6 +1,7 @@
 package bootstrapper
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -35,21 +36,36 @@ func channelPath(channel string) string {
 	return "/channel/" + channel + "/"
 }
 
-// FetchPackageManifest retrieves a package manifest for the given binary deployment.
-func FetchPackageManifest(d *Deployment) (PackageManifest, error) {
-	m, err := Mirror()
+// FetchPackageManifest retrieves a package manifest for the given binary
+// deployment from the fastest deploy mirror, failing over to the others.
+func FetchPackageManifest(ctx context.Context, d *Deployment) (PackageManifest, error) {
+	m, err := Mirror(ctx)
 	if err != nil {
 		return PackageManifest{}, fmt.Errorf("mirror: %w", err)
 	}
 
 	durl := m + channelPath(d.Channel) + d.GUID
-	url := durl + "-rbxPkgManifest.txt"
+	failed := map[string]bool{durl: true}
 
-	slog.Info("Fetching Package Manifest", "url", url)
+	var smanif string
+	for {
+		url := durl + "-rbxPkgManifest.txt"
+		slog.Info("Fetching Package Manifest", "url", url)
 
-	smanif, err := netutil.Body(url)
-	if err != nil {
-		return PackageManifest{}, err
+		smanif, err = netutil.Body(ctx, url)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return PackageManifest{}, err
+		}
+
+		next, ok := failover(durl, err)
+		if !ok || failed[next] {
+			return PackageManifest{}, err
+		}
+		failed[next] = true
+		durl = next
 	}
 
 	// Because the manifest ends with also a newline, it has to be removed.
@@ -0,0 +1,209 @@
+package bootstrapper
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Repair verifies the files extracted from the named package source file to
+// the destination directory dir against the checksums held in the package,
+// hashing the extracted files with the given amount of workers, and extracts
+// only the files which are missing or mismatched. Files for which skip
+// returns true are not verified. The paths of the repaired files are returned.
+func (p *Package) Repair(src, dir string, workers int, skip func(string) bool) ([]string, error) {
+	r, closeZip, err := openZip(src)
+	if err != nil {
+		return nil, err
+	}
+	defer closeZip()
+
+	files := make(chan *zip.File)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var bad []*zip.File
+	var errs []error
+
+	for i := 0; i < max(workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for f := range files {
+				ok, err := verifyFile(f, dir, skip)
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, err)
+				} else if !ok {
+					bad = append(bad, f)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, f := range r.File {
+		if !f.FileInfo().IsDir() {
+			files <- f
+		}
+	}
+	close(files)
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	repaired := make([]string, 0, len(bad))
+	for _, f := range bad {
+		dest, _ := extractPath(dir, f)
+
+		slog.Info("Repairing package file", "name", p.Name, "path", dest)
+
+		if err := extractFile(f, dest); err != nil {
+			return repaired, err
+		}
+
+		repaired = append(repaired, dest)
+	}
+
+	return repaired, nil
+}
+
+// verifyFile determines if the given file was extracted to dir intact.
+func verifyFile(f *zip.File, dir string, skip func(string) bool) (bool, error) {
+	dest, err := extractPath(dir, f)
+	if err != nil {
+		return false, err
+	}
+
+	if skip != nil && skip(dest) {
+		return true, nil
+	}
+
+	ok, err := CheckFile(dest, FileChecksum{Size: int64(f.UncompressedSize64), CRC32: f.CRC32})
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	return ok, err
+}
+
+// FileChecksum is the size and CRC-32 checksum of a file of a package,
+// as held in the package.
+type FileChecksum struct {
+	Size  int64  `json:"size"`
+	CRC32 uint32 `json:"crc32"`
+}
+
+// Checksums returns the checksums of the files within the named package
+// source file, keyed by their paths relative to the directory the package
+// is extracted to.
+func (p *Package) Checksums(src string) (map[string]FileChecksum, error) {
+	r, closeZip, err := openZip(src)
+	if err != nil {
+		return nil, fmt.Errorf("open package %s (%s): %w", p.Name, src, err)
+	}
+	defer closeZip()
+
+	sums := make(map[string]FileChecksum, len(r.File))
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		name := filepath.Clean(strings.ReplaceAll(f.Name, `\`, "/"))
+		if !filepath.IsLocal(name) {
+			return nil, fmt.Errorf("illegal file path: %s", f.Name)
+		}
+		sums[name] = FileChecksum{Size: int64(f.UncompressedSize64), CRC32: f.CRC32}
+	}
+
+	return sums, nil
+}
+
+// CheckFile determines if the named file matches the given checksum.
+// A missing file is an error matching [os.ErrNotExist].
+func CheckFile(name string, sum FileChecksum) (bool, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	fi, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	// Only hash the file if it could match.
+	if fi.Size() != sum.Size {
+		return false, nil
+	}
+
+	buf := extractBuffers.Get().(*[]byte)
+	defer extractBuffers.Put(buf)
+
+	h := crc32.NewIEEE()
+	if _, err := io.CopyBuffer(h, file, *buf); err != nil {
+		return false, err
+	}
+
+	return h.Sum32() == sum.CRC32, nil
+}
+
+// CheckFiles checks the files within the directory dir against the given
+// checksums keyed by their paths relative to dir, hashing the files with
+// the given amount of workers, and returns the relative paths of the files
+// which are missing and of those which are corrupted, sorted. Files for
+// which skip returns true are not checked.
+func CheckFiles(dir string, sums map[string]FileChecksum, workers int, skip func(string) bool) (missing, corrupted []string, err error) {
+	paths := make(chan string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var errs []error
+
+	for i := 0; i < max(workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for rel := range paths {
+				ok, err := CheckFile(filepath.Join(dir, rel), sums[rel])
+
+				mu.Lock()
+				switch {
+				case errors.Is(err, os.ErrNotExist):
+					missing = append(missing, rel)
+				case err != nil:
+					errs = append(errs, err)
+				case !ok:
+					corrupted = append(corrupted, rel)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for rel := range sums {
+		if skip == nil || !skip(rel) {
+			paths <- rel
+		}
+	}
+	close(paths)
+	wg.Wait()
+
+	sort.Strings(missing)
+	sort.Strings(corrupted)
+
+	return missing, corrupted, errors.Join(errs...)
+}
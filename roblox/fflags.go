@@ -10,6 +10,9 @@ import (
 
 var ErrInvalidRenderer = errors.New("invalid renderer given")
 
+// FPSFlag is the FFlag used by Roblox to limit its frame rate.
+const FPSFlag = "DFIntTaskSchedulerTargetFps"
+
 // defaultRenderer is used as the default renderer when
 // no explicit named renderer argument has been given.
 const DefaultRenderer = "D3D11"
@@ -88,3 +91,8 @@ func (f FFlags) SetRenderer(renderer string) error {
 
 	return nil
 }
+
+// SetFPS sets the named frame rate limit to the FFlags.
+func (f FFlags) SetFPS(fps int) {
+	f[FPSFlag] = fps
+}
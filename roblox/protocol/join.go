@@ -0,0 +1,64 @@
+package protocol
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PlaceLauncherURL is the join script URL used by Player to join a place.
+const PlaceLauncherURL = "https://assetgame.roblox.com/game/PlaceLauncher.ashx"
+
+// Join is a representation of a request to join a place, optionally to
+// a specific server or a private server by its link code.
+type Join struct {
+	PlaceID  string
+	JobID    string
+	LinkCode string
+}
+
+// PlaceLauncherURL returns the join script URL for the Join.
+func (j *Join) PlaceLauncherURL() string {
+	v := url.Values{}
+
+	switch {
+	case j.LinkCode != "":
+		v.Set("request", "RequestPrivateGame")
+		v.Set("linkCode", j.LinkCode)
+	case j.JobID != "":
+		v.Set("request", "RequestGameJob")
+		v.Set("gameId", j.JobID)
+	default:
+		v.Set("request", "RequestGame")
+	}
+
+	v.Set("placeId", j.PlaceID)
+	v.Set("isPlayTogetherGame", "false")
+
+	return PlaceLauncherURL + "?" + v.Encode()
+}
+
+// Experience returns the roblox scheme URL for the Join, which Player
+// authenticates by itself.
+func (j *Join) Experience() string {
+	v := url.Values{}
+	v.Set("placeId", j.PlaceID)
+
+	if j.JobID != "" {
+		v.Set("gameInstanceId", j.JobID)
+	}
+	if j.LinkCode != "" {
+		v.Set("linkCode", j.LinkCode)
+	}
+
+	return "roblox://experiences/start?" + v.Encode()
+}
+
+// Launch returns the roblox-player launch URI for the Join, authenticated
+// with the given authentication ticket.
+func (j *Join) Launch(ticket string) string {
+	return "roblox-player:1+launchmode:play" +
+		"+gameinfo:" + ticket +
+		"+launchtime:" + strconv.FormatInt(time.Now().UnixMilli(), 10) +
+		"+placelauncherurl:" + url.QueryEscape(j.PlaceLauncherURL())
+}
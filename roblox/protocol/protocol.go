@@ -0,0 +1,248 @@
+// Package protocol implements parsing of the URIs given to a Roblox
+// Binary by the browser, such as roblox-player: and roblox-studio:.
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotProtocol = errors.New("not a roblox protocol uri")
+	ErrBadField    = errors.New("malformed protocol field")
+)
+
+// Schemes is the list of URI schemes handled by Roblox binaries.
+var Schemes = []string{
+	"roblox-player",
+	"roblox-studio",
+	"roblox-studio-auth",
+	"roblox",
+}
+
+// URI is a representation of a Roblox protocol URI.
+//
+// The launch URIs (roblox-player:1+launchmode:play+...) are a set of
+// '+' separated key:value fields, while the 'roblox' and 'roblox-studio-auth'
+// schemes are more traditional URLs (roblox://experiences/start?placeId=...,
+// roblox-studio-auth:/?code=...). Both are
+// parsed into the same fields, and every field that was present is kept
+// within Fields, to retain unknown fields.
+type URI struct {
+	Scheme string
+
+	LaunchMode       string    // launchmode, such as 'play', 'edit' or 'app'
+	GameInfo         string    // gameinfo, the authentication ticket
+	LaunchTime       time.Time // launchtime
+	PlaceLauncherURL string    // placelauncherurl, the join script URL
+	BrowserTrackerID string    // browsertrackerid
+	RobloxLocale     string    // robloxLocale
+	GameLocale       string    // gameLocale
+	Channel          string    // channel
+	Task             string    // task, used by Studio, such as 'EditPlace'
+
+	PlaceID    string // placeId, or retrieved from PlaceLauncherURL
+	UniverseID string // universeId
+	UserID     string // userId
+	JobID      string // gameInstanceId, or retrieved from PlaceLauncherURL
+
+	// Fields contains all the fields present in the URI in their original
+	// order, including those not represented above.
+	Fields []Field
+
+	raw string
+}
+
+// Field is a key and value pair present in a URI.
+type Field struct {
+	Key   string
+	Value string
+}
+
+// IsProtocol determines if the named string is a Roblox protocol URI.
+func IsProtocol(s string) bool {
+	for _, scheme := range Schemes {
+		if strings.HasPrefix(s, scheme+":") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parse parses the named string into a URI.
+func Parse(s string) (*URI, error) {
+	scheme, rest, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || !IsProtocol(s) {
+		return nil, ErrNotProtocol
+	}
+
+	u := &URI{
+		Scheme: scheme,
+		raw:    s,
+	}
+
+	var err error
+	if strings.HasPrefix(rest, "//") || scheme == "roblox-studio-auth" {
+		err = u.parseURL(rest)
+	} else {
+		err = u.parseFields(rest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", scheme, err)
+	}
+
+	return u, nil
+}
+
+// parseFields parses the launch URI format, such as:
+//
+//	roblox-player:1+launchmode:play+gameinfo:TICKET+placelauncherurl:ESCAPED_URL
+//
+// Fields without a value are kept within Fields with an empty value.
+func (u *URI) parseFields(s string) error {
+	for i, f := range strings.Split(s, "+") {
+		if f == "" {
+			continue
+		}
+
+		// The first field is the version of the protocol, without a key.
+		if i == 0 {
+			if _, err := strconv.Atoi(f); err == nil {
+				continue
+			}
+		}
+
+		k, v, ok := strings.Cut(f, ":")
+		if !ok {
+			u.Fields = append(u.Fields, Field{Key: f})
+			continue
+		}
+
+		uv, err := url.QueryUnescape(v)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrBadField, k, err)
+		}
+
+		if err := u.set(k, uv); err != nil {
+			return err
+		}
+	}
+
+	return u.parsePlaceLauncher()
+}
+
+// parseURL parses the URL format, such as:
+//
+//	roblox://experiences/start?placeId=1818&gameInstanceId=UUID
+//	roblox://placeId=1818/
+//	roblox-studio-auth:/?code=CODE&state=STATE
+func (u *URI) parseURL(s string) error {
+	pu, err := url.Parse(u.Scheme + ":" + s)
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range []string{pu.Host, strings.Trim(pu.Path, "/")} {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			if err := u.set(k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	for k, vs := range pu.Query() {
+		if err := u.set(k, vs[0]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parsePlaceLauncher retrieves the PlaceID and JobID from the PlaceLauncherURL
+// if they were not already given by the URI.
+func (u *URI) parsePlaceLauncher() error {
+	if u.PlaceLauncherURL == "" {
+		return nil
+	}
+
+	pl, err := url.Parse(u.PlaceLauncherURL)
+	if err != nil {
+		return fmt.Errorf("%w: placelauncherurl: %w", ErrBadField, err)
+	}
+
+	q := pl.Query()
+	if u.PlaceID == "" {
+		u.PlaceID = q.Get("placeId")
+	}
+	if u.JobID == "" {
+		u.JobID = q.Get("gameId")
+	}
+
+	return nil
+}
+
+func (u *URI) set(k, v string) error {
+	u.Fields = append(u.Fields, Field{Key: k, Value: v})
+
+	switch strings.ToLower(k) {
+	case "launchmode":
+		u.LaunchMode = v
+	case "gameinfo":
+		u.GameInfo = v
+	case "launchtime":
+		if v == "" {
+			break
+		}
+
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: launchtime: %w", ErrBadField, err)
+		}
+		u.LaunchTime = time.UnixMilli(ms)
+	case "placelauncherurl":
+		u.PlaceLauncherURL = v
+	case "browsertrackerid":
+		u.BrowserTrackerID = v
+	case "robloxlocale":
+		u.RobloxLocale = v
+	case "gamelocale":
+		u.GameLocale = v
+	case "channel":
+		u.Channel = v
+	case "task":
+		u.Task = v
+	case "placeid":
+		u.PlaceID = v
+	case "universeid":
+		u.UniverseID = v
+	case "userid":
+		u.UserID = v
+	case "gameinstanceid":
+		u.JobID = v
+	}
+
+	return nil
+}
+
+// Field returns the value of the named field key, and whether it
+// was present in the URI. The key is case insensitive.
+func (u *URI) Field(key string) (string, bool) {
+	for _, f := range u.Fields {
+		if strings.EqualFold(f.Key, key) {
+			return f.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// String returns the URI in the form it was originally given.
+func (u *URI) String() string {
+	return u.raw
+}
@@ -0,0 +1,178 @@
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParsePlayer(t *testing.T) {
+	s := "roblox-player:1+launchmode:play+gameinfo:TICKETmeow+launchtime:1707064612123" +
+		"+placelauncherurl:https%3A%2F%2Fassetgame.roblox.com%2Fgame%2FPlaceLauncher.ashx%3Frequest%3DRequestGame" +
+		"%26browserTrackerId%3D123456%26placeId%3D1818%26isPlayTogetherGame%3Dfalse%26joinAttemptId%3Dabc" +
+		"+browsertrackerid:123456+robloxLocale:en_us+gameLocale:en_us+channel:zintegration+LaunchExp:InApp"
+
+	u, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Scheme != "roblox-player" {
+		t.Errorf("scheme %s, want roblox-player", u.Scheme)
+	}
+
+	if u.LaunchMode != "play" {
+		t.Errorf("launchmode %s, want play", u.LaunchMode)
+	}
+
+	if u.GameInfo != "TICKETmeow" {
+		t.Errorf("gameinfo %s, want ticket", u.GameInfo)
+	}
+
+	if !u.LaunchTime.Equal(time.UnixMilli(1707064612123)) {
+		t.Errorf("launchtime %s, want unix milli", u.LaunchTime)
+	}
+
+	if u.PlaceLauncherURL != "https://assetgame.roblox.com/game/PlaceLauncher.ashx?request=RequestGame"+
+		"&browserTrackerId=123456&placeId=1818&isPlayTogetherGame=false&joinAttemptId=abc" {
+		t.Errorf("placelauncherurl %s, want unescaped url", u.PlaceLauncherURL)
+	}
+
+	if u.PlaceID != "1818" {
+		t.Errorf("placeid %s, want placeid from placelauncherurl", u.PlaceID)
+	}
+
+	if u.Channel != "zintegration" {
+		t.Errorf("channel %s, want zintegration", u.Channel)
+	}
+
+	if v, ok := u.Field("launchexp"); !ok || v != "InApp" {
+		t.Errorf("field launchexp %s, want unknown field retained", v)
+	}
+
+	if u.String() != s {
+		t.Error("want original uri string")
+	}
+}
+
+func TestParseEmptyChannel(t *testing.T) {
+	u, err := Parse("roblox-player:1+launchmode:app+channel:+LaunchExp:InApp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Channel != "" {
+		t.Errorf("channel %s, want empty channel", u.Channel)
+	}
+
+	if _, ok := u.Field("channel"); !ok {
+		t.Error("want empty channel field present")
+	}
+}
+
+func TestParseStudio(t *testing.T) {
+	u, err := Parse("roblox-studio:1+launchmode:edit+task:EditPlace+placeId:1818+universeId:13058" +
+		"+userId:1+gameinfo:TICKET+launchtime:1707064612123+browsertrackerid:123456" +
+		"+robloxLocale:en_us+gameLocale:en_us+channel:+distributorType:Global")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.LaunchMode != "edit" || u.Task != "EditPlace" {
+		t.Errorf("launchmode %s task %s, want edit EditPlace", u.LaunchMode, u.Task)
+	}
+
+	if u.PlaceID != "1818" || u.UniverseID != "13058" || u.UserID != "1" {
+		t.Errorf("ids %s %s %s, want studio ids", u.PlaceID, u.UniverseID, u.UserID)
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	u, err := Parse("roblox://experiences/start?placeId=1818&gameInstanceId=2d3e4f5a-0000-1111-2222-333344445555")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.PlaceID != "1818" {
+		t.Errorf("placeid %s, want 1818", u.PlaceID)
+	}
+
+	if u.JobID != "2d3e4f5a-0000-1111-2222-333344445555" {
+		t.Errorf("jobid %s, want gameInstanceId", u.JobID)
+	}
+
+	u, err = Parse("roblox://placeId=1818/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.PlaceID != "1818" {
+		t.Errorf("placeid %s, want 1818", u.PlaceID)
+	}
+}
+
+func TestParseStudioAuth(t *testing.T) {
+	u, err := Parse("roblox-studio-auth:/?code=CODE&state=STATE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := u.Field("code"); v != "CODE" {
+		t.Errorf("code %q, want CODE", v)
+	}
+
+	if v, _ := u.Field("state"); v != "STATE" {
+		t.Errorf("state %q, want STATE", v)
+	}
+}
+
+func TestParseFieldless(t *testing.T) {
+	if _, err := Parse("roblox-studio:"); err != nil {
+		t.Errorf("bare scheme: %v", err)
+	}
+
+	u, err := Parse("roblox-player:1+launchmode:play+newtoken")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.LaunchMode != "play" {
+		t.Errorf("launchmode %s, want play", u.LaunchMode)
+	}
+
+	if _, ok := u.Field("newtoken"); !ok {
+		t.Error("expected fieldless token to be kept")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	if _, err := Parse("-app"); !errors.Is(err, ErrNotProtocol) {
+		t.Error("expected not protocol uri")
+	}
+
+	if _, err := Parse("roblox-player:1+launchtime:soon"); !errors.Is(err, ErrBadField) {
+		t.Error("expected malformed launchtime")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	j := Join{PlaceID: "1818", JobID: "meow"}
+
+	u, err := Parse(j.Launch("ticket"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.PlaceID != j.PlaceID || u.JobID != j.JobID || u.GameInfo != "ticket" {
+		t.Errorf("got %+v, want launch uri for %+v", u, j)
+	}
+
+	u, err = Parse(j.Experience())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.PlaceID != j.PlaceID || u.JobID != j.JobID {
+		t.Errorf("got %+v, want experience uri for %+v", u, j)
+	}
+}
@@ -0,0 +1,56 @@
+package roblox
+
+import (
+	"regexp"
+)
+
+// SettingsFile is the name of the Player's settings file, made by
+// the Player within the Roblox directory of the user's local AppData.
+const SettingsFile = "GlobalBasicSettings_13.xml"
+
+// Player settings properties of UserGameSettings.
+const (
+	QualityLevelSetting = "SavedQualityLevel"
+	VolumeSetting       = "MasterVolume"
+	FullscreenSetting   = "Fullscreen"
+	ScreenSizeSetting   = "StartScreenSize"
+)
+
+// DefaultSettings is a Player settings file without any properties set,
+// used to set properties before the Player makes its own settings file.
+const DefaultSettings = `<roblox version="4">
+	<External>null</External>
+	<External>nil</External>
+	<Item class="UserGameSettings" referent="RBX0">
+		<Properties>
+		</Properties>
+	</Item>
+</roblox>
+`
+
+var settingsProperties = regexp.MustCompile(`<Item class="UserGameSettings"[^>]*>\s*<Properties>`)
+
+// SetSetting sets the named property of the given type within the given
+// Player settings to value, replacing the property if present. It reports
+// whether the property could be set, which requires the UserGameSettings
+// properties to be present.
+func SetSetting(settings []byte, typ, name, value string) ([]byte, bool) {
+	prop := []byte("<" + typ + ` name="` + name + `">` + value + "</" + typ + ">")
+
+	re := regexp.MustCompile(`(?s)<(?:token|int|float|bool|Vector2) name="` +
+		regexp.QuoteMeta(name) + `">.*?</(?:token|int|float|bool|Vector2)>`)
+
+	if loc := re.FindIndex(settings); loc != nil {
+		return append(append(append([]byte{}, settings[:loc[0]]...), prop...), settings[loc[1]:]...), true
+	}
+
+	loc := settingsProperties.FindIndex(settings)
+	if loc == nil {
+		return settings, false
+	}
+
+	s := append([]byte{}, settings[:loc[1]]...)
+	s = append(s, "\n\t\t\t"...)
+	s = append(s, prop...)
+	return append(s, settings[loc[1]:]...), true
+}
@@ -0,0 +1,50 @@
+package roblox
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetSetting(t *testing.T) {
+	s := []byte(`<roblox>
+	<Item class="UserGameSettings" referent="RBX0">
+		<Properties>
+			<token name="SavedQualityLevel">3</token>
+			<Vector2 name="StartScreenSize">
+				<X>800</X>
+				<Y>600</Y>
+			</Vector2>
+		</Properties>
+	</Item>
+</roblox>`)
+
+	s, ok := SetSetting(s, "token", QualityLevelSetting, "10")
+	if !ok {
+		t.Fatal("expected quality level to be set")
+	}
+
+	s, _ = SetSetting(s, "Vector2", ScreenSizeSetting, "<X>1920</X><Y>1080</Y>")
+	s, _ = SetSetting(s, "float", VolumeSetting, "0.2")
+	want := `<roblox>
+	<Item class="UserGameSettings" referent="RBX0">
+		<Properties>
+			<float name="MasterVolume">0.2</float>
+			<token name="SavedQualityLevel">10</token>
+			<Vector2 name="StartScreenSize"><X>1920</X><Y>1080</Y></Vector2>
+		</Properties>
+	</Item>
+</roblox>`
+
+	if string(s) != want {
+		t.Errorf("got settings %s, want %s", s, want)
+	}
+
+	if _, ok := SetSetting([]byte("<roblox></roblox>"), "bool", FullscreenSetting, "true"); ok {
+		t.Error("expected setting to require UserGameSettings")
+	}
+
+	d, ok := SetSetting([]byte(DefaultSettings), "bool", FullscreenSetting, "true")
+	if !ok || !strings.Contains(string(d), `<bool name="Fullscreen">true</bool>`) {
+		t.Error("expected setting to be added to default settings")
+	}
+}
@@ -0,0 +1,57 @@
+package splash
+
+import (
+	"os"
+
+	"github.com/vinegarhq/vinegar/internal/cenv"
+)
+
+// Backend is an implementation of the splash window and its dialogs.
+type Backend interface {
+	Run() error
+	SetMessage(msg string)
+	SetDesc(desc string)
+	SetProgress(progress float32)
+	SetLogPath(path string)
+	Close()
+	IsClosed() bool
+	Dialog(txt string, user bool) bool
+}
+
+var _ Backend = (*Splash)(nil)
+
+// displays returns the environment variables of the display servers
+// the named backend must not connect to.
+func displays(backend string) []string {
+	switch backend {
+	case "wayland":
+		return []string{"DISPLAY"}
+	case "x11":
+		return []string{"WAYLAND_DISPLAY"}
+	default:
+		return nil
+	}
+}
+
+// hideDisplays hides the display servers the named backend must not connect
+// to, as the window will connect to the first display server it finds. The
+// returned function restores them.
+//
+// Only the C environment read by the display server libraries is changed,
+// as setup runs concurrently with the window: processes started by Vinegar
+// inherit the Go environment, and keep their display servers.
+func hideDisplays(backend string) func() {
+	hidden := make(map[string]string)
+	for _, name := range displays(backend) {
+		if v, ok := os.LookupEnv(name); ok {
+			hidden[name] = v
+			cenv.Unsetenv(name)
+		}
+	}
+
+	return func() {
+		for name, v := range hidden {
+			cenv.Setenv(name, v)
+		}
+	}
+}
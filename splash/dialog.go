@@ -66,8 +66,8 @@ func (ui *Splash) Dialog(txt string, user bool) (r bool) {
 	var yesButton widget.Clickable // Okay if !user
 	var noButton widget.Clickable
 
-	for {
-		switch e := w.NextEvent().(type) {
+	for e := connect(w, ui.Config.Backend); ; e = w.NextEvent() {
+		switch e := e.(type) {
 		case app.DestroyEvent:
 			return r
 		case app.FrameEvent:
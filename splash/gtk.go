@@ -0,0 +1,191 @@
+package splash
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/vinegarhq/vinegar/internal/portal"
+)
+
+// GTK is a backend which shows the splash as a GTK progress dialog and
+// its dialogs as GTK message dialogs, with zenity.
+type GTK struct {
+	Config *Config
+
+	mu       sync.Mutex
+	cmd      *exec.Cmd
+	in       io.WriteCloser
+	message  string
+	desc     string
+	progress float32
+	closed   bool
+	logPath  string
+}
+
+var _ Backend = (*GTK)(nil)
+
+// NewGTK returns a GTK backend, which only shows its dialogs if hidden.
+func NewGTK(cfg *Config, hidden bool) *GTK {
+	return &GTK{Config: cfg, closed: hidden}
+}
+
+// Run shows the progress dialog until it is closed, returning [ErrClosed]
+// if it was cancelled by the user.
+func (g *GTK) Run() error {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return nil
+	}
+
+	g.cmd = exec.Command("zenity", "--progress", "--title=Vinegar", "--width=384",
+		"--text="+g.text(), fmt.Sprintf("--percentage=%d", g.percentage()))
+
+	in, err := g.cmd.StdinPipe()
+	if err != nil {
+		g.mu.Unlock()
+		return err
+	}
+	g.in = in
+
+	if err := g.cmd.Start(); err != nil {
+		g.mu.Unlock()
+		return fmt.Errorf("zenity: %w", err)
+	}
+	g.mu.Unlock()
+
+	err = g.cmd.Wait()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return nil
+	}
+	g.closed = true
+
+	var ee *exec.ExitError
+	if err == nil || errors.As(err, &ee) {
+		return ErrClosed
+	}
+	return err
+}
+
+// text returns the progress dialog's text, escaped as it is Pango markup,
+// which must be called with the mutex held.
+func (g *GTK) text() string {
+	if g.desc == "" {
+		return html.EscapeString(g.message)
+	}
+
+	return html.EscapeString(g.message + "\n" + g.desc)
+}
+
+func (g *GTK) percentage() int {
+	return int(min(max(g.progress, 0), 1) * 99) // 100 finishes the dialog
+}
+
+// write writes the given line to the progress dialog, if it is shown,
+// which must be called with the mutex held.
+func (g *GTK) write(line string) {
+	if g.in == nil || g.closed {
+		return
+	}
+
+	if _, err := io.WriteString(g.in, line+"\n"); err != nil {
+		slog.Warn("Could not update GTK splash", "error", err)
+	}
+}
+
+func (g *GTK) SetMessage(msg string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.message = msg
+	g.write("# " + strings.ReplaceAll(g.text(), "\n", `\n`))
+}
+
+func (g *GTK) SetDesc(desc string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.desc = desc
+	g.write("# " + strings.ReplaceAll(g.text(), "\n", `\n`))
+}
+
+func (g *GTK) SetProgress(progress float32) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.progress = progress
+	g.write(fmt.Sprint(g.percentage()))
+}
+
+func (g *GTK) SetLogPath(path string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.logPath = path
+}
+
+func (g *GTK) Close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return
+	}
+	g.closed = true
+
+	if g.cmd != nil && g.cmd.Process != nil {
+		g.in.Close()
+		g.cmd.Process.Kill()
+	}
+}
+
+func (g *GTK) IsClosed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.closed
+}
+
+// Dialog shows the given text in a GTK message dialog. If user is true,
+// Dialog returns if 'Yes' was clicked rather than 'No'. The log file is
+// offered to be opened alongside an error.
+func (g *GTK) Dialog(txt string, user bool) bool {
+	g.mu.Lock()
+	logPath := g.logPath
+	g.mu.Unlock()
+
+	// The text is Pango markup, which errors and logs are not.
+	args := []string{"--title=Vinegar", "--width=384", "--text=" + html.EscapeString(txt)}
+	switch {
+	case user:
+		args = append([]string{"--question"}, args...)
+	case logPath != "":
+		args = append([]string{"--error", "--extra-button=Show Log"}, args...)
+	default:
+		args = append([]string{"--info"}, args...)
+	}
+
+	out, err := exec.Command("zenity", args...).Output()
+	if strings.TrimSpace(string(out)) == "Show Log" {
+		if err := portal.Open(logPath); err != nil {
+			slog.Error("Could not open log file", "error", err)
+		}
+	}
+
+	var ee *exec.ExitError
+	if err != nil && !errors.As(err, &ee) {
+		slog.Error("Could not show GTK dialog", "error", err, "text", txt)
+	}
+
+	return user && err == nil
+}
@@ -0,0 +1,80 @@
+package splash
+
+import (
+	"log/slog"
+	"os"
+	"os/exec"
+)
+
+// Kinds of backends, as set by Config.Kind.
+const (
+	KindAuto     = "auto"
+	KindWindow   = "window"   // the splash window
+	KindGTK      = "gtk"      // GTK dialogs shown with zenity
+	KindTerminal = "terminal" // drawn in the terminal by the caller
+	KindNone     = "none"     // nothing is shown
+)
+
+// KindEnv is the environment variable which overrides the configured kind,
+// named after the kind key of the splash table like other overrides.
+const KindEnv = "VINEGAR_SPLASH_KIND"
+
+// Kinds are the valid kinds of backends.
+var Kinds = []string{KindAuto, KindWindow, KindGTK, KindTerminal, KindNone}
+
+// Display determines if there is a display server to connect to, which
+// there isn't for headless and SSH sessions.
+func Display() bool {
+	return os.Getenv("WAYLAND_DISPLAY") != "" || os.Getenv("DISPLAY") != ""
+}
+
+// ResolveKind returns the kind of backend to use with the configuration,
+// given if Vinegar is ran in a terminal, with $VINEGAR_SPLASH_KIND taking
+// precedence over the configured kind. The window is used by default.
+//
+// Without a display server, or if the splash is disabled, the terminal
+// is used, or nothing if not ran in a terminal.
+func (c *Config) ResolveKind(terminal bool) string {
+	kind := c.Kind
+	if env := os.Getenv(KindEnv); env != "" {
+		kind = env
+	} else if !c.Enabled {
+		kind = KindTerminal
+	}
+
+	switch kind {
+	case KindWindow, KindGTK, KindTerminal, KindNone:
+	default:
+		kind = KindWindow
+	}
+
+	if (kind == KindWindow || kind == KindGTK) && !Display() {
+		slog.Info("No display server found, not showing the splash window")
+		kind = KindTerminal
+	}
+
+	if kind == KindTerminal && !terminal {
+		kind = KindNone
+	}
+
+	return kind
+}
+
+// NewBackend returns the backend of the given kind, which must not be the
+// terminal. Hidden backends only show their dialogs.
+func NewBackend(kind string, cfg *Config, hidden bool) Backend {
+	switch kind {
+	case KindNone:
+		return Null{}
+	case KindGTK:
+		if _, err := exec.LookPath("zenity"); err == nil {
+			return NewGTK(cfg, hidden)
+		}
+		slog.Warn("Zenity is required for GTK dialogs, using the splash window")
+	}
+
+	if hidden {
+		return NewHidden(cfg)
+	}
+	return New(cfg)
+}
@@ -0,0 +1,32 @@
+package splash
+
+import "testing"
+
+func TestResolveKind(t *testing.T) {
+	for _, tc := range []struct {
+		kind     string
+		enabled  bool
+		env      string
+		display  string
+		terminal bool
+		want     string
+	}{
+		{KindAuto, true, "", ":0", false, KindWindow},
+		{KindGTK, true, "", ":0", false, KindGTK},
+		{KindAuto, true, "", "", true, KindTerminal},
+		{KindAuto, true, "", "", false, KindNone},
+		{KindWindow, false, "", ":0", true, KindTerminal},
+		{KindWindow, false, "", ":0", false, KindNone},
+		{KindWindow, true, KindNone, ":0", true, KindNone},
+		{KindNone, false, KindGTK, ":0", false, KindGTK},
+	} {
+		t.Setenv("WAYLAND_DISPLAY", "")
+		t.Setenv("DISPLAY", tc.display)
+		t.Setenv(KindEnv, tc.env)
+
+		c := Config{Kind: tc.kind, Enabled: tc.enabled}
+		if got := c.ResolveKind(tc.terminal); got != tc.want {
+			t.Errorf("%+v: got kind %s, want %s", tc, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package splash
+
+import "log/slog"
+
+// Null is a backend which shows nothing, for headless sessions and
+// background tasks. Dialogs are logged, and their questions declined.
+type Null struct{}
+
+var _ Backend = Null{}
+
+func (Null) Run() error          { return nil }
+func (Null) SetMessage(string)   {}
+func (Null) SetDesc(string)      {}
+func (Null) SetProgress(float32) {}
+func (Null) SetLogPath(string)   {}
+func (Null) Close()              {}
+func (Null) IsClosed() bool      { return true }
+
+func (Null) Dialog(txt string, user bool) bool {
+	slog.Info("Dialog", "text", txt)
+	return false
+}
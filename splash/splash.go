@@ -10,9 +10,12 @@ import (
 	"io"
 	"log"
 	"os"
+	"sync"
+	"time"
 
 	"gioui.org/app"
 	"gioui.org/font/gofont"
+	"gioui.org/io/event"
 	"gioui.org/io/system"
 	"gioui.org/op"
 	"gioui.org/op/paint"
@@ -20,6 +23,7 @@ import (
 	"gioui.org/unit"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
+	"github.com/vinegarhq/vinegar/internal/portal"
 )
 
 //go:embed vinegar.png
@@ -27,8 +31,15 @@ var vinegarLogo []byte
 
 var ErrClosed = errors.New("window closed")
 
+// redrawInterval is the minimum interval between the redraws of the window
+// caused by changes of its state, for frequent changes such as the progress
+// of an extraction to not flood the window's event loop.
+const redrawInterval = time.Second / 30
+
 type Config struct {
 	Enabled     bool   `toml:"enabled"`     // Determines if splash is shown or not
+	Kind        string `toml:"kind"`        // Backend to use: auto, window, gtk, terminal or none
+	Backend     string `toml:"backend"`     // Display server to use: auto, wayland or x11
 	LogoPath    string `toml:"logo_path"`   // Logo file path used to load and render the logo
 	Style       string `toml:"style"`       // Style to use for the splash layout
 	BgColor     uint32 `toml:"background"`  // Foreground color
@@ -47,42 +58,73 @@ type Splash struct {
 	Style
 	LogPath string
 
-	logo    *image.Image
-	message string
-	desc    string
+	logo  *image.Image
+	first event.Event
 
+	// The window's state is changed by the setup while the window's event
+	// loop draws it, only queueing a redraw which is applied by the loop.
+	mu       sync.Mutex
+	message  string
+	desc     string
 	progress float32
 	closed   bool
+	redraw   chan struct{}
 
 	exitButton    *widget.Clickable
 	openLogButton *widget.Clickable
 }
 
 func (ui *Splash) SetMessage(msg string) {
-	if ui.Window == nil {
-		return
-	}
-
-	ui.message = msg
-	ui.Invalidate()
+	ui.update(func() { ui.message = msg })
 }
 
 func (ui *Splash) SetDesc(desc string) {
+	ui.update(func() { ui.desc = desc })
+}
+
+func (ui *Splash) SetProgress(progress float32) {
+	ui.update(func() { ui.progress = progress })
+}
+
+// update applies the given change to the window's state, and queues the
+// window to be redrawn without waiting for it. The queue holds only
+// a single redraw, as the latest state is drawn.
+func (ui *Splash) update(fn func()) {
 	if ui.Window == nil {
 		return
 	}
 
-	ui.desc = desc
-	ui.Invalidate()
+	ui.mu.Lock()
+	fn()
+	ui.mu.Unlock()
+
+	select {
+	case ui.redraw <- struct{}{}:
+	default:
+	}
 }
 
-func (ui *Splash) SetProgress(progress float32) {
-	if ui.Window == nil {
-		return
+// redraws invalidates the window when a redraw is queued, atmost every
+// redrawInterval, until done is closed.
+func (ui *Splash) redraws(done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-ui.redraw:
+			ui.Invalidate()
+		}
+
+		select {
+		case <-done:
+			return
+		case <-time.After(redrawInterval):
+		}
 	}
+}
 
-	ui.progress = progress
-	ui.Invalidate()
+func (ui *Splash) SetLogPath(path string) {
+	ui.LogPath = path
 }
 
 func (ui *Splash) Close() {
@@ -90,11 +132,17 @@ func (ui *Splash) Close() {
 		return
 	}
 
+	ui.mu.Lock()
 	ui.closed = true
+	ui.mu.Unlock()
+
 	ui.Perform(system.ActionClose)
 }
 
 func (ui *Splash) IsClosed() bool {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
 	return ui.closed
 }
 
@@ -108,6 +156,13 @@ func window(width, height unit.Dp) *app.Window {
 	)
 }
 
+// connect connects the window to the display server of the named backend,
+// returning the window's first event.
+func connect(w *app.Window, backend string) event.Event {
+	defer hideDisplays(backend)()
+	return w.NextEvent()
+}
+
 func New(cfg *Config) *Splash {
 	if !cfg.Enabled {
 		return &Splash{
@@ -116,15 +171,24 @@ func New(cfg *Config) *Splash {
 		}
 	}
 
+	ui := NewHidden(cfg)
+	ui.closed = false
+	ui.Window = window(ui.Style.Size())
+	ui.Perform(system.ActionCenter)
+	ui.first = connect(ui.Window, cfg.Backend)
+
+	return ui
+}
+
+// NewHidden returns a Splash without its window, for which
+// only dialogs are shown.
+func NewHidden(cfg *Config) *Splash {
 	s := Compact
 
 	if cfg.Style == "familiar" {
 		s = Familiar
 	}
 
-	w := window(s.Size())
-	w.Perform(system.ActionCenter)
-
 	th := material.NewTheme()
 	th.Shaper = text.NewShaper(text.WithCollection(gofont.Collection()))
 	th.Palette = material.Palette{
@@ -141,7 +205,8 @@ func New(cfg *Config) *Splash {
 		Theme:         th,
 		Style:         s,
 		Config:        cfg,
-		Window:        w,
+		closed:        true,
+		redraw:        make(chan struct{}, 1),
 		exitButton:    eb,
 		openLogButton: olb,
 	}
@@ -171,7 +236,7 @@ func (ui *Splash) loadLogo() error {
 }
 
 func (ui *Splash) Run() error {
-	if ui.closed {
+	if ui.IsClosed() {
 		return nil
 	}
 
@@ -181,20 +246,24 @@ func (ui *Splash) Run() error {
 		log.Println("Failed to load logo:", err)
 	}
 
-	defer func() {
-		ui.closed = true
-	}()
-
 	if ui.Style == Familiar {
 		drawfn = ui.drawFamiliar
 	}
 
-	ui.closed = false
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		ui.mu.Lock()
+		ui.closed = true
+		ui.mu.Unlock()
+	}()
+	go ui.redraws(done)
+
 	var ops op.Ops
-	for {
-		switch e := ui.NextEvent().(type) {
+	for e := ui.first; ; e = ui.NextEvent() {
+		switch e := e.(type) {
 		case app.DestroyEvent:
-			if ui.closed && e.Err == nil {
+			if ui.IsClosed() && e.Err == nil {
 				return nil
 			} else if e.Err == nil {
 				return ErrClosed
@@ -207,7 +276,7 @@ func (ui *Splash) Run() error {
 
 			if ui.openLogButton.Clicked(gtx) {
 				log.Printf("Opening log file: %s", ui.LogPath)
-				err := XDGOpen(ui.LogPath).Start()
+				err := portal.Open(ui.LogPath)
 				if err != nil {
 					return err
 				}
@@ -217,7 +286,9 @@ func (ui *Splash) Run() error {
 				ui.Perform(system.ActionClose)
 			}
 
+			ui.mu.Lock()
 			drawfn(gtx)
+			ui.mu.Unlock()
 
 			e.Frame(gtx.Ops)
 		}
@@ -1,15 +1,15 @@
 package splash
 
 import (
-	"os"
 	"os/exec"
+
+	"github.com/vinegarhq/vinegar/internal/portal"
 )
 
 // XDGOpen makes a *exec.Cmd with xdg-open as the named program.
+//
+// Deprecated: xdg-open cannot open files on the host within Flatpak,
+// which the splash backends open through the portal instead.
 func XDGOpen(file string) *exec.Cmd {
-	cmd := exec.Command("xdg-open", file)
-	cmd.Stderr = os.Stderr
-	cmd.Stdout = os.Stdout
-
-	return cmd
+	return portal.XDGOpen(file)
 }
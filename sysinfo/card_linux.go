@@ -35,6 +35,18 @@ func getCards() (cs []Card) {
 	return
 }
 
+// cardDrivers returns the base driver name of each card.
+func cardDrivers() (ds []string) {
+	drivers, _ := filepath.Glob(path.Join(drmPath, "card[0-9]", "device", "driver"))
+
+	for _, d := range drivers {
+		d, _ = filepath.EvalSymlinks(d)
+		ds = append(ds, path.Base(d))
+	}
+
+	return
+}
+
 // Walks over the drm path, and checks if there are any displays
 // that are matched with the card path and contain any of embeddedDisplays
 func embedded(cardPath string) (embed bool) {
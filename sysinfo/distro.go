@@ -6,7 +6,7 @@ import (
 	"strings"
 )
 
-func getDistro() (name string) {
+func getDistro() (name string, id string) {
 	name = "Linux"
 
 	f, err := os.Open("/etc/os-release")
@@ -30,6 +30,8 @@ func getDistro() (name string) {
 			name = val
 		case "VERSION_ID":
 			name += " " + val
+		case "ID":
+			id = val
 		}
 	}
 
@@ -0,0 +1,44 @@
+package sysinfo
+
+import (
+	"os"
+	"path"
+	"strings"
+)
+
+// Hardware is the information about the host's processor and GPUs, which
+// is expensive to detect and may be cached. It is only valid for the Key
+// it was detected with.
+type Hardware struct {
+	Key   string
+	CPU   Processor
+	Cards []Card
+}
+
+// HardwareKey returns the key identifying the host's kernel and GPU driver
+// versions, which changes if the information in [Hardware] could have.
+func HardwareKey() string {
+	key := []string{Kernel}
+
+	for _, d := range cardDrivers() {
+		v, _ := os.ReadFile(path.Join("/sys/module", d, "version"))
+		key = append(key, d+"="+strings.TrimSpace(string(v)))
+	}
+
+	return strings.Join(key, ",")
+}
+
+// DetectHardware detects the host's hardware information.
+func DetectHardware() Hardware {
+	return Hardware{
+		Key:   HardwareKey(),
+		CPU:   getCPU(),
+		Cards: getCards(),
+	}
+}
+
+// SetHardware sets CPU and Cards to the given hardware information.
+func SetHardware(h Hardware) {
+	CPU = h.CPU
+	Cards = h.Cards
+}
@@ -0,0 +1,45 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// inputMethods maps input method daemon process names to the name
+// of their X input method server.
+var inputMethods = map[string]string{
+	"fcitx5":      "fcitx",
+	"fcitx":       "fcitx",
+	"ibus-daemon": "ibus",
+	"ibus-x11":    "ibus",
+}
+
+func getInputMethod() string {
+	if _, im, ok := strings.Cut(os.Getenv("XMODIFIERS"), "@im="); ok && im != "" {
+		return im
+	}
+
+	for _, env := range []string{"GTK_IM_MODULE", "QT_IM_MODULE"} {
+		switch m := os.Getenv(env); {
+		case strings.HasPrefix(m, "fcitx"):
+			return "fcitx"
+		case m == "ibus":
+			return "ibus"
+		}
+	}
+
+	comms, _ := filepath.Glob("/proc/[0-9]*/comm")
+	for _, c := range comms {
+		b, err := os.ReadFile(c)
+		if err != nil {
+			continue
+		}
+
+		if im, ok := inputMethods[strings.TrimSpace(string(b))]; ok {
+			return im
+		}
+	}
+
+	return ""
+}
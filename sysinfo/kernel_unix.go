@@ -10,8 +10,19 @@ func getKernel() string {
 	var un unix.Utsname
 	_ = unix.Uname(&un)
 
+	return utsString(un.Release[:])
+}
+
+func getArch() string {
+	var un unix.Utsname
+	_ = unix.Uname(&un)
+
+	return utsString(un.Machine[:])
+}
+
+func utsString(field []byte) string {
 	var sb strings.Builder
-	for _, b := range un.Release[:] {
+	for _, b := range field {
 		if b == 0 {
 			break
 		}
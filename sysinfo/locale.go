@@ -0,0 +1,60 @@
+package sysinfo
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+func getLocale() string {
+	for _, env := range []string{"LC_ALL", "LANG"} {
+		if l := os.Getenv(env); l != "" && l != "C" && l != "POSIX" {
+			return l
+		}
+	}
+
+	return ""
+}
+
+// getKeyboardLayout returns the first XKB layout of the host, as
+// configured by localectl or the Debian keyboard configuration.
+func getKeyboardLayout() string {
+	if l := os.Getenv("XKB_DEFAULT_LAYOUT"); l != "" {
+		return firstLayout(l)
+	}
+
+	for _, c := range []struct{ path, key string }{
+		{"/etc/X11/xorg.conf.d/00-keyboard.conf", `Option "XkbLayout"`},
+		{"/etc/default/keyboard", "XKBLAYOUT="},
+	} {
+		if l := firstLayout(configValue(c.path, c.key)); l != "" {
+			return l
+		}
+	}
+
+	return ""
+}
+
+// configValue returns the unquoted value following key in the
+// named configuration file.
+func configValue(name, key string) string {
+	f, err := os.Open(name)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if _, v, ok := strings.Cut(strings.TrimSpace(s.Text()), key); ok {
+			return strings.Trim(strings.TrimSpace(v), `"`)
+		}
+	}
+
+	return ""
+}
+
+func firstLayout(layouts string) string {
+	l, _, _ := strings.Cut(layouts, ",")
+	return strings.TrimSpace(l)
+}
@@ -0,0 +1,26 @@
+package sysinfo
+
+import (
+	"os"
+	"strings"
+)
+
+func getSteamDeck() bool {
+	b, err := os.ReadFile("/sys/devices/virtual/dmi/id/product_name")
+	if err != nil {
+		return false
+	}
+
+	// LCD and OLED models respectively
+	switch strings.TrimSpace(string(b)) {
+	case "Jupiter", "Galileo":
+		return true
+	}
+
+	return false
+}
+
+func getGamingMode() bool {
+	return os.Getenv("SteamGamepadUI") == "1" ||
+		strings.EqualFold(os.Getenv("XDG_CURRENT_DESKTOP"), "gamescope")
+}
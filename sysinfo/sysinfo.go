@@ -7,18 +7,42 @@ import (
 )
 
 var (
-	Kernel    string
-	CPU       Processor
-	Cards     []Card
+	Arch   string
+	Kernel string
+
+	// CPU and Cards are only set once detected or loaded from Vinegar's
+	// cache with [SetHardware].
+	CPU   Processor
+	Cards []Card
+
 	Distro    string
+	DistroID  string
 	InFlatpak bool
+
+	// InputMethod is the name of the running X input method server,
+	// such as fcitx or ibus.
+	InputMethod string
+
+	// Locale is the host's locale, such as en_US.UTF-8, and KeyboardLayout
+	// the host's first XKB keyboard layout, such as us.
+	Locale         string
+	KeyboardLayout string
+
+	// SteamDeck determines if the host is a Steam Deck, and GamingMode
+	// if running within SteamOS's Gaming Mode (gamescope session).
+	SteamDeck  bool
+	GamingMode bool
 )
 
 func init() {
+	Arch = getArch()
 	Kernel = getKernel()
-	CPU = getCPU()
-	Cards = getCards()
-	Distro = getDistro()
+	Distro, DistroID = getDistro()
+	InputMethod = getInputMethod()
+	Locale = getLocale()
+	KeyboardLayout = getKeyboardLayout()
+	SteamDeck = getSteamDeck()
+	GamingMode = getGamingMode()
 
 	_, err := os.Stat("/.flatpak-info")
 	InFlatpak = err == nil
@@ -0,0 +1,191 @@
+// Package build implements installing Wine and Proton builds, which are
+// used as the wine root of a [wine.Runner].
+package build
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var ErrBadEntry = errors.New("bad build archive entry")
+
+// Build is a Wine or Proton build, distributed as a tarball holding
+// its installation within a single directory.
+type Build struct {
+	Name string
+	URL  string
+}
+
+// Builds are the known builds, which can be installed by their name.
+var Builds = []Build{
+	{
+		Name: "GE-Proton9-1",
+		URL:  "https://github.com/GloriousEggroll/proton-ge-custom/releases/download/GE-Proton9-1/GE-Proton9-1.tar.gz",
+	},
+	{
+		Name: "wine-lutris-GE-Proton8-26",
+		URL:  "https://github.com/GloriousEggroll/wine-ge-custom/releases/download/GE-Proton8-26/wine-lutris-GE-Proton8-26-x86_64.tar.xz",
+	},
+	{
+		Name: "wine-9.0-staging",
+		URL:  "https://github.com/Kron4ek/Wine-Builds/releases/download/9.0/wine-9.0-staging-amd64.tar.xz",
+	},
+}
+
+// Find returns the known build with the given name, if any.
+func Find(name string) (Build, bool) {
+	for _, b := range Builds {
+		if b.Name == name {
+			return b, true
+		}
+	}
+
+	return Build{}, false
+}
+
+// FromURL returns the build of the given tarball URL, named after
+// the tarball.
+func FromURL(url string) Build {
+	name := path.Base(url)
+	for _, ext := range []string{".tar.gz", ".tgz", ".tar.xz", ".txz"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	return Build{Name: name, URL: url}
+}
+
+// Extract extracts the build tarball at the named path to the directory
+// dir, without the tarball's top directory. Tarballs compressed with xz
+// are decompressed with the system's xz.
+func Extract(name, dir string) error {
+	slog.Info("Extracting build", "file", name, "dir", dir)
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if !strings.HasSuffix(name, "xz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+
+		return extractTar(tar.NewReader(zr), dir)
+	}
+
+	cmd := exec.Command("xz", "-dc")
+	cmd.Stdin = f
+
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("xz: %w", err)
+	}
+
+	if err := extractTar(tar.NewReader(out), dir); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+
+	// The padding after the tar archive.
+	if _, err := io.Copy(io.Discard, out); err != nil {
+		return err
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("xz: %w", err)
+	}
+
+	return nil
+}
+
+func extractTar(tr *tar.Reader, dir string) error {
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// The top directory, such as 'GE-Proton9-1/'.
+		_, rel, ok := strings.Cut(strings.TrimPrefix(hdr.Name, "./"), "/")
+		if !ok || rel == "" {
+			continue
+		}
+		rel = filepath.FromSlash(strings.TrimSuffix(rel, "/"))
+
+		if !filepath.IsLocal(rel) {
+			return fmt.Errorf("%w: %s", ErrBadEntry, hdr.Name)
+		}
+
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(p, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(p, tr, hdr.FileInfo().Mode()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			// Symlinks may not point outside of the build, for files
+			// to not be extracted through them.
+			if filepath.IsAbs(hdr.Linkname) ||
+				!filepath.IsLocal(filepath.Join(filepath.Dir(rel), hdr.Linkname)) {
+				return fmt.Errorf("%w: %s -> %s", ErrBadEntry, hdr.Name, hdr.Linkname)
+			}
+
+			if err := os.Symlink(hdr.Linkname, p); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			_, target, _ := strings.Cut(strings.TrimPrefix(hdr.Linkname, "./"), "/")
+			target = filepath.FromSlash(target)
+			if !filepath.IsLocal(target) {
+				return fmt.Errorf("%w: %s => %s", ErrBadEntry, hdr.Name, hdr.Linkname)
+			}
+
+			if err := os.Link(filepath.Join(dir, target), p); err != nil {
+				return err
+			}
+		default:
+			slog.Warn("Skipping build unhandled file", "file", hdr.Name)
+		}
+	}
+}
+
+func writeFile(name string, r io.Reader, perm os.FileMode) error {
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm.Perm())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,89 @@
+package build
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type entry struct {
+	name, link string
+	typ        byte
+}
+
+func writeTarball(t *testing.T, name string, entries []entry) {
+	t.Helper()
+
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	tw := tar.NewWriter(zw)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Linkname: e.link, Typeflag: e.typ, Mode: 0o755}
+		if e.typ == tar.TypeReg {
+			hdr.Size = int64(len(e.name))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if e.typ == tar.TypeReg {
+			tw.Write([]byte(e.name))
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tmp := t.TempDir()
+	src := filepath.Join(tmp, "GE-Proton9-1.tar.gz")
+	writeTarball(t, src, []entry{
+		{"GE-Proton9-1/", "", tar.TypeDir},
+		{"GE-Proton9-1/proton", "", tar.TypeReg},
+		{"GE-Proton9-1/files/bin/wine64", "", tar.TypeReg},
+		{"GE-Proton9-1/files/bin/wine", "wine64", tar.TypeSymlink},
+		{"GE-Proton9-1/files/bin/wine64-preloader", "GE-Proton9-1/files/bin/wine64", tar.TypeLink},
+	})
+
+	dir := filepath.Join(tmp, "runner")
+	if err := Extract(src, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []string{"proton", "files/bin/wine", "files/bin/wine64-preloader"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Error(err)
+		}
+	}
+
+	bad := filepath.Join(tmp, "bad.tar.gz")
+	writeTarball(t, bad, []entry{
+		{"bad/lib", "../../../etc", tar.TypeSymlink},
+	})
+	if err := Extract(bad, filepath.Join(tmp, "bad")); !errors.Is(err, ErrBadEntry) {
+		t.Errorf("got %v for escaping symlink, want %v", err, ErrBadEntry)
+	}
+}
+
+func TestFromURL(t *testing.T) {
+	b := FromURL("https://example.com/releases/wine-9.0-staging-amd64.tar.xz")
+	if b.Name != "wine-9.0-staging-amd64" {
+		t.Errorf("got build name %s, want tarball name", b.Name)
+	}
+
+	if _, ok := Find("GE-Proton9-1"); !ok {
+		t.Error("want known build found")
+	}
+}
@@ -4,11 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/fs"
 	"os"
 	"os/exec"
 )
 
+// Cmd is a program being prepared or run within a Prefix.
 type Cmd struct {
 	*exec.Cmd
 }
@@ -29,6 +29,12 @@ func (p *Prefix) Command(name string, arg ...string) *Cmd {
 	cmd.Env = append(cmd.Environ(),
 		"WINEPREFIX="+p.dir,
 	)
+	cmd.Env = append(cmd.Env, p.Runner.Env(p.data)...)
+
+	if len(p.Overrides) > 0 {
+		o := ParseOverrides(os.Getenv("WINEDLLOVERRIDES")).Merge(p.Overrides)
+		cmd.Env = append(cmd.Env, "WINEDLLOVERRIDES="+o.String())
+	}
 
 	cmd.Stderr = p.Stderr
 	cmd.Stdout = p.Stdout
@@ -61,12 +67,9 @@ func (c *Cmd) Start() error {
 			return fmt.Errorf("stderr pipe: %w", err)
 		}
 
-		go func() {
-			_, err := io.Copy(pfxStderr, cmdErrPipe)
-			if err != nil && !errors.Is(err, fs.ErrClosed) {
-				panic(err)
-			}
-		}()
+		// The pipe is closed by Wait, and writing to the
+		// Prefix's Stderr may fail; neither is of concern.
+		go io.Copy(pfxStderr, cmdErrPipe)
 	}
 
 	return c.Cmd.Start()
@@ -16,13 +16,23 @@ import (
 
 const Repo = "https://github.com/doitsujin/dxvk"
 
+// Overrides are the DLL overrides telling Wine to use the DXVK DLLs,
+// which may be set as a [wine.Prefix]'s Overrides instead of with Setenv.
+var Overrides = wine.Overrides{
+	"d3d9":      "n",
+	"d3d10core": "n",
+	"d3d11":     "n",
+	"dxgi":      "n",
+}
+
 // Setenv sets/appends WINEDLLOVERRIDES to tell Wine to use the DXVK DLLs.
 //
 // This is required to call inorder to tell Wine to use DXVK.
 func Setenv() {
 	slog.Info("Enabling WINE DXVK DLL overrides")
 
-	os.Setenv("WINEDLLOVERRIDES", os.Getenv("WINEDLLOVERRIDES")+";d3d10core=n;d3d11=n;d3d9=n;dxgi=n")
+	o := wine.ParseOverrides(os.Getenv("WINEDLLOVERRIDES")).Merge(Overrides)
+	os.Setenv("WINEDLLOVERRIDES", o.String())
 }
 
 // Remove removes the DXVK overridden DLLs from the given wineprefix, then
@@ -0,0 +1,68 @@
+package wine_test
+
+import (
+	"log"
+	"os"
+
+	"github.com/vinegarhq/vinegar/wine"
+)
+
+func ExampleNew() {
+	// The system's Wine installation.
+	r, err := wine.Lookup("", "wine")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pfx, err := wine.New("/home/meow/.local/share/wineprefixes/meow", r)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := pfx.Init(); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("Initialized", pfx, "with", pfx.Version())
+}
+
+func ExamplePrefix_Wine() {
+	r, err := wine.Lookup(os.Getenv("WINEROOT"), "")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pfx, err := wine.New("/home/meow/.wine", r)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Use the native d3d11 and dxgi DLLs placed within the wineprefix.
+	pfx.Overrides = wine.Overrides{"d3d11": "n", "dxgi": "n"}
+
+	if err := pfx.RegistryAdd(`HKEY_CURRENT_USER\Software\Wine\X11 Driver`,
+		"UseTakeFocus", wine.REG_SZ, "N"); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := pfx.Wine("winecfg").Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleEmulate() {
+	r, err := wine.Lookup("/opt/wine", "")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Run the x86_64 Wine installation with FEX-Emu on ARM64.
+	pfx, err := wine.New("/home/meow/.wine", wine.Emulate(r, "/usr/bin/FEXInterpreter"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := pfx.Wine("explorer.exe").Run(); err != nil {
+		log.Fatal(err)
+	}
+}
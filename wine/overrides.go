@@ -0,0 +1,62 @@
+package wine
+
+import (
+	"sort"
+	"strings"
+)
+
+// Overrides are the DLL overrides given to Wine with WINEDLLOVERRIDES,
+// keyed by the name of the DLL, with their load order such as "n,b"
+// (native, then builtin), or empty to disable the DLL.
+type Overrides map[string]string
+
+// ParseOverrides returns the DLL overrides of the given WINEDLLOVERRIDES
+// value, in which the DLLs of each entry are separated by commas and
+// the entries by semicolons, such as "d3d11,dxgi=n;mshtml=".
+func ParseOverrides(s string) Overrides {
+	o := make(Overrides)
+
+	for _, e := range strings.Split(s, ";") {
+		dlls, order, _ := strings.Cut(e, "=")
+
+		for _, dll := range strings.Split(dlls, ",") {
+			if dll = strings.TrimSpace(dll); dll != "" {
+				o[dll] = strings.TrimSpace(order)
+			}
+		}
+	}
+
+	return o
+}
+
+// Merge returns the DLL overrides with the given overrides taking
+// precedence over them.
+func (o Overrides) Merge(over Overrides) Overrides {
+	m := make(Overrides, len(o)+len(over))
+	for dll, order := range o {
+		m[dll] = order
+	}
+	for dll, order := range over {
+		m[dll] = order
+	}
+
+	return m
+}
+
+// String returns the WINEDLLOVERRIDES value of the DLL overrides, with
+// the DLLs sorted and those with the same load order grouped together.
+func (o Overrides) String() string {
+	orders := make(map[string][]string)
+	for dll, order := range o {
+		orders[order] = append(orders[order], dll)
+	}
+
+	entries := make([]string, 0, len(orders))
+	for order, dlls := range orders {
+		sort.Strings(dlls)
+		entries = append(entries, strings.Join(dlls, ",")+"="+order)
+	}
+	sort.Strings(entries)
+
+	return strings.Join(entries, ";")
+}
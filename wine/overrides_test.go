@@ -0,0 +1,34 @@
+package wine
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestOverrides(t *testing.T) {
+	o := ParseOverrides("dxdiagn,winemenubuilder.exe,mscoree,mshtml=;d3d11=n;;dxgi =n,b")
+	want := Overrides{
+		"dxdiagn":             "",
+		"winemenubuilder.exe": "",
+		"mscoree":             "",
+		"mshtml":              "",
+		"d3d11":               "n",
+		"dxgi":                "n,b",
+	}
+	if !maps.Equal(o, want) {
+		t.Fatalf("parsed %v, want %v", o, want)
+	}
+
+	m := o.Merge(Overrides{"dxgi": "n", "d3d9": "n"})
+	if m["dxgi"] != "n" || m["d3d9"] != "n" || o["dxgi"] != "n,b" {
+		t.Errorf("merged %v, want overridden dxgi and added d3d9", m)
+	}
+
+	s := Overrides{"dxgi": "n", "d3d11": "n", "mshtml": ""}.String()
+	if s != "d3d11,dxgi=n;mshtml=" {
+		t.Errorf("got %q, want sorted and grouped overrides", s)
+	}
+	if !maps.Equal(ParseOverrides(s), Overrides{"dxgi": "n", "d3d11": "n", "mshtml": ""}) {
+		t.Errorf("overrides %q did not round trip", s)
+	}
+}
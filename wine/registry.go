@@ -4,6 +4,8 @@ import (
 	"errors"
 )
 
+var ErrNoRegistryKey = errors.New("no registry key given")
+
 // RegistryType is the type of registry that the wine 'reg' program
 // can accept.
 type RegistryType string
@@ -21,8 +23,22 @@ const (
 // RegistryAdd adds a new registry key to the Prefix with the named key, value, type, and data.
 func (p *Prefix) RegistryAdd(key, value string, rtype RegistryType, data string) error {
 	if key == "" {
-		return errors.New("no registry key given")
+		return ErrNoRegistryKey
 	}
 
 	return p.Wine("reg", "add", key, "/v", value, "/t", string(rtype), "/d", data, "/f").Run()
 }
+
+// RegistryDelete deletes the named value of the named registry key from
+// the Prefix, or the key itself if the value is empty.
+func (p *Prefix) RegistryDelete(key, value string) error {
+	if key == "" {
+		return ErrNoRegistryKey
+	}
+
+	if value == "" {
+		return p.Wine("reg", "delete", key, "/f").Run()
+	}
+
+	return p.Wine("reg", "delete", key, "/v", value, "/f").Run()
+}
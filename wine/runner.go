@@ -0,0 +1,355 @@
+package wine
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNoProton      = errors.New("proton script not found in wineroot")
+	ErrUnknownRunner = errors.New("unknown runner")
+)
+
+// Runner runs Windows programs within a Prefix, such as with Wine or Proton.
+type Runner interface {
+	// String returns the name of the Runner's backend.
+	String() string
+
+	// Dir returns the wineprefix kept within the Prefix's directory dir.
+	Dir(dir string) string
+
+	// Command returns the program and its arguments to run the named
+	// Windows program with.
+	Command(exe string, arg ...string) (string, []string)
+
+	// Env returns the additional environment to run programs with within
+	// the Prefix's directory dir.
+	Env(dir string) []string
+
+	// Server returns the program and its arguments to run the wineserver
+	// with, if the Runner's Wine installation has one.
+	Server(arg ...string) (string, []string, error)
+
+	// Version returns the version of the Runner's Wine installation.
+	Version(p *Prefix) string
+
+	// Paths returns the files the Runner runs Windows programs from,
+	// which are empty for the system's Wine installation.
+	Paths() []string
+}
+
+// Backend returns a Runner with the Wine installation at the named root,
+// which uses the backend's own default installation if empty.
+type Backend func(root string) (Runner, error)
+
+var backends = map[string]Backend{
+	"wine":   NewWine,
+	"proton": NewProton,
+	"ulwgl":  NewULWGL,
+}
+
+// Register registers the named Backend, for it to be used with [Lookup].
+func Register(name string, b Backend) {
+	backends[name] = b
+}
+
+// Backends returns the names of the registered Backends, sorted.
+func Backends() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func ulwgl(root string) bool {
+	r := strings.ToLower(root)
+	return strings.Contains(r, "ulwgl") || strings.Contains(r, "umu")
+}
+
+// Detect returns the name of the Backend of the named wine root, which
+// will be Proton if the wine root contains a Proton script.
+func Detect(root string) string {
+	if root == "" {
+		return "wine"
+	}
+
+	if ulwgl(root) {
+		return "ulwgl"
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "proton")); err == nil {
+		return "proton"
+	}
+
+	return "wine"
+}
+
+// Lookup returns the Runner of the named Backend for the named wine root.
+// If the backend is empty, it will be detected with [Detect].
+func Lookup(root string, backend string) (Runner, error) {
+	if root != "" && !filepath.IsAbs(root) {
+		return nil, ErrWineRootAbs
+	}
+
+	if backend == "" {
+		backend = Detect(root)
+	}
+
+	b, ok := backends[backend]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRunner, backend)
+	}
+
+	return b(root)
+}
+
+// WineRunner runs Windows programs with the wine executable.
+type WineRunner struct {
+	root string
+	wine string
+}
+
+// NewWine returns a WineRunner using the Wine installation at
+// the named root, or the system's Wine installation if empty.
+func NewWine(root string) (Runner, error) {
+	// Kept for configurations setting the wine runner with
+	// a ULWGL wine root, which was previously allowed.
+	if ulwgl(root) {
+		return NewULWGL(root)
+	}
+
+	w, err := Wine64(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WineRunner{root: root, wine: w}, nil
+}
+
+func (r *WineRunner) String() string { return "wine" }
+
+func (r *WineRunner) Dir(dir string) string { return dir }
+
+func (r *WineRunner) Command(exe string, arg ...string) (string, []string) {
+	return r.wine, append([]string{exe}, arg...)
+}
+
+func (r *WineRunner) Env(dir string) []string { return nil }
+
+func (r *WineRunner) Server(arg ...string) (string, []string, error) {
+	ws := filepath.Join(filepath.Dir(r.wine), "wineserver")
+	if _, err := os.Stat(ws); err != nil {
+		return "", nil, err
+	}
+
+	return ws, arg, nil
+}
+
+func (r *WineRunner) Version(p *Prefix) string { return commandVersion(p) }
+
+func (r *WineRunner) Paths() []string { return rootPaths(r.root) }
+
+// ProtonRunner runs Windows programs with the Proton script of a Proton
+// installation, which keeps the wineprefix in a 'pfx' directory
+// of its compatibility data directory.
+type ProtonRunner struct {
+	root   string
+	script string
+}
+
+// NewProton returns a ProtonRunner using the Proton installation at
+// the named root. Without a root or with a ULWGL root, a ULWGLRunner
+// is returned instead, which runs Proton by itself.
+func NewProton(root string) (Runner, error) {
+	if root == "" || ulwgl(root) {
+		return NewULWGL(root)
+	}
+
+	s := filepath.Join(root, "proton")
+	if _, err := os.Stat(s); err != nil {
+		return nil, ErrNoProton
+	}
+
+	return &ProtonRunner{root: root, script: s}, nil
+}
+
+func (r *ProtonRunner) String() string { return "proton" }
+
+// Dir returns the wineprefix within Proton's compatibility data directory dir.
+func (r *ProtonRunner) Dir(dir string) string { return filepath.Join(dir, "pfx") }
+
+// Command returns the Proton script with its run verb, which sets up the
+// wineprefix before running the program, which wineboot would usually do.
+func (r *ProtonRunner) Command(exe string, arg ...string) (string, []string) {
+	return r.script, append([]string{"run", exe}, arg...)
+}
+
+// Env returns Proton's compatibility data directory and Steam's client
+// installation path, which is required by Proton but only used for Steam
+// integration.
+func (r *ProtonRunner) Env(dir string) []string {
+	return []string{
+		"STEAM_COMPAT_DATA_PATH=" + dir,
+		"STEAM_COMPAT_CLIENT_INSTALL_PATH=" + dir,
+	}
+}
+
+func (r *ProtonRunner) Server(arg ...string) (string, []string, error) {
+	for _, dir := range []string{
+		filepath.Join(r.root, "files", "bin"),
+		filepath.Join(r.root, "dist", "bin"), // Proton 5.0 and older
+	} {
+		ws := filepath.Join(dir, "wineserver")
+		if _, err := os.Stat(ws); err == nil {
+			return ws, arg, nil
+		}
+	}
+
+	return "", nil, os.ErrNotExist
+}
+
+// Version returns the version name of the Proton installation,
+// which is kept in its version file.
+func (r *ProtonRunner) Version(p *Prefix) string {
+	v, err := os.ReadFile(filepath.Join(r.root, "version"))
+	if err != nil {
+		slog.Error("Could not read Proton version", "error", err)
+		return "unknown"
+	}
+
+	// timestamp name
+	f := strings.Fields(string(v))
+	if len(f) == 0 {
+		return "unknown"
+	}
+
+	return f[len(f)-1]
+}
+
+func (r *ProtonRunner) Paths() []string { return rootPaths(r.root) }
+
+// ULWGLRunner runs Windows programs with the [ULWGL launcher] (or umu),
+// which runs Proton outside of Steam.
+//
+// [ULWGL launcher]: https://github.com/Open-Wine-Components/ULWGL-launcher
+type ULWGLRunner struct {
+	root     string
+	launcher string
+}
+
+// NewULWGL returns a ULWGLRunner using the ULWGL launcher within the named
+// root, or the ULWGL launcher from $PATH if empty.
+func NewULWGL(root string) (Runner, error) {
+	if root == "" {
+		for _, l := range []string{"umu-run", "ulwgl-run"} {
+			if p, err := exec.LookPath(l); err == nil {
+				return &ULWGLRunner{launcher: p}, nil
+			}
+		}
+
+		return nil, fmt.Errorf("proton: %w", exec.ErrNotFound)
+	}
+
+	slog.Info("Detected ULWGL Wineroot!")
+
+	l := filepath.Join(root, "ulwgl-run")
+	if _, err := os.Stat(l); err != nil {
+		l = filepath.Join(root, "umu-run")
+	}
+
+	p, err := exec.LookPath(l)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ULWGLRunner{root: root, launcher: p}, nil
+}
+
+func (r *ULWGLRunner) String() string { return "ulwgl" }
+
+func (r *ULWGLRunner) Dir(dir string) string { return dir }
+
+func (r *ULWGLRunner) Command(exe string, arg ...string) (string, []string) {
+	return r.launcher, append([]string{exe}, arg...)
+}
+
+func (r *ULWGLRunner) Env(dir string) []string {
+	return []string{"STORE=none", "PROTON_VERB=runinprefix"}
+}
+
+// Server returns no wineserver, as the ULWGL launcher has none.
+func (r *ULWGLRunner) Server(arg ...string) (string, []string, error) {
+	return "", nil, os.ErrNotExist
+}
+
+func (r *ULWGLRunner) Version(p *Prefix) string { return commandVersion(p) }
+
+func (r *ULWGLRunner) Paths() []string { return rootPaths(r.root) }
+
+// EmulatedRunner runs the Windows programs of its Runner with an x86_64
+// emulator, such as FEX-Emu or box64.
+type EmulatedRunner struct {
+	Runner
+
+	// Emulator is the path to the x86_64 emulator.
+	Emulator string
+}
+
+// Emulate returns the Runner wrapped with the x86_64 emulator at the named
+// path, or the Runner itself if empty.
+func Emulate(r Runner, emulator string) Runner {
+	if emulator == "" {
+		return r
+	}
+
+	return &EmulatedRunner{Runner: r, Emulator: emulator}
+}
+
+func (r *EmulatedRunner) Command(exe string, arg ...string) (string, []string) {
+	name, arg := r.Runner.Command(exe, arg...)
+	return r.Emulator, append([]string{name}, arg...)
+}
+
+func (r *EmulatedRunner) Server(arg ...string) (string, []string, error) {
+	name, arg, err := r.Runner.Server(arg...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return r.Emulator, append([]string{name}, arg...), nil
+}
+
+func (r *EmulatedRunner) Paths() []string {
+	return append(r.Runner.Paths(), r.Emulator)
+}
+
+func rootPaths(root string) []string {
+	if root == "" {
+		return nil
+	}
+
+	return []string{root}
+}
+
+// commandVersion returns the version reported by the Prefix's Wine.
+func commandVersion(p *Prefix) string {
+	cmd := p.Wine("--version")
+	cmd.Stdout = nil // required for Output()
+	cmd.Stderr = nil
+
+	ver, _ := cmd.Output()
+	if len(ver) == 0 {
+		return "unknown"
+	}
+
+	return strings.TrimSpace(string(ver))
+}
@@ -0,0 +1,114 @@
+package wine
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeExecutable writes an empty executable file at the named path.
+func writeExecutable(t *testing.T, name string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	wineRoot := t.TempDir()
+	writeExecutable(t, filepath.Join(wineRoot, "bin", "wine64"))
+	writeExecutable(t, filepath.Join(wineRoot, "bin", "wineserver"))
+
+	protonRoot := t.TempDir()
+	writeExecutable(t, filepath.Join(protonRoot, "proton"))
+	writeExecutable(t, filepath.Join(protonRoot, "files", "bin", "wineserver"))
+
+	if b := Detect(wineRoot); b != "wine" {
+		t.Errorf("detected %s for wine root, want wine", b)
+	}
+	if b := Detect(protonRoot); b != "proton" {
+		t.Errorf("detected %s for proton root, want proton", b)
+	}
+	if b := Detect("/opt/ULWGL-launcher"); b != "ulwgl" {
+		t.Errorf("detected %s for ulwgl root, want ulwgl", b)
+	}
+
+	r, err := Lookup(wineRoot, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, arg := r.Command("notepad.exe", "meow.txt")
+	if name != filepath.Join(wineRoot, "bin", "wine64") || !slices.Equal(arg, []string{"notepad.exe", "meow.txt"}) {
+		t.Errorf("wine command is %s %v", name, arg)
+	}
+	if ws, _, err := r.Server("-k"); err != nil || ws != filepath.Join(wineRoot, "bin", "wineserver") {
+		t.Errorf("wine server is %s (%v)", ws, err)
+	}
+
+	r, err = Lookup(protonRoot, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, arg = r.Command("notepad.exe")
+	if name != filepath.Join(protonRoot, "proton") || !slices.Equal(arg, []string{"run", "notepad.exe"}) {
+		t.Errorf("proton command is %s %v", name, arg)
+	}
+	if d := r.Dir("/compat"); d != filepath.Join("/compat", "pfx") {
+		t.Errorf("proton wineprefix is %s, want within compat data", d)
+	}
+
+	if _, err := Lookup(wineRoot, "proton"); !errors.Is(err, ErrNoProton) {
+		t.Errorf("got %v for wine root as proton, want %v", err, ErrNoProton)
+	}
+	if _, err := Lookup("wine", ""); !errors.Is(err, ErrWineRootAbs) {
+		t.Errorf("got %v for relative root, want %v", err, ErrWineRootAbs)
+	}
+	if _, err := Lookup(wineRoot, "meow"); !errors.Is(err, ErrUnknownRunner) {
+		t.Errorf("got %v for unknown backend, want %v", err, ErrUnknownRunner)
+	}
+	if _, err := Lookup(protonRoot, "wine"); !errors.Is(err, ErrWineNotFound) {
+		t.Errorf("got %v for proton root as wine, want %v", err, ErrWineNotFound)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("fake", func(string) (Runner, error) { return &fakeRunner{}, nil })
+	defer delete(backends, "fake")
+
+	if !slices.Contains(Backends(), "fake") {
+		t.Errorf("backends %v are missing registered backend", Backends())
+	}
+
+	r, err := Lookup("", "fake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "fake" {
+		t.Errorf("looked up %s, want registered backend", r)
+	}
+}
+
+func TestEmulate(t *testing.T) {
+	r := &fakeRunner{}
+	if Emulate(r, "") != Runner(r) {
+		t.Error("want runner without emulator unwrapped")
+	}
+
+	e := Emulate(r, "/usr/bin/FEXInterpreter")
+	name, arg := e.Command("notepad.exe")
+	if name != "/usr/bin/FEXInterpreter" || !slices.Equal(arg, []string{"echo", "notepad.exe"}) {
+		t.Errorf("emulated command is %s %v", name, arg)
+	}
+	if _, _, err := e.Server("-k"); err == nil {
+		t.Error("want no emulated wineserver for runner without one")
+	}
+	if p := e.Paths(); !slices.Equal(p, []string{"/usr/bin/FEXInterpreter"}) {
+		t.Errorf("emulated paths are %v, want emulator", p)
+	}
+}
@@ -1,16 +1,34 @@
 // Package wine implements wine program command routines for
 // interacting with a wineprefix [Prefix]
+//
+// A Prefix runs Windows programs with a [Runner], such as Wine, Proton
+// or the ULWGL launcher, which is looked up for a Wine installation with
+// [Lookup]. Runners for other backends may be added with [Register].
+//
+//	r, err := wine.Lookup("/opt/wine-ge", "")
+//	if err != nil {
+//		return err
+//	}
+//
+//	pfx, err := wine.New(dir, r)
+//	if err != nil {
+//		return err
+//	}
+//
+//	pfx.Overrides = wine.Overrides{"mshtml": ""}
+//	err = pfx.Wine("notepad.exe").Run()
+//
+// The package does not depend on Roblox, and may be used by other
+// programs which manage wineprefixes.
 package wine
 
 import (
 	"errors"
 	"fmt"
 	"io"
-	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strings"
 )
 
 var (
@@ -21,17 +39,26 @@ var (
 // Prefix is a representation of a wineprefix, which is where
 // WINE stores its data and is equivalent to a C:\ drive.
 type Prefix struct {
-	// Path to a wine installation.
-	Root string
+	// Runner is the Runner used to run Windows programs within the Prefix,
+	// which may be wrapped with an x86_64 emulator with [Emulate].
+	Runner Runner
 
 	// Stdout and Stderr specify the descendant Prefix wine call's
 	// standard output and error. This is mostly reserved for logging purposes.
 	// By default, they will be set to their os counterparts.
+	//
+	// Stderr is written to from its own goroutine, which is not waited
+	// for by [Cmd.Wait], and must be safe to use alongside Stdout.
 	Stderr io.Writer
 	Stdout io.Writer
 
-	wine string
+	// Overrides are the DLL overrides of the programs run within the
+	// Prefix, which take precedence over the WINEDLLOVERRIDES of the
+	// environment.
+	Overrides Overrides
+
 	dir  string
+	data string // directory given to New, which may hold the wineprefix
 }
 
 func (p Prefix) String() string {
@@ -39,10 +66,6 @@ func (p Prefix) String() string {
 }
 
 // Wine64 returns a path to the system or wineroot's 'wine64'.
-// Wine64 will attempt to resolve for a [ULWGL launcher] if
-// it is present and set necessary environment variables.
-//
-// [ULWGL launcher]: https://github.com/Open-Wine-Components/ULWGL-launcher
 func Wine64(root string) (string, error) {
 	wineLook := "wine64"
 
@@ -51,50 +74,36 @@ func Wine64(root string) (string, error) {
 			return "", ErrWineRootAbs
 		}
 
-		if strings.Contains(strings.ToLower(root), "ulwgl") {
-			slog.Info("Detected ULWGL Wineroot!")
-
-			wineLook = filepath.Join(root, "ulwgl-run")
-			os.Setenv("STORE", "none")
-		} else {
-			wineLook = filepath.Join(root, "bin", wineLook)
-		}
+		wineLook = filepath.Join(root, "bin", wineLook)
 	}
 
 	wine, err := exec.LookPath(wineLook)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("%w: %w", ErrWineNotFound, err)
 	}
 
 	return wine, nil
 }
 
-// New returns a new Prefix.
-//
-// [Wine64] will be used to verify the named root or if
-// wine is installed; it will be looked in $PATH only once -
-// if the wine executable changes it will not be re-looked.
+// New returns a new Prefix using the given Runner, which may be
+// looked up with [Lookup].
 //
 // dir must be an absolute path and has correct permissions
-// to modify.
-func New(dir string, root string) (*Prefix, error) {
-	w, err := Wine64(root)
-	if err != nil {
-		return nil, fmt.Errorf("bad wine: %w", err)
-	}
-
+// to modify. The Runner may keep the wineprefix within dir,
+// such as Proton within its compatibility data directory.
+func New(dir string, r Runner) (*Prefix, error) {
 	// Always ensure its created, wine will complain if the root
 	// directory doesnt exist
 	if err := os.MkdirAll(dir, 0o755); err != nil {
-		return nil, fmt.Errorf("create prefix: %s", err)
+		return nil, fmt.Errorf("create prefix: %w", err)
 	}
 
 	return &Prefix{
-		Root:   root,
+		Runner: r,
 		Stderr: os.Stderr,
 		Stdout: os.Stdout,
-		wine:   w,
-		dir:    dir,
+		dir:    r.Dir(dir),
+		data:   dir,
 	}, nil
 }
 
@@ -103,16 +112,10 @@ func (p *Prefix) Dir() string {
 	return p.dir
 }
 
-// Wine returns a new Cmd with the prefix's Wine as the named program.
+// Wine returns a new Cmd with the prefix's Runner running the named program.
 func (p *Prefix) Wine(exe string, arg ...string) *Cmd {
-	arg = append([]string{exe}, arg...)
-	cmd := p.Command(p.wine, arg...)
-
-	if strings.Contains(strings.ToLower(p.wine), "ulwgl") {
-		cmd.Env = append(cmd.Environ(), "PROTON_VERB=runinprefix")
-	}
-
-	return cmd
+	name, arg := p.Runner.Command(exe, arg...)
+	return p.Command(name, arg...)
 }
 
 // Kill kills the Prefix's processes.
@@ -120,6 +123,18 @@ func (p *Prefix) Kill() error {
 	return p.Wine("wineboot", "-k").Run()
 }
 
+// ServerKill kills the Prefix's wineserver, and with it all of the Prefix's
+// processes. If the Runner has no wineserver, such as with ULWGL,
+// [Prefix.Kill] is used instead.
+func (p *Prefix) ServerKill() error {
+	name, arg, err := p.Runner.Server("-k")
+	if err != nil {
+		return p.Kill()
+	}
+
+	return p.Command(name, arg...).Run()
+}
+
 // Init preforms initialization for first Wine instance.
 func (p *Prefix) Init() error {
 	return p.Wine("wineboot", "-i").Run()
@@ -132,15 +147,5 @@ func (p *Prefix) Update() error {
 
 // Version returns the wineprefix's Wine version.
 func (p *Prefix) Version() string {
-	cmd := p.Wine("--version")
-	cmd.Stdout = nil // required for Output()
-	cmd.Stderr = nil
-
-	ver, _ := cmd.Output()
-	if len(ver) < 0 {
-		return "unknown"
-	}
-
-	// remove newline
-	return string(ver[:len(ver)-1])
+	return p.Runner.Version(p)
 }
@@ -0,0 +1,105 @@
+package wine
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeRunner echoes the Windows programs it runs with their arguments,
+// and has no wineserver.
+type fakeRunner struct{}
+
+func (*fakeRunner) String() string        { return "fake" }
+func (*fakeRunner) Dir(dir string) string { return dir }
+func (*fakeRunner) Env(string) []string   { return []string{"FAKE=1"} }
+func (*fakeRunner) Version(*Prefix) string {
+	return "fake-1.0"
+}
+func (*fakeRunner) Paths() []string { return nil }
+
+func (*fakeRunner) Command(exe string, arg ...string) (string, []string) {
+	return "echo", append([]string{exe}, arg...)
+}
+
+func (*fakeRunner) Server(...string) (string, []string, error) {
+	return "", nil, os.ErrNotExist
+}
+
+func newFakePrefix(t *testing.T) (*Prefix, *bytes.Buffer) {
+	t.Helper()
+
+	pfx, err := New(filepath.Join(t.TempDir(), "pfx"), &fakeRunner{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	pfx.Stdout = &out
+	pfx.Stderr = io.Discard
+	return pfx, &out
+}
+
+func TestPrefixCommand(t *testing.T) {
+	t.Setenv("WINEDLLOVERRIDES", "mshtml=;d3d11=b")
+
+	pfx, out := newFakePrefix(t)
+	if _, err := os.Stat(pfx.Dir()); err != nil {
+		t.Fatalf("want wineprefix directory created: %s", err)
+	}
+
+	pfx.Overrides = Overrides{"d3d11": "n"}
+	cmd := pfx.Wine("notepad.exe", "meow.txt")
+	for _, e := range []string{"WINEPREFIX=" + pfx.Dir(), "FAKE=1", "WINEDLLOVERRIDES=d3d11=n;mshtml="} {
+		if !slices.Contains(cmd.Env, e) {
+			t.Errorf("environment %v is missing %s", cmd.Env, e)
+		}
+	}
+
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "notepad.exe meow.txt" {
+		t.Errorf("ran %q, want program run by runner", got)
+	}
+
+	// Without a wineserver, the wineprefix is killed with wineboot.
+	out.Reset()
+	if err := pfx.ServerKill(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "wineboot -k" {
+		t.Errorf("killed with %q, want wineboot", got)
+	}
+
+	if v := pfx.Version(); v != "fake-1.0" {
+		t.Errorf("got version %s, want runner's version", v)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	pfx, out := newFakePrefix(t)
+
+	if err := pfx.RegistryAdd(`HKEY_CURRENT_USER\Control Panel\Desktop`, "LogPixels", REG_DWORD, "96"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pfx.RegistryDelete(`HKEY_CURRENT_USER\Software\Meow`, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `reg add HKEY_CURRENT_USER\Control Panel\Desktop /v LogPixels /t REG_DWORD /d 96 /f
+reg delete HKEY_CURRENT_USER\Software\Meow /f
+`
+	if out.String() != want {
+		t.Errorf("ran %q, want %q", out.String(), want)
+	}
+
+	if err := pfx.RegistryAdd("", "meow", REG_SZ, ""); !errors.Is(err, ErrNoRegistryKey) {
+		t.Errorf("got %v, want %v", err, ErrNoRegistryKey)
+	}
+}